@@ -8,11 +8,16 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/urfave/cli/v3"
 
 	output "github.com/openstatusHQ/cli/internal/cli"
+	"github.com/openstatusHQ/cli/internal/config"
+	"github.com/openstatusHQ/cli/internal/curl"
 )
 
 func CheckCmd() *cli.Command {
@@ -25,7 +30,14 @@ func CheckCmd() *cli.Command {
   openstatus check https://openstat.us -X POST -H 'Authorization: Bearer …' -d '{"ping":true}'
   openstatus check https://openstat.us -d @payload.json
   openstatus check https://openstat.us --timing
-  openstatus check https://openstat.us --json | jq '.summary'`,
+  openstatus check https://openstat.us --json | jq '.summary'
+  openstatus check https://openstat.us --expect-status 200 --max-latency 800ms --min-success-rate 0.95
+  openstatus check https://openstat.us --regions ams,fra,iad --expect-header 'Content-Type: text/html'
+  openstatus check https://openstat.us -X POST -d @payload.json --save-as api-ping
+  openstatus check --from-curl "curl -X POST https://openstat.us -H 'Content-Type: application/json' --data-raw '{}'"
+  openstatus check --compare https://old.openstat.us https://new.openstat.us --timing
+  openstatus check https://openstat.us --json > before.json && openstatus check --baseline before.json
+  openstatus check https://openstat.us --count 5 --interval 30s`,
 		Description: `Run a one-shot HTTP check against a URL from 28 global regions.
 
 The check is executed by the public OpenStatus speed checker. No API token is
@@ -35,6 +47,43 @@ Output is sorted in the order regions report back (roughly fastest first).
 Pass --timing to see DNS/Connection/TLS/TTFB/Transfer phase breakdowns.
 Pass --json for a machine-readable single object including all phase data.
 
+Expectations (--expect-status, --expect-header, --expect-body-contains,
+--max-latency, --min-success-rate) are evaluated once every region has
+reported; the command exits with status 1 when one of them fails, which
+makes it usable as a CI gate. Header and body expectations need the checker
+to report the responses of every region; regions that do not report them
+fail the expectation. --regions only keeps the results of the given region
+codes, for the table, the summary and the expectations.
+
+--save-as appends the check to the monitors file (--config, openstatus.yaml
+by default) as an HTTP monitor running every 10 minutes in the regions that
+succeeded, asserting the status code they observed and the expectations of
+the check. Authorization, Cookie and X-Api-Key headers are saved as
+${secret:NAME} references, to set in a --var-file before applying. Nothing
+is saved when an expectation fails. Run 'openstatus monitors apply' to
+create it.
+
+--from-curl takes the request from a curl command, e.g. one copied from the
+browser devtools, instead of the URL, --method, --header and --body. It
+understands -X, -H, -d/--data-raw/--data-binary (with @file), -u, -A, -e,
+-b, -I, -L, -k and --compressed; options that change the request in a way
+the checker cannot reproduce are rejected. -k is ignored.
+
+--compare runs the same request against two URLs at once and prints the
+latency, status and timing phases of every region side by side, with the
+change from the first URL to the second. --baseline compares a new run with
+a check saved with --json, against the URL of the saved check unless another
+URL is given. --json does not print the Authorization, Proxy-Authorization,
+Cookie and X-Api-Key headers, so pass them again with -H. Expectations and
+--save-as cannot be combined with them.
+
+--count runs the check several times, --interval apart, and aggregates the
+minimum, median and maximum latency and the success rate of every region
+over all runs. When the checker rate limits a run, it is retried once the
+limit allows it instead of failing. A run failing for another reason is
+reported and the next one still runs; the command then exits non-zero.
+--timing cannot be combined with --count.
+
 Rate limit: 3 requests per 60 seconds.`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
@@ -53,51 +102,117 @@ Rate limit: 3 requests per 60 seconds.`,
 				Aliases: []string{"d"},
 				Usage:   "Request body. Use @filename to read a file, @- for stdin.",
 			},
+			&cli.StringFlag{
+				Name:  "from-curl",
+				Usage: "Take the request from a curl command instead of the URL, --method, --header and --body",
+			},
+			&cli.BoolFlag{
+				Name:  "compare",
+				Usage: "Compare two URLs region by region",
+			},
+			&cli.StringFlag{
+				Name:  "baseline",
+				Usage: "Compare with a check saved with --json",
+			},
+			&cli.IntFlag{
+				Name:  "count",
+				Usage: "Run the check this many times and aggregate the results",
+				Value: 1,
+			},
+			&cli.DurationFlag{
+				Name:  "interval",
+				Usage: "Time between the runs of --count",
+				Value: rateLimitWindow,
+			},
 			&cli.BoolFlag{
 				Name:  "timing",
 				Usage: "Show DNS/Connection/TLS/TTFB/Transfer phases",
 			},
+			&cli.IntFlag{
+				Name:  "expect-status",
+				Usage: "Fail unless every region gets this HTTP status code",
+			},
+			&cli.StringSliceFlag{
+				Name:  "expect-header",
+				Usage: "Fail unless every response has this header, in \"Key: Value\" or \"Key\" form (repeatable)",
+			},
+			&cli.StringFlag{
+				Name:  "expect-body-contains",
+				Usage: "Fail unless every response body contains this text",
+			},
+			&cli.DurationFlag{
+				Name:  "max-latency",
+				Usage: "Fail if any region is slower than this, e.g. 800ms",
+			},
+			&cli.FloatFlag{
+				Name:  "min-success-rate",
+				Usage: "Fail if fewer regions succeed than this share, from 0 to 1, e.g. 0.95",
+			},
+			&cli.StringSliceFlag{
+				Name:  "regions",
+				Usage: "Only keep the results of these region codes, e.g. ams,fra,iad",
+			},
+			&cli.StringFlag{
+				Name:  "save-as",
+				Usage: "Append the check to the monitors file as a monitor with this name",
+			},
+			&cli.StringFlag{
+				Name:  "config",
+				Usage: "The monitors file --save-as appends to",
+				Value: "openstatus.yaml",
+			},
 		},
 		Action: runCheck,
 	}
 }
 
 func runCheck(ctx context.Context, cmd *cli.Command) error {
-	rawURL := cmd.Args().Get(0)
-	if rawURL == "" {
-		return cli.Exit("URL is required.\n\nUsage: openstatus check <URL>\nExample: openstatus check https://openstat.us", 1)
+	if cmd.Bool("compare") || cmd.IsSet("baseline") {
+		return runCompare(ctx, cmd)
 	}
-	if err := validateURL(rawURL); err != nil {
-		return cli.Exit(err.Error(), 1)
+	if cmd.IsSet("count") || cmd.IsSet("interval") {
+		return runRepeat(ctx, cmd)
 	}
 
-	headers, err := parseHeaders(cmd.StringSlice("header"))
+	payload, err := buildPayload(cmd, "")
 	if err != nil {
 		return cli.Exit(err.Error(), 1)
 	}
 
-	body, err := resolveBody(cmd.String("body"))
+	expectedHeaders, err := parseExpectedHeaders(cmd.StringSlice("expect-header"))
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+	expect := Expectations{
+		Status:         int(cmd.Int("expect-status")),
+		Headers:        expectedHeaders,
+		BodyContains:   cmd.String("expect-body-contains"),
+		MaxLatency:     cmd.Duration("max-latency"),
+		MinSuccessRate: cmd.Float("min-success-rate"),
+	}
+	if err := expect.validate(); err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+	regions, err := parseRegions(cmd.StringSlice("regions"))
 	if err != nil {
 		return cli.Exit(err.Error(), 1)
 	}
 
-	payload := Payload{
-		URL:     rawURL,
-		Method:  strings.ToUpper(cmd.String("method")),
-		Headers: headers,
-		Body:    body,
+	saveAs := strings.TrimSpace(cmd.String("save-as"))
+	if cmd.IsSet("save-as") && saveAs == "" {
+		return cli.Exit("invalid --save-as: name is empty", 1)
 	}
 
 	timing := cmd.Bool("timing")
 
-	spinner := output.StartSpinner(fmt.Sprintf("Checking %s…", rawURL))
+	spinner := output.StartSpinner(fmt.Sprintf("Checking %s…", payload.URL))
 	var renderer *Renderer
 	if !output.IsJSONOutput() && !output.IsQuiet() {
 		renderer = NewRenderer(os.Stdout, timing)
 	}
 
 	onRow := func(r RegionResult) {
-		if renderer == nil {
+		if renderer == nil || (len(regions) > 0 && !slices.Contains(regions, r.Region)) {
 			return
 		}
 		output.StopSpinner(spinner)
@@ -105,23 +220,281 @@ func runCheck(ctx context.Context, cmd *cli.Command) error {
 		renderer.Row(r)
 	}
 
-	results, checkID, runErr := Run(ctx, nil, payload, onRow)
+	run := Run
+	if expect.needsResponses() {
+		run = RunWithResponses
+	}
+	results, checkID, runErr := run(ctx, nil, payload, onRow)
 	output.StopSpinner(spinner)
 
 	if runErr != nil {
 		return formatRunError(runErr)
 	}
+	results = filterRegions(results, regions)
 
-	if output.IsJSONOutput() {
-		return output.PrintJSON(buildJSONOutput(payload.URL, checkID, results))
+	var assertions []AssertionResult
+	if !expect.IsZero() {
+		assertions = expect.Evaluate(results)
 	}
 
-	if renderer != nil {
+	if output.IsJSONOutput() {
+		out := buildJSONOutput(payload, checkID, results)
+		if !expect.IsZero() {
+			out.Summary.setAssertions(assertions)
+		}
+		if err := output.PrintJSON(out); err != nil {
+			return err
+		}
+	} else if renderer != nil {
 		renderer.Footer(payload.URL, results, checkID)
+		renderer.Assertions(assertions)
+	}
+
+	if len(regions) > 0 && len(results) == 0 {
+		return cli.Exit("None of the selected regions reported a result.", 1)
+	}
+	if !allPassed(assertions) {
+		return cli.Exit("Check failed: some expectations were not met.", 1)
+	}
+
+	if saveAs != "" {
+		path := cmd.String("config")
+		monitor, err := monitorFromCheck(saveAs, payload, results, expect)
+		if err != nil {
+			return cli.Exit(err.Error(), 1)
+		}
+		var secrets []string
+		monitor.Request.Headers, secrets = config.SecretHeaders(saveAs, monitor.Request.Headers)
+		if err := config.AppendMonitor(path, saveAs, monitor); err != nil {
+			return cli.Exit(fmt.Sprintf("Failed to save monitor: %s", err), 1)
+		}
+		if len(secrets) > 0 {
+			fmt.Fprintf(os.Stderr, "Credentials were saved as secret references: set %s in a --var-file or the environment before applying.\n", strings.Join(secrets, ", "))
+		}
+		if !output.IsJSONOutput() && !output.IsQuiet() {
+			fmt.Printf("\nMonitor %q saved to %s\n", saveAs, path)
+			fmt.Println("Run 'openstatus monitors apply' to create it")
+		}
+	}
+	return nil
+}
+
+// runCompare runs two checks, or one against a baseline, and compares them
+// region by region.
+func runCompare(ctx context.Context, cmd *cli.Command) error {
+	compare, baselinePath := cmd.Bool("compare"), cmd.String("baseline")
+	if compare && cmd.IsSet("baseline") {
+		return cli.Exit("--compare and --baseline cannot be combined.", 1)
+	}
+	for _, name := range []string{"expect-status", "expect-header", "expect-body-contains", "max-latency", "min-success-rate", "save-as", "count", "interval"} {
+		if cmd.IsSet(name) {
+			return cli.Exit(fmt.Sprintf("--%s cannot be combined with --compare or --baseline.", name), 1)
+		}
+	}
+	regions, err := parseRegions(cmd.StringSlice("regions"))
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+
+	var base JSONOutput
+	var payloads []Payload
+	if compare {
+		if cmd.IsSet("from-curl") || cmd.Args().Len() != 2 {
+			return cli.Exit("--compare takes two URLs.\n\nUsage: openstatus check --compare <URL> <URL>\nExample: openstatus check --compare https://old.openstat.us https://new.openstat.us", 1)
+		}
+		payload, err := buildPayload(cmd, "")
+		if err != nil {
+			return cli.Exit(err.Error(), 1)
+		}
+		other := payload
+		other.URL = cmd.Args().Get(1)
+		if err := validateURL(other.URL); err != nil {
+			return cli.Exit(err.Error(), 1)
+		}
+		payloads = []Payload{payload, other}
+	} else {
+		base, err = readBaseline(baselinePath)
+		if err != nil {
+			return cli.Exit(err.Error(), 1)
+		}
+		payload, err := buildPayload(cmd, base.URL)
+		if err != nil {
+			return cli.Exit(err.Error(), 1)
+		}
+		payload, err = baselineRequest(base, payload, cmd.IsSet)
+		if err != nil {
+			return cli.Exit(err.Error(), 1)
+		}
+		payloads = []Payload{payload}
+	}
+
+	spinner := output.StartSpinner("Checking…")
+	outputs := make([]JSONOutput, len(payloads))
+	errs := make([]error, len(payloads))
+	var wg sync.WaitGroup
+	for i, payload := range payloads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results, checkID, err := Run(ctx, nil, payload, nil)
+			outputs[i] = buildJSONOutput(payload, checkID, filterRegions(results, regions))
+			errs[i] = err
+		}()
+	}
+	wg.Wait()
+	output.StopSpinner(spinner)
+	for _, err := range errs {
+		if err != nil {
+			return formatRunError(err)
+		}
+	}
+
+	if compare {
+		base = outputs[0]
+	} else {
+		base.Results = filterRegions(base.Results, regions)
+		base.Summary = computeSummary(base.Results)
+	}
+	out := compareResults(base, outputs[len(outputs)-1])
+
+	if output.IsJSONOutput() {
+		return output.PrintJSON(out)
+	}
+	if !output.IsQuiet() {
+		NewRenderer(os.Stdout, cmd.Bool("timing")).Compare(out)
 	}
 	return nil
 }
 
+// runRepeat runs the check --count times and aggregates the results of
+// every region.
+func runRepeat(ctx context.Context, cmd *cli.Command) error {
+	for _, name := range []string{"expect-status", "expect-header", "expect-body-contains", "max-latency", "min-success-rate", "save-as", "timing"} {
+		if cmd.IsSet(name) {
+			return cli.Exit(fmt.Sprintf("--%s cannot be combined with --count.", name), 1)
+		}
+	}
+	count, interval := cmd.Int("count"), cmd.Duration("interval")
+	if count < 1 {
+		return cli.Exit(fmt.Sprintf("invalid --count %d: must be at least 1", count), 1)
+	}
+	if interval < 0 {
+		return cli.Exit(fmt.Sprintf("invalid --interval %s: must be positive", interval), 1)
+	}
+	payload, err := buildPayload(cmd, "")
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+	regions, err := parseRegions(cmd.StringSlice("regions"))
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+
+	var renderer *Renderer
+	if !output.IsJSONOutput() && !output.IsQuiet() {
+		renderer = NewRenderer(os.Stdout, false)
+	}
+	spinner := output.StartSpinner(fmt.Sprintf("Checking %s (1/%d)…", payload.URL, count))
+	r := &repeater{
+		count:    int(count),
+		interval: interval,
+		run: func(ctx context.Context) ([]RegionResult, string, error) {
+			results, checkID, err := Run(ctx, nil, payload, nil)
+			return filterRegions(results, regions), checkID, err
+		},
+		wait: sleep,
+		onRateLimit: func(d time.Duration) {
+			output.StopSpinner(spinner)
+			fmt.Fprintf(os.Stderr, "Rate limited, retrying in %s…\n", d.Round(time.Second))
+			spinner = output.StartSpinner("Waiting for the rate limit…")
+		},
+		onRun: func(n int, run repeatRun) {
+			output.StopSpinner(spinner)
+			spinner = nil
+			if renderer != nil {
+				renderer.RunProgress(n, int(count), run)
+			}
+			if n < int(count) {
+				spinner = output.StartSpinner(fmt.Sprintf("Checking %s (%d/%d)…", payload.URL, n+1, count))
+			}
+		},
+	}
+	runs, err := r.Run(ctx)
+	output.StopSpinner(spinner)
+	if err != nil {
+		return formatRunError(err)
+	}
+
+	out := buildRepeatOutput(payload, interval, runs)
+	if output.IsJSONOutput() {
+		if err := output.PrintJSON(out); err != nil {
+			return err
+		}
+	} else if renderer != nil {
+		renderer.Aggregate(out)
+	}
+	if out.Failed > 0 {
+		return cli.Exit(fmt.Sprintf("%d of %d runs failed.", out.Failed, out.Count), 1)
+	}
+	if len(regions) > 0 && out.Summary.Runs == 0 {
+		return cli.Exit("None of the selected regions reported a result.", 1)
+	}
+	return nil
+}
+
+// buildPayload reads the request from the arguments and flags, or from the
+// curl command given to --from-curl. defaultURL is used when no URL is
+// given.
+func buildPayload(cmd *cli.Command, defaultURL string) (Payload, error) {
+	if command := cmd.String("from-curl"); command != "" {
+		if cmd.Args().Present() || cmd.IsSet("method") || cmd.IsSet("header") || cmd.IsSet("body") {
+			return Payload{}, errors.New("--from-curl cannot be combined with a URL, --method, --header or --body")
+		}
+		req, err := curl.Parse(command)
+		if err != nil {
+			return Payload{}, fmt.Errorf("invalid --from-curl: %w", err)
+		}
+		if err := validateURL(req.URL); err != nil {
+			return Payload{}, err
+		}
+		if req.Insecure {
+			fmt.Fprintln(os.Stderr, "Warning: -k/--insecure is not supported by the checker and is ignored.")
+		}
+		if req.FollowRedirects {
+			fmt.Fprintln(os.Stderr, "Warning: -L/--location is not supported by the checker and is ignored.")
+		}
+		return Payload{URL: req.URL, Method: req.Method, Headers: req.Headers, Body: req.Body}, nil
+	}
+
+	rawURL := cmd.Args().Get(0)
+	if rawURL == "" {
+		rawURL = defaultURL
+	}
+	if rawURL == "" {
+		return Payload{}, errors.New("URL is required.\n\nUsage: openstatus check <URL>\nExample: openstatus check https://openstat.us")
+	}
+	if err := validateURL(rawURL); err != nil {
+		return Payload{}, err
+	}
+
+	headers, err := parseHeaders(cmd.StringSlice("header"))
+	if err != nil {
+		return Payload{}, err
+	}
+
+	body, err := resolveBody(cmd.String("body"))
+	if err != nil {
+		return Payload{}, err
+	}
+
+	return Payload{
+		URL:     rawURL,
+		Method:  strings.ToUpper(cmd.String("method")),
+		Headers: headers,
+		Body:    body,
+	}, nil
+}
+
 func validateURL(raw string) error {
 	u, err := url.Parse(raw)
 	if err != nil {
@@ -1,9 +1,12 @@
 package check
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
+
+	"github.com/urfave/cli/v3"
 )
 
 func TestValidateURL(t *testing.T) {
@@ -212,3 +215,38 @@ func TestFormatRunError_Unknown(t *testing.T) {
 		t.Errorf("err = %v, want reach-error wrap", err)
 	}
 }
+
+func TestBuildPayloadFromCurl(t *testing.T) {
+	t.Parallel()
+	parse := func(args ...string) (Payload, error) {
+		var p Payload
+		var err error
+		cmd := CheckCmd()
+		cmd.Action = func(_ context.Context, c *cli.Command) error {
+			p, err = buildPayload(c, "")
+			return nil
+		}
+		if runErr := cmd.Run(context.Background(), append([]string{"check"}, args...)); runErr != nil {
+			t.Fatal(runErr)
+		}
+		return p, err
+	}
+
+	p, err := parse("--from-curl", `curl -X POST https://openstat.us/api -H 'Authorization: Bearer x' --data-raw '{"ping":true}'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.URL != "https://openstat.us/api" || p.Method != "POST" || p.Body != `{"ping":true}` || p.Headers["Authorization"] != "Bearer x" {
+		t.Errorf("unexpected payload %+v", p)
+	}
+
+	if _, err := parse("--from-curl", "curl https://openstat.us", "-X", "PUT"); err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+	if _, err := parse("--from-curl", "curl ftp://openstat.us"); err == nil || !strings.Contains(err.Error(), "only http and https") {
+		t.Errorf("expected a scheme error, got %v", err)
+	}
+	if _, err := parse("--from-curl", "curl --proxy http://p:3128 https://openstat.us"); err == nil || !strings.Contains(err.Error(), "unsupported curl option --proxy") {
+		t.Errorf("expected an unsupported option error, got %v", err)
+	}
+}
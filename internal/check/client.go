@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -24,6 +25,16 @@ func debugWriter() io.Writer { return os.Stderr }
 type OnRow func(RegionResult)
 
 func Run(ctx context.Context, client *http.Client, payload Payload, onRow OnRow) ([]RegionResult, string, error) {
+	return run(ctx, client, payload, onRow, true)
+}
+
+// RunWithResponses is Run without the compact mode of the checker, which
+// then reports the response headers and body of every region.
+func RunWithResponses(ctx context.Context, client *http.Client, payload Payload, onRow OnRow) ([]RegionResult, string, error) {
+	return run(ctx, client, payload, onRow, false)
+}
+
+func run(ctx context.Context, client *http.Client, payload Payload, onRow OnRow, compact bool) ([]RegionResult, string, error) {
 	if onRow == nil {
 		onRow = func(RegionResult) {}
 	}
@@ -36,7 +47,11 @@ func Run(ctx context.Context, client *http.Client, payload Payload, onRow OnRow)
 		return nil, "", fmt.Errorf("encode payload: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, api.PlayCheckerURL+"?compact=true", bytes.NewReader(body))
+	endpoint := api.PlayCheckerURL
+	if compact {
+		endpoint += "?compact=true"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
 	if err != nil {
 		return nil, "", fmt.Errorf("build request: %w", err)
 	}
@@ -56,35 +71,37 @@ func Run(ctx context.Context, client *http.Client, payload Payload, onRow OnRow)
 		return nil, "", err
 	}
 
-	scanner := bufio.NewScanner(resp.Body)
-	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	// Lines are read whole: without compact mode, a row holds the response
+	// body of its region, which has no size limit.
+	reader := bufio.NewReader(resp.Body)
 
 	var results []RegionResult
 	var checkID string
 
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		trimmed := bytes.TrimSpace(line)
-		if len(trimmed) == 0 {
-			continue
+	for checkID == "" {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return results, checkID, fmt.Errorf("read stream: %w", readErr)
 		}
-		if trimmed[0] != '{' {
+		trimmed := bytes.TrimSpace(line)
+		switch {
+		case len(trimmed) == 0:
+		case trimmed[0] != '{':
 			checkID = string(trimmed)
-			break
-		}
-		var row RegionResult
-		if err := json.Unmarshal(trimmed, &row); err != nil {
-			if output.IsDebug() {
-				fmt.Fprintf(debugWriter(), "[debug] skipping unparseable line: %v\n", err)
+		default:
+			var row RegionResult
+			if err := json.Unmarshal(trimmed, &row); err != nil {
+				if output.IsDebug() {
+					fmt.Fprintf(debugWriter(), "[debug] skipping unparseable line: %v\n", err)
+				}
+				break
 			}
-			continue
+			results = append(results, row)
+			onRow(row)
+		}
+		if readErr != nil {
+			break
 		}
-		results = append(results, row)
-		onRow(row)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return results, checkID, fmt.Errorf("read stream: %w", err)
 	}
 
 	if checkID == "" {
@@ -317,3 +317,54 @@ func itoa(n int64) string {
 	}
 	return string(buf[i:])
 }
+
+func TestRunWithResponses_NotCompact(t *testing.T) {
+	t.Parallel()
+	stream := `{"region":"ams","state":"success","status":200,"latency":16,"headers":{"content-type":"text/html"},"body":"ok"}` + "\nabc123\n"
+
+	var compact []bool
+	rt := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		compact = append(compact, req.URL.Query().Get("compact") == "true")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(stream)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	results, _, err := check.RunWithResponses(context.Background(), newClient(rt), check.Payload{URL: "https://example.com"}, nil)
+	if err != nil {
+		t.Fatalf("RunWithResponses: %v", err)
+	}
+	if len(results) != 1 || results[0].Headers["content-type"] != "text/html" || results[0].Body != "ok" {
+		t.Errorf("unexpected results %+v", results)
+	}
+	if _, _, err := check.Run(context.Background(), newClient(rt), check.Payload{URL: "https://example.com"}, nil); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(compact) != 2 || compact[0] || !compact[1] {
+		t.Errorf("compact = %v, want [false true]", compact)
+	}
+}
+
+func TestRunWithResponses_LargeBody(t *testing.T) {
+	t.Parallel()
+	body := strings.Repeat("x", 3<<20)
+	stream := `{"region":"ams","state":"success","status":200,"latency":16,"body":"` + body + `"}` + "\nabc123"
+
+	rt := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(stream)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	results, id, err := check.RunWithResponses(context.Background(), newClient(rt), check.Payload{URL: "https://example.com"}, nil)
+	if err != nil {
+		t.Fatalf("RunWithResponses: %v", err)
+	}
+	if len(results) != 1 || len(results[0].Body) != len(body) || id != "abc123" {
+		t.Errorf("expected the whole body and the check ID, got %d results, id %q", len(results), id)
+	}
+}
@@ -0,0 +1,261 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+const (
+	colDelta        = 14
+	colStatusChange = 9
+)
+
+// CompareOutput is the JSON output of check --compare and --baseline. Base
+// is the first URL or the baseline, New the second URL or the new run.
+type CompareOutput struct {
+	Base    JSONOutput     `json:"base"`
+	New     JSONOutput     `json:"new"`
+	Regions []RegionDelta  `json:"regions"`
+	Summary CompareSummary `json:"summary"`
+}
+
+// RegionDelta compares the results of one region. The deltas are New minus
+// Base, and are only set when both runs measured them.
+type RegionDelta struct {
+	Region       string  `json:"region"`
+	BaseLatency  int64   `json:"base_latency,omitempty"`
+	NewLatency   int64   `json:"new_latency,omitempty"`
+	LatencyDelta *int64  `json:"latency_delta,omitempty"`
+	BaseStatus   int     `json:"base_status,omitempty"`
+	NewStatus    int     `json:"new_status,omitempty"`
+	BaseState    string  `json:"base_state,omitempty"`
+	NewState     string  `json:"new_state,omitempty"`
+	TimingDelta  *Timing `json:"timing_delta,omitempty"`
+}
+
+type CompareSummary struct {
+	MeanLatencyDelta int64   `json:"mean_latency_delta"`
+	SuccessRateDelta float64 `json:"success_rate_delta"`
+	// Faster and Slower count the regions whose latency went down or up.
+	Faster int `json:"faster"`
+	Slower int `json:"slower"`
+}
+
+// readBaseline reads a check saved with --json.
+func readBaseline(path string) (JSONOutput, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return JSONOutput{}, fmt.Errorf("read baseline %q: %w", path, err)
+	}
+	var out JSONOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return JSONOutput{}, fmt.Errorf("invalid baseline %q: %w", path, err)
+	}
+	if len(out.Results) == 0 {
+		return JSONOutput{}, fmt.Errorf("invalid baseline %q: no results, save one with 'openstatus check <URL> --json > %s'", path, path)
+	}
+	return out, nil
+}
+
+// baselineRequest fills in the parts of the request the command line leaves
+// unset from the baseline, so that it is re-run with the same method, headers
+// and body; --header adds to the headers of the baseline. Requests with
+// different methods are not compared, and the headers carrying credentials,
+// which are not saved, must be given again. isSet reports whether a flag was
+// given.
+func baselineRequest(base JSONOutput, payload Payload, isSet func(name string) bool) (Payload, error) {
+	if !isSet("from-curl") {
+		if !isSet("method") && base.Method != "" {
+			payload.Method = base.Method
+		}
+		if len(base.Headers) > 0 {
+			headers := maps.Clone(base.Headers)
+			maps.Copy(headers, payload.Headers)
+			payload.Headers = headers
+		}
+		if !isSet("body") && base.Body != "" {
+			payload.Body = base.Body
+		}
+	}
+	if base.Method != "" && !strings.EqualFold(payload.Method, base.Method) {
+		return Payload{}, fmt.Errorf("the baseline is a %s request and cannot be compared with a %s request", base.Method, payload.Method)
+	}
+	var missing []string
+	for _, key := range base.RedactedHeaders {
+		if _, ok := lookupHeader(payload.Headers, key); !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return Payload{}, fmt.Errorf("the baseline request sent %s, which is not saved: pass it again with -H \"%s: ...\"", strings.Join(missing, ", "), missing[0])
+	}
+	return payload, nil
+}
+
+// compareResults pairs the results of both runs by region, in region code
+// order. A region missing from one run is kept with the fields of the other.
+func compareResults(base, next JSONOutput) CompareOutput {
+	byRegion := func(results []RegionResult) map[string]RegionResult {
+		m := make(map[string]RegionResult, len(results))
+		for _, r := range results {
+			m[r.Region] = r
+		}
+		return m
+	}
+	baseBy, nextBy := byRegion(base.Results), byRegion(next.Results)
+	all := maps.Clone(baseBy)
+	maps.Copy(all, nextBy)
+	regions := slices.Sorted(maps.Keys(all))
+
+	out := CompareOutput{Base: base, New: next, Regions: make([]RegionDelta, 0, len(regions))}
+	for _, region := range regions {
+		d := RegionDelta{Region: region}
+		b, hasBase := baseBy[region]
+		n, hasNext := nextBy[region]
+		if hasBase {
+			d.BaseLatency, d.BaseStatus, d.BaseState = b.Latency, b.Status, stateLabel(b)
+		}
+		if hasNext {
+			d.NewLatency, d.NewStatus, d.NewState = n.Latency, n.Status, stateLabel(n)
+		}
+		if d.BaseLatency > 0 && d.NewLatency > 0 {
+			delta := d.NewLatency - d.BaseLatency
+			d.LatencyDelta = &delta
+			switch {
+			case delta < 0:
+				out.Summary.Faster++
+			case delta > 0:
+				out.Summary.Slower++
+			}
+		}
+		if hasBase && hasNext && b.Timing != nil && n.Timing != nil {
+			d.TimingDelta = &Timing{
+				DNS:        n.Timing.DNS - b.Timing.DNS,
+				Connection: n.Timing.Connection - b.Timing.Connection,
+				TLS:        n.Timing.TLS - b.Timing.TLS,
+				TTFB:       n.Timing.TTFB - b.Timing.TTFB,
+				Transfer:   n.Timing.Transfer - b.Timing.Transfer,
+			}
+		}
+		out.Regions = append(out.Regions, d)
+	}
+	out.Summary.MeanLatencyDelta = next.Summary.MeanLatency - base.Summary.MeanLatency
+	out.Summary.SuccessRateDelta = next.Summary.SuccessRate - base.Summary.SuccessRate
+	return out
+}
+
+// Compare prints the side-by-side table of both runs, followed by how the
+// mean latency and the success rate changed.
+func (r *Renderer) Compare(c CompareOutput) {
+	bold := color.New(color.Bold).SprintfFunc()
+	describe := func(o JSONOutput) string {
+		if o.CheckID == "" {
+			return o.URL
+		}
+		return fmt.Sprintf("%s (%s)", o.URL, shareURL(o.CheckID))
+	}
+	fmt.Fprintf(r.Out, "%s %s\n", bold("Base:"), describe(c.Base))
+	fmt.Fprintf(r.Out, "%s  %s\n\n", bold("New:"), describe(c.New))
+
+	green := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	header := fmt.Sprintf("%-*s  %*s  %*s  %*s  %s",
+		colRegion, "Region",
+		colLatency, "Base",
+		colLatency, "New",
+		colDelta, "Delta",
+		"Status",
+	)
+	if r.Timing {
+		header = padRight(header, utf8.RuneCountInString(header)+colStatusChange-len("Status"))
+		for _, phase := range []string{"ΔDNS", "ΔConn", "ΔTLS", "ΔTTFB", "ΔXfer"} {
+			header += "  " + padLeft(phase, colTimingNum)
+		}
+	}
+	fmt.Fprintln(r.Out, green("%s", header))
+
+	for _, d := range c.Regions {
+		status := statusChange(d)
+		if r.Timing {
+			status = padRight(status, colStatusChange)
+		}
+		fmt.Fprintf(r.Out, "%s  %s  %s  %s  %s",
+			padRight(truncate(DisplayName(d.Region), colRegion), colRegion),
+			padLeft(formatLatency(d.BaseLatency), colLatency),
+			padLeft(formatLatency(d.NewLatency), colLatency),
+			deltaCell(d),
+			status,
+		)
+		if r.Timing {
+			t := d.TimingDelta
+			for _, get := range []func(*Timing) int64{
+				func(t *Timing) int64 { return t.DNS },
+				func(t *Timing) int64 { return t.Connection },
+				func(t *Timing) int64 { return t.TLS },
+				func(t *Timing) int64 { return t.TTFB },
+				func(t *Timing) int64 { return t.Transfer },
+			} {
+				cell := "—"
+				if t != nil {
+					cell = signed(get(t))
+				}
+				fmt.Fprintf(r.Out, "  %s", padLeft(cell, colTimingNum))
+			}
+		}
+		fmt.Fprintln(r.Out)
+	}
+
+	base, next := c.Base.Summary, c.New.Summary
+	fmt.Fprintln(r.Out)
+	fmt.Fprintf(r.Out, "%s    %dms → %dms (%sms)\n", bold("Mean:"), base.MeanLatency, next.MeanLatency, signed(c.Summary.MeanLatencyDelta))
+	fmt.Fprintf(r.Out, "%s %d/%d → %d/%d\n", bold("Success:"), base.Successes, base.TotalRegions, next.Successes, next.TotalRegions)
+	fmt.Fprintf(r.Out, "%s  faster in %d regions, slower in %d\n", bold("Delta:"), c.Summary.Faster, c.Summary.Slower)
+}
+
+// deltaCell formats the latency delta with its share of the base latency,
+// in green when faster and red when slower.
+func deltaCell(d RegionDelta) string {
+	if d.LatencyDelta == nil {
+		return padLeft("—", colDelta)
+	}
+	delta := *d.LatencyDelta
+	cell := fmt.Sprintf("%*s", colDelta, fmt.Sprintf("%sms %s%%", signed(delta), signed(delta*100/d.BaseLatency)))
+	switch {
+	case delta < 0:
+		return color.GreenString("%s", cell)
+	case delta > 0:
+		return color.RedString("%s", cell)
+	}
+	return cell
+}
+
+func statusChange(d RegionDelta) string {
+	base, next := dashOrInt(d.BaseStatus), dashOrInt(d.NewStatus)
+	if base == next {
+		return base
+	}
+	return base + "→" + next
+}
+
+// padLeft and padRight pad s to n characters, which the fmt verbs count in
+// bytes.
+func padLeft(s string, n int) string {
+	return strings.Repeat(" ", max(n-utf8.RuneCountInString(s), 0)) + s
+}
+
+func padRight(s string, n int) string {
+	return s + strings.Repeat(" ", max(n-utf8.RuneCountInString(s), 0))
+}
+
+func signed(v int64) string {
+	if v > 0 {
+		return fmt.Sprintf("+%d", v)
+	}
+	return fmt.Sprintf("%d", v)
+}
@@ -0,0 +1,177 @@
+package check
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func compareFixture() CompareOutput {
+	base := buildJSONOutput(Payload{URL: "https://old.example.com"}, "base1", []RegionResult{
+		{Region: "fra", State: "success", Status: 200, Latency: 100, Timing: &Timing{DNS: 10, Connection: 5, TLS: 20, TTFB: 60, Transfer: 5}},
+		{Region: "iad", State: "success", Status: 200, Latency: 200},
+		{Region: "syd", State: "success", Status: 200, Latency: 300},
+	})
+	next := buildJSONOutput(Payload{URL: "https://new.example.com"}, "", []RegionResult{
+		{Region: "fra", State: "success", Status: 200, Latency: 50, Timing: &Timing{DNS: 2, Connection: 5, TLS: 8, TTFB: 30, Transfer: 5}},
+		{Region: "iad", State: "success", Status: 301, Latency: 260},
+		{Region: "gru", State: "error", Message: "url not reachable"},
+	})
+	return compareResults(base, next)
+}
+
+func TestCompareResults(t *testing.T) {
+	t.Parallel()
+	c := compareFixture()
+
+	if len(c.Regions) != 4 {
+		t.Fatalf("expected the regions of both runs, got %+v", c.Regions)
+	}
+	fra, gru, iad, syd := c.Regions[0], c.Regions[1], c.Regions[2], c.Regions[3]
+	if fra.Region != "fra" || fra.LatencyDelta == nil || *fra.LatencyDelta != -50 {
+		t.Errorf("unexpected fra %+v", fra)
+	}
+	if fra.TimingDelta == nil || *fra.TimingDelta != (Timing{DNS: -8, TLS: -12, TTFB: -30}) {
+		t.Errorf("unexpected fra timing delta %+v", fra.TimingDelta)
+	}
+	if gru.LatencyDelta != nil || gru.BaseState != "" || gru.NewState != "url not reachable" {
+		t.Errorf("unexpected gru %+v", gru)
+	}
+	if iad.LatencyDelta == nil || *iad.LatencyDelta != 60 || iad.BaseStatus != 200 || iad.NewStatus != 301 || iad.TimingDelta != nil {
+		t.Errorf("unexpected iad %+v", iad)
+	}
+	if syd.LatencyDelta != nil || syd.NewState != "" {
+		t.Errorf("unexpected syd %+v", syd)
+	}
+	if c.Summary.Faster != 1 || c.Summary.Slower != 1 || c.Summary.MeanLatencyDelta != -45 {
+		t.Errorf("unexpected summary %+v", c.Summary)
+	}
+}
+
+func TestRenderer_Compare(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	NewRenderer(&buf, true).Compare(compareFixture())
+	out := buf.String()
+	for _, want := range []string{
+		"Base: https://old.example.com (https://www.openstatus.dev/play/checker/base1)\n",
+		"New:  https://new.example.com\n",
+		"ΔDNS",
+		"Frankfurt (Fly)                    100ms        50ms      -50ms -50%  200              -8         0       -12       -30         0\n",
+		"São Paulo (Fly)                        —           —               —  —                 —",
+		"Ashburn (Fly)                      200ms       260ms      +60ms +30%  200→301           —         —         —         —         —\n",
+		"Mean:    200ms → 155ms (-45ms)\n",
+		"Success: 3/3 → 2/3\n",
+		"Delta:  faster in 1 regions, slower in 1\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestReadBaseline(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	saved := buildJSONOutput(Payload{URL: "https://openstat.us"}, "abc", []RegionResult{{Region: "ams", State: "success", Status: 200, Latency: 80}})
+	data, err := json.Marshal(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "before.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := readBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.URL != "https://openstat.us" || len(got.Results) != 1 || got.Results[0].Latency != 80 {
+		t.Errorf("unexpected baseline %+v", got)
+	}
+
+	empty := filepath.Join(dir, "empty.json")
+	if err := os.WriteFile(empty, []byte(`{"url":"https://openstat.us","results":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readBaseline(empty); err == nil || !strings.Contains(err.Error(), "no results") {
+		t.Errorf("expected a no results error, got %v", err)
+	}
+	if _, err := readBaseline(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestBuildJSONOutput_RedactsCredentials(t *testing.T) {
+	t.Parallel()
+	headers, err := parseHeaders([]string{"Authorization: Bearer x", "Accept: text/plain"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(buildJSONOutput(Payload{URL: "https://openstat.us", Method: "GET", Headers: headers}, "abc", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	if strings.Contains(out, "Bearer x") {
+		t.Errorf("expected the token not to be printed:\n%s", out)
+	}
+	if !strings.Contains(out, `"headers":{"Accept":"text/plain"},"redacted_headers":["Authorization"]`) {
+		t.Errorf("expected the other headers and the name of the redacted one:\n%s", out)
+	}
+}
+
+func TestBaselineRequest(t *testing.T) {
+	t.Parallel()
+	base := buildJSONOutput(Payload{
+		URL:     "https://openstat.us/login",
+		Method:  "POST",
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    `{"user":"a"}`,
+	}, "abc", nil)
+	flags := func(set ...string) func(string) bool {
+		return func(name string) bool { return slices.Contains(set, name) }
+	}
+
+	got, err := baselineRequest(base, Payload{URL: base.URL, Method: "GET"}, flags())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Method != "POST" || got.Body != base.Body || got.Headers["Content-Type"] != "application/json" {
+		t.Errorf("expected the baseline request to be replayed, got %+v", got)
+	}
+
+	got, err = baselineRequest(base, Payload{URL: base.URL, Method: "POST", Body: `{"user":"b"}`}, flags("body"))
+	if err != nil || got.Body != `{"user":"b"}` {
+		t.Errorf("expected --body to override the baseline, got %+v, %v", got, err)
+	}
+
+	if _, err := baselineRequest(base, Payload{URL: base.URL, Method: "GET"}, flags("method")); err == nil || !strings.Contains(err.Error(), "POST") {
+		t.Errorf("expected a method mismatch error, got %v", err)
+	}
+
+	secret := buildJSONOutput(Payload{
+		URL:     base.URL,
+		Method:  "GET",
+		Headers: map[string]string{"Authorization": "Bearer x", "Accept": "text/plain"},
+	}, "abc", nil)
+	if _, err := baselineRequest(secret, Payload{URL: base.URL, Method: "GET"}, flags()); err == nil || !strings.Contains(err.Error(), "-H") {
+		t.Errorf("expected the redacted header to be required, got %v", err)
+	}
+	got, err = baselineRequest(secret, Payload{URL: base.URL, Method: "GET", Headers: map[string]string{"authorization": "Bearer y"}}, flags("header"))
+	if err != nil || got.Headers["authorization"] != "Bearer y" || got.Headers["Accept"] != "text/plain" {
+		t.Errorf("expected -H to add to the baseline headers, got %+v, %v", got, err)
+	}
+
+	// Baselines saved before the request was recorded keep the command line.
+	old := JSONOutput{URL: base.URL}
+	got, err = baselineRequest(old, Payload{URL: base.URL, Method: "GET"}, flags())
+	if err != nil || got.Method != "GET" {
+		t.Errorf("expected GET, got %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,204 @@
+package check
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Expectations are the assertions of a check, evaluated over the results of
+// every region once the stream is complete.
+type Expectations struct {
+	// Status is the expected HTTP status code of every region.
+	Status int
+	// Headers maps a header name to its expected value. An empty value only
+	// requires the header to be present.
+	Headers map[string]string
+	// BodyContains is a substring every response body must contain.
+	BodyContains string
+	// MaxLatency is the highest latency allowed in any region.
+	MaxLatency time.Duration
+	// MinSuccessRate is the lowest share of successful regions, from 0 to 1.
+	MinSuccessRate float64
+}
+
+func (e Expectations) IsZero() bool {
+	return e.Status == 0 && len(e.Headers) == 0 && e.BodyContains == "" && e.MaxLatency == 0 && e.MinSuccessRate == 0
+}
+
+// needsResponses reports whether the response headers or body of every
+// region are needed to evaluate the expectations.
+func (e Expectations) needsResponses() bool {
+	return len(e.Headers) > 0 || e.BodyContains != ""
+}
+
+func (e Expectations) validate() error {
+	if e.Status != 0 && (e.Status < 100 || e.Status > 599) {
+		return fmt.Errorf("invalid --expect-status %d: must be an HTTP status code", e.Status)
+	}
+	if e.MaxLatency < 0 {
+		return fmt.Errorf("invalid --max-latency %s: must be positive", e.MaxLatency)
+	}
+	if e.MinSuccessRate < 0 || e.MinSuccessRate > 1 {
+		return fmt.Errorf("invalid --min-success-rate %v: must be between 0 and 1, e.g. 0.95", e.MinSuccessRate)
+	}
+	return nil
+}
+
+// parseExpectedHeaders accepts "Key: Value" and "Key" forms.
+func parseExpectedHeaders(raw []string) (map[string]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]string, len(raw))
+	for _, h := range raw {
+		key, value, _ := strings.Cut(h, ":")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("invalid --expect-header %q: key is empty", h)
+		}
+		out[key] = strings.TrimSpace(value)
+	}
+	return out, nil
+}
+
+// AssertionResult is the outcome of one expectation. Failures name the
+// regions that did not meet it.
+type AssertionResult struct {
+	Name     string   `json:"name"`
+	Expected string   `json:"expected"`
+	Passed   bool     `json:"passed"`
+	Failures []string `json:"failures,omitempty"`
+}
+
+// Evaluate checks the results against the expectations, in a stable order.
+func (e Expectations) Evaluate(results []RegionResult) []AssertionResult {
+	var out []AssertionResult
+	perRegion := func(name, expected string, failure func(RegionResult) string) {
+		res := AssertionResult{Name: name, Expected: expected}
+		// Without results, nothing was checked: that is not a pass.
+		if len(results) == 0 {
+			res.Failures = []string{"no region reported a result"}
+		}
+		for _, r := range results {
+			if msg := failure(r); msg != "" {
+				res.Failures = append(res.Failures, r.Region+": "+msg)
+			}
+		}
+		res.Passed = len(res.Failures) == 0
+		out = append(out, res)
+	}
+
+	if e.Status != 0 {
+		perRegion("status", fmt.Sprint(e.Status), func(r RegionResult) string {
+			switch {
+			case r.Status == 0:
+				return "no response (" + stateLabel(r) + ")"
+			case r.Status != e.Status:
+				return fmt.Sprintf("got %d", r.Status)
+			}
+			return ""
+		})
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(e.Headers)) {
+		want := e.Headers[key]
+		expected := key
+		if want != "" {
+			expected = key + ": " + want
+		}
+		perRegion("header", expected, func(r RegionResult) string {
+			if r.Headers == nil {
+				return "no response headers reported"
+			}
+			got, ok := lookupHeader(r.Headers, key)
+			switch {
+			case !ok:
+				return "missing"
+			case want != "" && got != want:
+				return fmt.Sprintf("got %q", got)
+			}
+			return ""
+		})
+	}
+
+	if e.BodyContains != "" {
+		perRegion("body_contains", e.BodyContains, func(r RegionResult) string {
+			switch {
+			case r.Body == "":
+				return "no response body reported"
+			case !strings.Contains(r.Body, e.BodyContains):
+				return "not found"
+			}
+			return ""
+		})
+	}
+
+	if e.MaxLatency > 0 {
+		limit := e.MaxLatency.Milliseconds()
+		perRegion("max_latency", fmt.Sprintf("%dms", limit), func(r RegionResult) string {
+			if r.Latency > limit {
+				return fmt.Sprintf("%dms", r.Latency)
+			}
+			return ""
+		})
+	}
+
+	if e.MinSuccessRate > 0 {
+		summary := computeSummary(results)
+		res := AssertionResult{
+			Name:     "min_success_rate",
+			Expected: fmt.Sprintf("%.0f%%", e.MinSuccessRate*100),
+			Passed:   len(results) > 0 && summary.SuccessRate >= e.MinSuccessRate,
+		}
+		if !res.Passed {
+			res.Failures = []string{fmt.Sprintf("%d/%d regions succeeded (%.0f%%)", summary.Successes, summary.TotalRegions, summary.SuccessRate*100)}
+		}
+		out = append(out, res)
+	}
+	return out
+}
+
+func lookupHeader(headers map[string]string, key string) (string, bool) {
+	for k, v := range headers {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// allPassed reports whether every assertion passed.
+func allPassed(assertions []AssertionResult) bool {
+	return !slices.ContainsFunc(assertions, func(a AssertionResult) bool { return !a.Passed })
+}
+
+// parseRegions validates region codes given to --regions.
+func parseRegions(raw []string) ([]string, error) {
+	var out []string
+	for _, r := range raw {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		if _, ok := regionDisplayNames[r]; !ok {
+			codes := slices.Sorted(maps.Keys(regionDisplayNames))
+			return nil, fmt.Errorf("unknown region %q, expected one of: %s", r, strings.Join(codes, ", "))
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// filterRegions keeps the results of regions, or all results when regions
+// is empty.
+func filterRegions(results []RegionResult, regions []string) []RegionResult {
+	if len(regions) == 0 {
+		return results
+	}
+	return slices.DeleteFunc(slices.Clone(results), func(r RegionResult) bool {
+		return !slices.Contains(regions, r.Region)
+	})
+}
@@ -0,0 +1,133 @@
+package check
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExpectationsEvaluate(t *testing.T) {
+	t.Parallel()
+	results := []RegionResult{
+		{Region: "ams", State: "success", Status: 200, Latency: 120, Headers: map[string]string{"content-type": "text/html"}, Body: "<h1>All systems operational</h1>"},
+		{Region: "syd", State: "success", Status: 503, Latency: 950, Headers: map[string]string{"Content-Type": "text/plain"}, Body: "down"},
+		{Region: "gru", State: "error", Message: "url not reachable"},
+	}
+	e := Expectations{
+		Status:         200,
+		Headers:        map[string]string{"Content-Type": "text/html", "X-Missing": ""},
+		BodyContains:   "operational",
+		MaxLatency:     800 * time.Millisecond,
+		MinSuccessRate: 0.5,
+	}
+	got := e.Evaluate(results)
+
+	want := []struct {
+		name, expected string
+		passed         bool
+		failures       string
+	}{
+		{"status", "200", false, "syd: got 503; gru: no response (url not reachable)"},
+		{"header", "Content-Type: text/html", false, `syd: got "text/plain"; gru: no response headers reported`},
+		{"header", "X-Missing", false, "ams: missing; syd: missing; gru: no response headers reported"},
+		{"body_contains", "operational", false, "syd: not found; gru: no response body reported"},
+		{"max_latency", "800ms", false, "syd: 950ms"},
+		{"min_success_rate", "50%", true, ""},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d assertions, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		a := got[i]
+		if a.Name != w.name || a.Expected != w.expected || a.Passed != w.passed || strings.Join(a.Failures, "; ") != w.failures {
+			t.Errorf("assertion %d = %+v, want %+v", i, a, w)
+		}
+	}
+	if allPassed(got) {
+		t.Error("allPassed() = true with failing assertions")
+	}
+}
+
+func TestExpectationsMinSuccessRate(t *testing.T) {
+	t.Parallel()
+	e := Expectations{MinSuccessRate: 0.95}
+	got := e.Evaluate([]RegionResult{{Region: "ams", State: "success"}, {Region: "gru", State: "error"}})
+	if len(got) != 1 || got[0].Passed || got[0].Failures[0] != "1/2 regions succeeded (50%)" {
+		t.Errorf("unexpected assertions %+v", got)
+	}
+	if got := e.Evaluate(nil); got[0].Passed {
+		t.Error("min_success_rate should fail without results")
+	}
+}
+
+func TestExpectationsWithoutResults(t *testing.T) {
+	t.Parallel()
+	e := Expectations{Status: 200, Headers: map[string]string{"Content-Type": ""}, BodyContains: "ok", MaxLatency: time.Second}
+	for _, a := range e.Evaluate(nil) {
+		if a.Passed || len(a.Failures) != 1 || a.Failures[0] != "no region reported a result" {
+			t.Errorf("%s should fail without results, got %+v", a.Name, a)
+		}
+	}
+}
+
+func TestExpectationsValidate(t *testing.T) {
+	t.Parallel()
+	for _, e := range []Expectations{{Status: 42}, {MinSuccessRate: 95}, {MaxLatency: -time.Second}} {
+		if err := e.validate(); err == nil {
+			t.Errorf("validate(%+v) = nil, want error", e)
+		}
+	}
+	if err := (Expectations{Status: 204, MinSuccessRate: 1}).validate(); err != nil {
+		t.Errorf("validate() = %v", err)
+	}
+}
+
+func TestParseExpectedHeaders(t *testing.T) {
+	t.Parallel()
+	h, err := parseExpectedHeaders([]string{"Content-Type: text/html", "X-Request-Id"})
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if h["Content-Type"] != "text/html" || h["X-Request-Id"] != "" || len(h) != 2 {
+		t.Errorf("unexpected headers %v", h)
+	}
+	if _, err := parseExpectedHeaders([]string{": x"}); err == nil {
+		t.Error("expected an error for an empty key")
+	}
+}
+
+func TestRegionsFilter(t *testing.T) {
+	t.Parallel()
+	if _, err := parseRegions([]string{"ams", "mars"}); err == nil || !strings.Contains(err.Error(), `unknown region "mars"`) {
+		t.Errorf("expected an unknown region error, got %v", err)
+	}
+	regions, err := parseRegions([]string{"ams", " koyeb_fra"})
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	results := []RegionResult{{Region: "ams"}, {Region: "fra"}, {Region: "koyeb_fra"}}
+	got := filterRegions(results, regions)
+	if len(got) != 2 || got[0].Region != "ams" || got[1].Region != "koyeb_fra" {
+		t.Errorf("filterRegions() = %+v", got)
+	}
+	if len(results) != 3 {
+		t.Error("filterRegions modified its input")
+	}
+}
+
+func TestSummaryAssertionsJSON(t *testing.T) {
+	t.Parallel()
+	results := []RegionResult{{Region: "fra", State: "success", Status: 200, Latency: 34}}
+	out := buildJSONOutput(Payload{URL: "https://example.com"}, "abc123", results)
+	raw, _ := json.Marshal(out)
+	if strings.Contains(string(raw), `"passed"`) {
+		t.Errorf("summary without expectations should not report passed: %s", raw)
+	}
+
+	out.Summary.setAssertions(Expectations{Status: 200}.Evaluate(results))
+	raw, _ = json.Marshal(out.Summary)
+	if !strings.Contains(string(raw), `"passed":true,"assertions":[{"name":"status","expected":"200","passed":true}]`) {
+		t.Errorf("unexpected summary %s", raw)
+	}
+}
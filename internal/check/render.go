@@ -3,8 +3,12 @@ package check
 import (
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 
 	"github.com/fatih/color"
+
+	"github.com/openstatusHQ/cli/internal/config"
 )
 
 const (
@@ -119,6 +123,25 @@ func (r *Renderer) Footer(checkedURL string, results []RegionResult, checkID str
 	_ = checkedURL
 }
 
+// Assertions prints one line per expectation, with the failing regions.
+func (r *Renderer) Assertions(assertions []AssertionResult) {
+	if len(assertions) == 0 {
+		return
+	}
+	fmt.Fprintln(r.Out)
+	fmt.Fprintln(r.Out, color.New(color.Bold).Sprint("Expectations:"))
+	for _, a := range assertions {
+		mark := color.GreenString("✓")
+		if !a.Passed {
+			mark = color.RedString("✗")
+		}
+		fmt.Fprintf(r.Out, "  %s %s %s\n", mark, a.Name, a.Expected)
+		for _, f := range a.Failures {
+			fmt.Fprintf(r.Out, "      %s\n", f)
+		}
+	}
+}
+
 func stateLabel(row RegionResult) string {
 	if row.Succeeded() {
 		return "success"
@@ -175,11 +198,20 @@ func truncate(s string, n int) string {
 }
 
 type JSONOutput struct {
-	URL      string         `json:"url"`
-	CheckID  string         `json:"check_id"`
-	ShareURL string         `json:"share_url"`
-	Results  []RegionResult `json:"results"`
-	Summary  Summary        `json:"summary"`
+	URL string `json:"url"`
+	// Method, Headers and Body let --baseline replay the same request. The
+	// headers carrying credentials are not saved, only their names in
+	// RedactedHeaders.
+	Method          string            `json:"method,omitempty"`
+	Headers         map[string]string `json:"headers,omitempty"`
+	RedactedHeaders []string          `json:"redacted_headers,omitempty"`
+	Body            string            `json:"body,omitempty"`
+	CheckID         string            `json:"check_id"`
+	ShareURL        string            `json:"share_url"`
+	Results         []RegionResult    `json:"results"`
+	Summary         Summary           `json:"summary"`
+	// Error is set on the runs of check --count that failed.
+	Error string `json:"error,omitempty"`
 }
 
 type Summary struct {
@@ -189,6 +221,15 @@ type Summary struct {
 	SuccessRate  float64   `json:"success_rate"`
 	TotalRegions int       `json:"total_regions"`
 	Successes    int       `json:"successes"`
+	// Passed and Assertions are only set when the check has expectations.
+	Passed     *bool             `json:"passed,omitempty"`
+	Assertions []AssertionResult `json:"assertions,omitempty"`
+}
+
+func (s *Summary) setAssertions(assertions []AssertionResult) {
+	passed := allPassed(assertions)
+	s.Passed = &passed
+	s.Assertions = assertions
 }
 
 type Endpoint struct {
@@ -196,14 +237,27 @@ type Endpoint struct {
 	Latency int64  `json:"latency"`
 }
 
-func buildJSONOutput(checkedURL, checkID string, results []RegionResult) JSONOutput {
-	return JSONOutput{
-		URL:      checkedURL,
+func buildJSONOutput(payload Payload, checkID string, results []RegionResult) JSONOutput {
+	out := JSONOutput{
+		URL:      payload.URL,
+		Method:   payload.Method,
+		Body:     payload.Body,
 		CheckID:  checkID,
 		ShareURL: shareURL(checkID),
 		Results:  results,
 		Summary:  computeSummary(results),
 	}
+	for _, key := range slices.Sorted(maps.Keys(payload.Headers)) {
+		if config.IsSensitiveHeader(key) {
+			out.RedactedHeaders = append(out.RedactedHeaders, key)
+			continue
+		}
+		if out.Headers == nil {
+			out.Headers = map[string]string{}
+		}
+		out.Headers[key] = payload.Headers[key]
+	}
+	return out
 }
 
 func computeSummary(results []RegionResult) Summary {
@@ -156,7 +156,7 @@ func TestBuildJSONOutput_Shape(t *testing.T) {
 	results := []RegionResult{
 		{Region: "fra", State: "success", Status: 200, Latency: 34, Timestamp: 1, Timing: &Timing{DNS: 14, Connection: 2, TLS: 9, TTFB: 9, Transfer: 1}},
 	}
-	out := buildJSONOutput("https://example.com", "abc123", results)
+	out := buildJSONOutput(Payload{URL: "https://example.com"}, "abc123", results)
 	if out.URL != "https://example.com" {
 		t.Errorf("url = %q", out.URL)
 	}
@@ -0,0 +1,249 @@
+package check
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// rateLimitWindow is how long the checker needs to allow a new request
+// when it rate limits without a Retry-After.
+const rateLimitWindow = 20 * time.Second
+
+// maxRateLimitRetries is how many times in a row a run is retried after
+// being rate limited.
+const maxRateLimitRetries = 5
+
+// RepeatOutput is the JSON output of check --count. Failed counts the runs
+// that ended with an error; their results, if any, are still aggregated.
+type RepeatOutput struct {
+	URL      string        `json:"url"`
+	Count    int           `json:"count"`
+	Interval string        `json:"interval"`
+	Failed   int           `json:"failed"`
+	Runs     []JSONOutput  `json:"runs"`
+	Regions  []RegionStats `json:"regions"`
+	Summary  RegionStats   `json:"summary"`
+}
+
+// RegionStats aggregates the results of a region over every run. Latencies
+// are only taken from the runs that measured one.
+type RegionStats struct {
+	Region      string  `json:"region,omitempty"`
+	Runs        int     `json:"runs"`
+	Successes   int     `json:"successes"`
+	SuccessRate float64 `json:"success_rate"`
+	Min         int64   `json:"min_latency"`
+	Median      int64   `json:"median_latency"`
+	Max         int64   `json:"max_latency"`
+}
+
+// repeater runs a check several times, waiting between runs and after
+// being rate limited. run and wait are replaced in tests.
+type repeater struct {
+	count    int
+	interval time.Duration
+	run      func(ctx context.Context) ([]RegionResult, string, error)
+	wait     func(ctx context.Context, d time.Duration) error
+	// onRateLimit is called before waiting d to retry a run.
+	onRateLimit func(d time.Duration)
+	// onRun is called after every run, from 1 to count.
+	onRun func(n int, run repeatRun)
+}
+
+// repeatRun is one run of a repeater. Err is set when the run failed, with
+// the results received before the failure.
+type repeatRun struct {
+	Results []RegionResult
+	CheckID string
+	Err     error
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// Run returns every run. The interval is counted from the start of a run to
+// the start of the next. A failed run is recorded and the next one started,
+// but being rate limited too many times in a row or the context being done
+// stops the repeater, returning the runs completed so far with the error.
+func (r *repeater) Run(ctx context.Context) ([]repeatRun, error) {
+	var runs []repeatRun
+	var next time.Time
+	for n := 1; n <= r.count; n++ {
+		if n > 1 {
+			if err := r.wait(ctx, time.Until(next)); err != nil {
+				return runs, err
+			}
+		}
+		next = time.Now().Add(r.interval)
+
+		var run repeatRun
+		for retries := 0; ; retries++ {
+			results, checkID, err := r.run(ctx)
+			run = repeatRun{Results: results, CheckID: checkID, Err: err}
+			var rl *RateLimitError
+			if !errors.As(err, &rl) {
+				break
+			}
+			if retries == maxRateLimitRetries {
+				return runs, err
+			}
+			d := rl.RetryAfter
+			if d <= 0 {
+				d = rateLimitWindow
+			}
+			if r.onRateLimit != nil {
+				r.onRateLimit(d)
+			}
+			if err := r.wait(ctx, d); err != nil {
+				return runs, err
+			}
+		}
+		if run.Err != nil && ctx.Err() != nil {
+			return runs, ctx.Err()
+		}
+		runs = append(runs, run)
+		if r.onRun != nil {
+			r.onRun(n, run)
+		}
+	}
+	return runs, nil
+}
+
+// aggregateRuns computes the stats of every region, fastest median first,
+// and of all regions together.
+func aggregateRuns(runs [][]RegionResult) ([]RegionStats, RegionStats) {
+	byRegion := map[string][]RegionResult{}
+	var all []RegionResult
+	for _, results := range runs {
+		for _, r := range results {
+			byRegion[r.Region] = append(byRegion[r.Region], r)
+		}
+		all = append(all, results...)
+	}
+
+	regions := make([]RegionStats, 0, len(byRegion))
+	for region, results := range byRegion {
+		s := regionStats(results)
+		s.Region = region
+		regions = append(regions, s)
+	}
+	// Regions without any latency go last.
+	slices.SortFunc(regions, func(a, b RegionStats) int {
+		if (a.Median == 0) != (b.Median == 0) {
+			if a.Median == 0 {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Or(cmp.Compare(a.Median, b.Median), cmp.Compare(a.Region, b.Region))
+	})
+	return regions, regionStats(all)
+}
+
+func regionStats(results []RegionResult) RegionStats {
+	s := RegionStats{Runs: len(results)}
+	var latencies []int64
+	for _, r := range results {
+		if r.Succeeded() {
+			s.Successes++
+		}
+		if r.Latency > 0 {
+			latencies = append(latencies, r.Latency)
+		}
+	}
+	if s.Runs > 0 {
+		s.SuccessRate = float64(s.Successes) / float64(s.Runs)
+	}
+	if len(latencies) > 0 {
+		slices.Sort(latencies)
+		s.Min, s.Max = latencies[0], latencies[len(latencies)-1]
+		mid := len(latencies) / 2
+		s.Median = latencies[mid]
+		if len(latencies)%2 == 0 {
+			s.Median = (latencies[mid-1] + latencies[mid]) / 2
+		}
+	}
+	return s
+}
+
+func buildRepeatOutput(payload Payload, interval time.Duration, runs []repeatRun) RepeatOutput {
+	out := RepeatOutput{
+		URL:      payload.URL,
+		Count:    len(runs),
+		Interval: interval.String(),
+		Runs:     make([]JSONOutput, len(runs)),
+	}
+	results := make([][]RegionResult, len(runs))
+	for i, run := range runs {
+		out.Runs[i] = buildJSONOutput(payload, run.CheckID, run.Results)
+		if run.Err != nil {
+			out.Runs[i].Error = run.Err.Error()
+			out.Failed++
+		}
+		results[i] = run.Results
+	}
+	out.Regions, out.Summary = aggregateRuns(results)
+	return out
+}
+
+// RunProgress prints one line per run of check --count.
+func (r *Renderer) RunProgress(n, count int, run repeatRun) {
+	if run.Err != nil {
+		fmt.Fprintf(r.Out, "Run %d/%d: %s\n", n, count, color.RedString("failed: %s", run.Err))
+		return
+	}
+	s := computeSummary(run.Results)
+	fmt.Fprintf(r.Out, "Run %d/%d: %d/%d succeeded, mean %dms\n", n, count, s.Successes, s.TotalRegions, s.MeanLatency)
+}
+
+// Aggregate prints the latency and success rate of every region over all
+// the runs of check --count.
+func (r *Renderer) Aggregate(out RepeatOutput) {
+	green := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	fmt.Fprintln(r.Out)
+	fmt.Fprintln(r.Out, green(
+		"%-*s  %*s  %*s  %*s  %*s  %*s",
+		colRegion, "Region",
+		colLatency, "Min",
+		colLatency, "Median",
+		colLatency, "Max",
+		colLatency, "Success",
+		colStatus, "Runs",
+	))
+	for _, s := range out.Regions {
+		success := fmt.Sprintf("%*s", colLatency, fmt.Sprintf("%.0f%%", s.SuccessRate*100))
+		if s.Successes < s.Runs {
+			success = color.RedString("%s", success)
+		}
+		fmt.Fprintf(r.Out, "%s  %s  %s  %s  %s  %*d\n",
+			padRight(truncate(DisplayName(s.Region), colRegion), colRegion),
+			padLeft(formatLatency(s.Min), colLatency),
+			padLeft(formatLatency(s.Median), colLatency),
+			padLeft(formatLatency(s.Max), colLatency),
+			success,
+			colStatus, s.Runs,
+		)
+	}
+
+	bold := color.New(color.Bold).SprintfFunc()
+	fmt.Fprintln(r.Out)
+	fmt.Fprintf(r.Out, "%s %s min, %s median, %s max\n", bold("Latency:"), formatLatency(out.Summary.Min), formatLatency(out.Summary.Median), formatLatency(out.Summary.Max))
+	fmt.Fprintf(r.Out, "%s %d/%d (%.0f%%) over %d runs\n", bold("Success:"), out.Summary.Successes, out.Summary.Runs, out.Summary.SuccessRate*100, out.Count)
+	if out.Failed > 0 {
+		fmt.Fprintf(r.Out, "%s  %s\n", bold("Failed:"), color.RedString("%d of %d runs", out.Failed, out.Count))
+	}
+}
@@ -0,0 +1,175 @@
+package check
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRepeaterRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("waits on rate limits", func(t *testing.T) {
+		t.Parallel()
+		calls := 0
+		var waits, limited []time.Duration
+		var progress []int
+		r := &repeater{
+			count:    3,
+			interval: time.Minute,
+			run: func(context.Context) ([]RegionResult, string, error) {
+				calls++
+				switch calls {
+				case 2:
+					return nil, "", &RateLimitError{RetryAfter: 7 * time.Second}
+				case 3:
+					return nil, "", &RateLimitError{}
+				}
+				return []RegionResult{{Region: "ams", State: "success", Latency: int64(calls)}}, "id", nil
+			},
+			wait: func(_ context.Context, d time.Duration) error {
+				waits = append(waits, d)
+				return nil
+			},
+			onRateLimit: func(d time.Duration) { limited = append(limited, d) },
+			onRun:       func(n int, _ repeatRun) { progress = append(progress, n) },
+		}
+		runs, err := r.Run(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(runs) != 3 || runs[2].CheckID != "id" || calls != 5 {
+			t.Fatalf("expected 3 runs in 5 calls, got %d runs in %d calls", len(runs), calls)
+		}
+		if len(limited) != 2 || limited[0] != 7*time.Second || limited[1] != rateLimitWindow {
+			t.Errorf("rate limit waits = %v", limited)
+		}
+		// One wait per interval, plus one per rate limit.
+		if len(waits) != 4 || waits[0] <= 0 || waits[0] > time.Minute {
+			t.Errorf("waits = %v", waits)
+		}
+		if len(progress) != 3 || progress[2] != 3 {
+			t.Errorf("progress = %v", progress)
+		}
+	})
+
+	t.Run("gives up after repeated rate limits", func(t *testing.T) {
+		t.Parallel()
+		calls := 0
+		r := &repeater{
+			count: 2,
+			run: func(context.Context) ([]RegionResult, string, error) {
+				calls++
+				return nil, "", &RateLimitError{RetryAfter: time.Second}
+			},
+			wait: func(context.Context, time.Duration) error { return nil },
+		}
+		_, err := r.Run(context.Background())
+		var rl *RateLimitError
+		if !errors.As(err, &rl) || calls != maxRateLimitRetries+1 {
+			t.Errorf("expected a rate limit error after %d calls, got %v after %d", maxRateLimitRetries+1, err, calls)
+		}
+	})
+
+	t.Run("records failed runs", func(t *testing.T) {
+		t.Parallel()
+		calls := 0
+		r := &repeater{
+			count: 3,
+			run: func(context.Context) ([]RegionResult, string, error) {
+				calls++
+				if calls == 2 {
+					return []RegionResult{{Region: "ams", State: "success", Latency: 10}}, "", ErrStreamTruncated
+				}
+				return []RegionResult{{Region: "ams", State: "success", Latency: 20}}, "id", nil
+			},
+			wait: func(context.Context, time.Duration) error { return nil },
+		}
+		runs, err := r.Run(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(runs) != 3 || !errors.Is(runs[1].Err, ErrStreamTruncated) || runs[0].Err != nil || runs[2].Err != nil {
+			t.Fatalf("expected 3 runs with the second failed, got %+v", runs)
+		}
+		out := buildRepeatOutput(Payload{URL: "https://example.com"}, 0, runs)
+		if out.Failed != 1 || out.Runs[1].Error == "" || out.Summary.Runs != 3 {
+			t.Errorf("expected the failed run to be reported and its results aggregated, got %+v", out)
+		}
+	})
+
+	t.Run("stops on cancellation", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		r := &repeater{
+			count:    2,
+			interval: time.Hour,
+			run:      func(context.Context) ([]RegionResult, string, error) { return nil, "", nil },
+			wait:     sleep,
+		}
+		if _, err := r.Run(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func repeatFixture() [][]RegionResult {
+	return [][]RegionResult{
+		{{Region: "fra", State: "success", Latency: 40}, {Region: "syd", State: "success", Latency: 300}, {Region: "gru", State: "error"}},
+		{{Region: "fra", State: "success", Latency: 60}, {Region: "syd", State: "error", Message: "timeout", Latency: 900}, {Region: "gru", State: "error"}},
+		{{Region: "fra", State: "success", Latency: 50}, {Region: "syd", State: "success", Latency: 320}},
+	}
+}
+
+func TestAggregateRuns(t *testing.T) {
+	t.Parallel()
+	regions, total := aggregateRuns(repeatFixture())
+
+	want := []RegionStats{
+		{Region: "fra", Runs: 3, Successes: 3, SuccessRate: 1, Min: 40, Median: 50, Max: 60},
+		{Region: "syd", Runs: 3, Successes: 2, SuccessRate: 2.0 / 3, Min: 300, Median: 320, Max: 900},
+		{Region: "gru", Runs: 2},
+	}
+	if len(regions) != len(want) {
+		t.Fatalf("got %+v", regions)
+	}
+	for i := range want {
+		if regions[i] != want[i] {
+			t.Errorf("regions[%d] = %+v, want %+v", i, regions[i], want[i])
+		}
+	}
+	if total.Runs != 8 || total.Successes != 5 || total.Min != 40 || total.Median != 180 || total.Max != 900 {
+		t.Errorf("unexpected total %+v", total)
+	}
+}
+
+func TestRenderer_Aggregate(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	r := NewRenderer(&buf, false)
+	var runs []repeatRun
+	for _, results := range repeatFixture() {
+		runs = append(runs, repeatRun{Results: results, CheckID: "id"})
+	}
+	r.RunProgress(2, 3, runs[1])
+	r.RunProgress(3, 3, repeatRun{Err: ErrStreamTruncated})
+	r.Aggregate(buildRepeatOutput(Payload{URL: "https://example.com"}, 30*time.Second, runs))
+	out := buf.String()
+	for _, want := range []string{
+		"Run 2/3: 1/3 succeeded, mean 480ms\n",
+		"Run 3/3: failed: " + ErrStreamTruncated.Error() + "\n",
+		"Frankfurt (Fly)                     40ms        50ms        60ms        100%        3\n",
+		"São Paulo (Fly)                        —           —           —          0%        2\n",
+		"Latency: 40ms min, 180ms median, 900ms max\n",
+		"Success: 5/8 (62%) over 3 runs\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
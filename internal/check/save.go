@@ -0,0 +1,75 @@
+package check
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/openstatusHQ/cli/internal/config"
+)
+
+// monitorFromCheck builds the monitor definition of a check. It runs in the
+// regions that succeeded and asserts the status code they observed, along
+// with the expectations of the check.
+func monitorFromCheck(name string, payload Payload, results []RegionResult, expect Expectations) (config.Monitor, error) {
+	var regions []config.Region
+	statuses := map[int]int{}
+	for _, r := range results {
+		if !r.Succeeded() {
+			continue
+		}
+		regions = append(regions, config.Region(r.Region))
+		if r.Status != 0 {
+			statuses[r.Status]++
+		}
+	}
+	if len(regions) == 0 {
+		return config.Monitor{}, errors.New("no region succeeded, the check cannot be saved as a monitor")
+	}
+	slices.Sort(regions)
+
+	status := expect.Status
+	if status == 0 {
+		// The most common status, the lowest on a tie.
+		for _, code := range slices.Sorted(maps.Keys(statuses)) {
+			if statuses[code] > statuses[status] {
+				status = code
+			}
+		}
+	}
+
+	var assertions []config.Assertion
+	if status != 0 {
+		assertions = append(assertions, config.Assertion{Kind: config.StatusCode, Compare: config.Eq, Target: status})
+	}
+	for _, key := range slices.Sorted(maps.Keys(expect.Headers)) {
+		a := config.Assertion{Kind: config.Header, Key: key, Compare: config.NotEmpty, Target: ""}
+		if want := expect.Headers[key]; want != "" {
+			a.Compare, a.Target = config.Eq, want
+		}
+		assertions = append(assertions, a)
+	}
+	if expect.BodyContains != "" {
+		assertions = append(assertions, config.Assertion{Kind: config.TextBody, Compare: config.Contains, Target: expect.BodyContains})
+	}
+
+	method := payload.Method
+	if method == "" {
+		method = "GET"
+	}
+	return config.Monitor{
+		Name:          name,
+		Frequency:     config.The10M,
+		Regions:       regions,
+		Active:        true,
+		Kind:          config.HTTP,
+		DegradedAfter: expect.MaxLatency.Milliseconds(),
+		Request: config.Request{
+			URL:     payload.URL,
+			Method:  config.Method(method),
+			Headers: payload.Headers,
+			Body:    payload.Body,
+		},
+		Assertions: assertions,
+	}, nil
+}
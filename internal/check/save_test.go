@@ -0,0 +1,70 @@
+package check
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/openstatusHQ/cli/internal/config"
+)
+
+func TestMonitorFromCheck(t *testing.T) {
+	t.Parallel()
+	payload := Payload{URL: "https://openstat.us/api", Method: "POST", Headers: map[string]string{"Authorization": "Bearer x"}, Body: `{"ping":true}`}
+	results := []RegionResult{
+		{Region: "syd", State: "success", Status: 201},
+		{Region: "ams", State: "success", Status: 201},
+		{Region: "iad", State: "success", Status: 200},
+		{Region: "gru", State: "error", Message: "url not reachable"},
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		m, err := monitorFromCheck("api-ping", payload, results, Expectations{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.Name != "api-ping" || m.Kind != config.HTTP || m.Frequency != config.The10M || !m.Active {
+			t.Errorf("unexpected monitor %+v", m)
+		}
+		if !slices.Equal(m.Regions, []config.Region{"ams", "iad", "syd"}) {
+			t.Errorf("regions = %v, want the succeeded regions sorted", m.Regions)
+		}
+		if m.Request.Method != config.Post || m.Request.Body != payload.Body || m.Request.Headers["Authorization"] != "Bearer x" {
+			t.Errorf("unexpected request %+v", m.Request)
+		}
+		want := []config.Assertion{{Kind: config.StatusCode, Compare: config.Eq, Target: 201}}
+		if len(m.Assertions) != 1 || m.Assertions[0] != want[0] {
+			t.Errorf("assertions = %+v, want %+v", m.Assertions, want)
+		}
+	})
+
+	t.Run("expectations", func(t *testing.T) {
+		m, err := monitorFromCheck("api-ping", payload, results, Expectations{
+			Status:       200,
+			Headers:      map[string]string{"X-Version": "", "Content-Type": "application/json"},
+			BodyContains: "pong",
+			MaxLatency:   800 * time.Millisecond,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []config.Assertion{
+			{Kind: config.StatusCode, Compare: config.Eq, Target: 200},
+			{Kind: config.Header, Key: "Content-Type", Compare: config.Eq, Target: "application/json"},
+			{Kind: config.Header, Key: "X-Version", Compare: config.NotEmpty, Target: ""},
+			{Kind: config.TextBody, Compare: config.Contains, Target: "pong"},
+		}
+		if !slices.Equal(m.Assertions, want) {
+			t.Errorf("assertions = %+v, want %+v", m.Assertions, want)
+		}
+		if m.DegradedAfter != 800 {
+			t.Errorf("degradedAfter = %d, want 800", m.DegradedAfter)
+		}
+	})
+
+	t.Run("no region succeeded", func(t *testing.T) {
+		if _, err := monitorFromCheck("api-ping", payload, results[3:], Expectations{}); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
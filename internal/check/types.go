@@ -29,6 +29,10 @@ type RegionResult struct {
 	Timestamp int64   `json:"timestamp,omitempty"`
 	Timing    *Timing `json:"timing,omitempty"`
 	Message   string  `json:"message,omitempty"`
+	// Headers and Body are only reported when the check is run with
+	// RunWithResponses.
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
 }
 
 func (r RegionResult) Succeeded() bool {
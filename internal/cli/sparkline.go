@@ -0,0 +1,45 @@
+package cli
+
+import "strings"
+
+var (
+	sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+	// asciiBlocks draw sparklines as plain text, for output without colors
+	// that may end up in a file or on a dumb terminal.
+	asciiBlocks = []rune("_.-:=+*#")
+)
+
+// Sparkline renders values as a line of block characters, scaled between
+// their minimum and maximum. When width is positive, only the last width
+// values are drawn.
+func Sparkline(values []int64, width int) string {
+	return sparkline(values, width, sparkBlocks)
+}
+
+// ASCIISparkline is Sparkline drawn with ASCII characters.
+func ASCIISparkline(values []int64, width int) string {
+	return sparkline(values, width, asciiBlocks)
+}
+
+func sparkline(values []int64, width int, blocks []rune) string {
+	if width > 0 && len(values) > width {
+		values = values[len(values)-width:]
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if hi > lo {
+			i = int((v - lo) * int64(len(blocks)-1) / (hi - lo))
+		}
+		b.WriteRune(blocks[i])
+	}
+	return b.String()
+}
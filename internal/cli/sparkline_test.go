@@ -0,0 +1,38 @@
+package cli_test
+
+import (
+	"testing"
+
+	"github.com/openstatusHQ/cli/internal/cli"
+)
+
+func Test_Sparkline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		values []int64
+		width  int
+		want   string
+	}{
+		{"empty", nil, 0, ""},
+		{"scaled between min and max", []int64{100, 450, 800}, 0, "▁▄█"},
+		{"flat", []int64{120, 120}, 0, "▁▁"},
+		{"keeps the last values", []int64{800, 100, 800}, 2, "▁█"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cli.Sparkline(tt.values, tt.width); got != tt.want {
+				t.Errorf("Sparkline(%v, %d) = %q, want %q", tt.values, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ASCIISparkline(t *testing.T) {
+	t.Parallel()
+
+	if got := cli.ASCIISparkline([]int64{0, 30, 70, 50}, 0); got != "_:#+" {
+		t.Errorf("ASCIISparkline() = %q, want %q", got, "_:#+")
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/openstatusHQ/cli/internal/statuspage"
 	"github.com/openstatusHQ/cli/internal/statusreport"
 	"github.com/openstatusHQ/cli/internal/terraform"
+	"github.com/openstatusHQ/cli/internal/top"
 	"github.com/openstatusHQ/cli/internal/whoami"
 )
 
@@ -39,6 +40,7 @@ Get started:
   openstatus maintenance list     View maintenance windows
   openstatus monitors apply       Sync monitors from config
   openstatus monitors list        List your monitors
+  openstatus top                  Watch workspace health live
   openstatus run                  Run synthetic tests
 
 https://docs.openstatus.dev  |  https://github.com/openstatusHQ/cli/issues/new`,
@@ -72,6 +74,8 @@ https://docs.openstatus.dev  |  https://github.com/openstatusHQ/cli/issues/new`,
 		Commands: []*cli.Command{
 			check.CheckCmd(),
 			monitors.MonitorsCmd(),
+			monitors.SLOCmd(),
+			top.TopCmd(),
 			statusreport.StatusReportCmd(),
 			maintenance.MaintenanceCmd(),
 			statuspage.StatusPageCmd(),
@@ -32,13 +32,15 @@ func Test_NewApp(t *testing.T) {
 	t.Run("Has expected commands", func(t *testing.T) {
 		app := cmd.NewApp()
 
-		if len(app.Commands) != 11 {
-			t.Errorf("Expected 11 commands, got %d", len(app.Commands))
+		if len(app.Commands) != 13 {
+			t.Errorf("Expected 13 commands, got %d", len(app.Commands))
 		}
 
 		expectedCommands := map[string]bool{
 			"check":         false,
 			"monitors":      false,
+			"slo":           false,
+			"top":           false,
 			"status-report": false,
 			"maintenance":   false,
 			"status-page":   false,
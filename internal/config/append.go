@@ -0,0 +1,85 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigHeader starts the config files written by the CLI, so that editors
+// validate them against the schema.
+const ConfigHeader = "# yaml-language-server: $schema=https://www.openstatus.dev/schema.json\n\n"
+
+// AppendMonitor adds the monitor under name at the end of the config file,
+// keeping what the file already holds. The file is created when missing. The
+// name must not be defined in the file or in the files it includes.
+func AppendMonitor(path, name string, m Monitor) error {
+	if slices.Contains([]string{movedKey, includeKey, defaultsKey, templatesKey}, name) {
+		return fmt.Errorf("invalid monitor name %q: reserved by openstatus.yaml", name)
+	}
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(bytes.TrimSpace(existing)) > 0 {
+		conf, err := ReadOpenStatusFile(path, ReadOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if _, ok := conf.Monitors[name]; ok {
+			return fmt.Errorf("monitor %q is already defined in %s or the files it includes", name, path)
+		}
+	}
+
+	b, err := marshalMonitor(name, m)
+	if err != nil {
+		return err
+	}
+
+	// A blank file is rewritten from scratch, with the schema header.
+	flag := os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	var out bytes.Buffer
+	switch {
+	case len(bytes.TrimSpace(existing)) == 0:
+		flag = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		out.WriteString(ConfigHeader)
+	case !bytes.HasSuffix(existing, []byte("\n")):
+		out.WriteString("\n\n")
+	default:
+		out.WriteString("\n")
+	}
+	out.Write(b)
+
+	file, err := os.OpenFile(path, flag, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(out.Bytes())
+	return err
+}
+
+// marshalMonitor returns the YAML of the monitor under name. Empty objects,
+// such as an unset openTelemetry, are left out.
+func marshalMonitor(name string, m Monitor) ([]byte, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	for k, v := range fields {
+		if obj, ok := v.(map[string]any); ok && len(obj) == 0 {
+			delete(fields, k)
+		}
+	}
+	return yaml.Marshal(map[string]any{name: fields})
+}
@@ -0,0 +1,98 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openstatusHQ/cli/internal/config"
+)
+
+func Test_AppendMonitor(t *testing.T) {
+	t.Parallel()
+	m := config.Monitor{
+		Name:       "api-ping",
+		Frequency:  config.The10M,
+		Regions:    []config.Region{config.Ams},
+		Active:     true,
+		Kind:       config.HTTP,
+		Request:    config.Request{URL: "https://openstat.us", Method: config.Get},
+		Assertions: []config.Assertion{{Kind: config.StatusCode, Compare: config.Eq, Target: 200}},
+	}
+
+	t.Run("creates the file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openstatus.yaml")
+		if err := config.AppendMonitor(path, "api-ping", m); err != nil {
+			t.Fatal(err)
+		}
+		data, _ := os.ReadFile(path)
+		if !strings.HasPrefix(string(data), "# yaml-language-server: $schema=") {
+			t.Errorf("missing schema header:\n%s", data)
+		}
+		if strings.Contains(string(data), "openTelemetry") {
+			t.Errorf("expected the empty openTelemetry to be left out:\n%s", data)
+		}
+		got, err := config.ReadOpenStatus(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if saved := got["api-ping"]; saved.Request.URL != m.Request.URL || saved.Assertions[0].Target != 200 {
+			t.Errorf("unexpected saved monitor %+v", saved)
+		}
+	})
+
+	t.Run("appends to the file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openstatus.yaml")
+		existing := "# monitors\nhome:\n  name: Home\n  kind: http\n  frequency: 1m\n  regions: [iad]\n  active: true\n  request:\n    url: https://example.com"
+		if err := os.WriteFile(path, []byte(existing), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := config.AppendMonitor(path, "api-ping", m); err != nil {
+			t.Fatal(err)
+		}
+		data, _ := os.ReadFile(path)
+		if !strings.HasPrefix(string(data), existing+"\n\napi-ping:\n") {
+			t.Errorf("expected the monitor after the existing content:\n%s", data)
+		}
+		got, err := config.ReadOpenStatus(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 {
+			t.Errorf("expected 2 monitors, got %d", len(got))
+		}
+
+		if err := config.AppendMonitor(path, "api-ping", m); err == nil || !strings.Contains(err.Error(), "already defined") {
+			t.Errorf("expected a duplicate error, got %v", err)
+		}
+	})
+
+	t.Run("name defined in an included file", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "openstatus.yaml")
+		if err := os.WriteFile(path, []byte("include:\n  - monitors/\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Mkdir(filepath.Join(dir, "monitors"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := config.AppendMonitor(filepath.Join(dir, "monitors", "http.yaml"), "api-ping", m); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := config.AppendMonitor(path, "api-ping", m); err == nil || !strings.Contains(err.Error(), "already defined") {
+			t.Errorf("expected a duplicate error, got %v", err)
+		}
+		if _, err := config.ReadOpenStatusFile(path, config.ReadOptions{}); err != nil {
+			t.Errorf("expected the config to stay valid, got %v", err)
+		}
+	})
+
+	t.Run("reserved name", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openstatus.yaml")
+		if err := config.AppendMonitor(path, "defaults", m); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
@@ -1,13 +1,103 @@
 package config
 
 import (
+	"reflect"
+	"strconv"
+
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/file"
 	"github.com/knadh/koanf/v2"
 )
 
+// LatencyBudget holds the thresholds a monitor run must meet in openstatus run.
+// Latencies are in milliseconds; zero values are not checked.
+type LatencyBudget struct {
+	// Maximum latency of each region
+	MaxLatency RegionLatency `koanf:"maxLatency"`
+	// Maximum 95th percentile of the latencies across regions
+	P95Latency int64 `koanf:"p95Latency"`
+	// Minimum number of regions that must pass
+	MinPassingRegions int `koanf:"minPassingRegions"`
+	// Regions that must run and pass
+	RequiredRegions []string `koanf:"requiredRegions"`
+}
+
+func (b LatencyBudget) IsZero() bool {
+	return len(b.MaxLatency) == 0 && b.P95Latency == 0 && b.MinPassingRegions == 0 && len(b.RequiredRegions) == 0
+}
+
+// RegionLatency is a latency threshold by region code. The "default" key
+// applies to the regions not listed; a single number sets only the default.
+type RegionLatency map[string]int64
+
+// DefaultRegion is the key of the threshold of the regions not listed.
+const DefaultRegion = "default"
+
+// For returns the threshold of a region, zero when there is none.
+func (l RegionLatency) For(region string) int64 {
+	if v, ok := l[region]; ok {
+		return v
+	}
+	return l[DefaultRegion]
+}
+
+// regionLatencyHook decodes a single number into a RegionLatency default.
+func regionLatencyHook(f reflect.Type, t reflect.Type, data any) (any, error) {
+	if t != reflect.TypeFor[RegionLatency]() || f.Kind() == reflect.Map {
+		return data, nil
+	}
+	return map[string]any{DefaultRegion: data}, nil
+}
+
+// TestEntry is a monitor to run, with an optional latency budget. The
+// monitor is designated by its ID or by its name in openstatus.lock or in
+// the workspace.
+type TestEntry struct {
+	ID            int    `koanf:"id"`
+	Name          string `koanf:"name"`
+	LatencyBudget `koanf:",squash"`
+}
+
+func (e TestEntry) key() string {
+	if e.Name != "" {
+		return "name:" + e.Name
+	}
+	return "id:" + strconv.Itoa(e.ID)
+}
+
 type TestsConfig struct {
-	Ids []int `koanf:"ids"`
+	Ids      []int       `koanf:"ids"`
+	Names    []string    `koanf:"names"`
+	Monitors []TestEntry `koanf:"monitors"`
+}
+
+// Entries returns the monitors listed in ids, names and monitors, in order.
+// A monitor listed twice is run once, with the budget from monitors.
+func (t TestsConfig) Entries() []TestEntry {
+	size := len(t.Ids) + len(t.Names) + len(t.Monitors)
+	entries := make([]TestEntry, 0, size)
+	index := make(map[string]int, size)
+	add := func(e TestEntry) {
+		if i, ok := index[e.key()]; ok {
+			if !e.IsZero() {
+				entries[i] = e
+			}
+			return
+		}
+		index[e.key()] = len(entries)
+		entries = append(entries, e)
+	}
+	for _, id := range t.Ids {
+		add(TestEntry{ID: id})
+	}
+	for _, name := range t.Names {
+		add(TestEntry{Name: name})
+	}
+	for _, e := range t.Monitors {
+		add(e)
+	}
+	return entries
 }
 
 type Config struct {
@@ -23,7 +113,17 @@ func ReadConfig(path string) (*Config, error) {
 	}
 
 	var out Config
-	if err := k.Unmarshal("", &out); err != nil {
+	err := k.UnmarshalWithConf("", &out, koanf.UnmarshalConf{
+		DecoderConfig: &mapstructure.DecoderConfig{
+			DecodeHook: mapstructure.ComposeDecodeHookFunc(
+				mapstructure.StringToTimeDurationHookFunc(),
+				mapstructure.TextUnmarshallerHookFunc(),
+				regionLatencyHook,
+			),
+			WeaklyTypedInput: true,
+		},
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -104,3 +104,44 @@ func Test_ReadConfig_NoStatePollution(t *testing.T) {
 		t.Errorf("Second read: expected [4,5,6], got %v", out2.Tests.Ids)
 	}
 }
+
+func Test_ReadConfig_Budgets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.openstatus.yaml")
+	if err := os.WriteFile(path, []byte(`
+tests:
+  ids:
+    - 1
+    - 2
+  names:
+    - api-prod
+  monitors:
+    - id: 2
+      maxLatency: 2000
+      p95Latency: 1500
+    - id: 3
+      maxLatency:
+        syd: 1200
+        default: 800
+      minPassingRegions: 3
+      requiredRegions: [iad, fra]
+    - name: api-prod
+      maxLatency: 1000
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := config.ReadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []config.TestEntry{
+		{ID: 1},
+		{ID: 2, LatencyBudget: config.LatencyBudget{MaxLatency: config.RegionLatency{"default": 2000}, P95Latency: 1500}},
+		{Name: "api-prod", LatencyBudget: config.LatencyBudget{MaxLatency: config.RegionLatency{"default": 1000}}},
+		{ID: 3, LatencyBudget: config.LatencyBudget{MaxLatency: config.RegionLatency{"syd": 1200, "default": 800}, MinPassingRegions: 3, RequiredRegions: []string{"iad", "fra"}}},
+	}
+	if diff := cmp.Diff(expect, out.Tests.Entries()); diff != "" {
+		t.Errorf("Unexpected entries (-want +got):\n%s", diff)
+	}
+}
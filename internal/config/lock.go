@@ -2,11 +2,14 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/file"
 	"github.com/knadh/koanf/v2"
+	k8syaml "sigs.k8s.io/yaml"
 )
 
 type Lock struct {
@@ -40,3 +43,62 @@ func ReadLockFile(filename string) (MonitorsLock, error) {
 
 	return out, nil
 }
+
+// Move renames the entry stored under from to to, keeping its monitor ID.
+// It is a no-op when from is absent and to is already present, so that a
+// moved declaration can stay in openstatus.yaml after it has been applied.
+func (l MonitorsLock) Move(from, to string) (bool, error) {
+	if from == to {
+		return false, fmt.Errorf("cannot move %q to itself", from)
+	}
+	entry, hasFrom := l[from]
+	_, hasTo := l[to]
+	switch {
+	case hasFrom && hasTo:
+		return false, fmt.Errorf("cannot move %q to %q: %q already exists in the lock file", from, to, to)
+	case !hasFrom:
+		return false, nil
+	}
+	delete(l, from)
+	l[to] = entry
+	return true, nil
+}
+
+// WriteLockFile serializes the lock and atomically replaces filename, so an
+// interrupted write never leaves a truncated lock file behind.
+func WriteLockFile(filename string, lock MonitorsLock) error {
+	y, err := k8syaml.Marshal(&lock)
+	if err != nil {
+		return fmt.Errorf("failed to marshal lock file: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+
+	if _, err := tmpFile.Write(y); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to sync lock file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0o600); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to set lock file permissions: %w", err)
+	}
+	if err := os.Rename(tmpPath, filename); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to save lock file: %w", err)
+	}
+	return nil
+}
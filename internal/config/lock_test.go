@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -103,3 +104,114 @@ func Test_getMonitorTrigger(t *testing.T) {
 		}
 	})
 }
+
+func Test_WriteLockFile(t *testing.T) {
+	t.Run("Round trips through ReadLockFile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openstatus.lock")
+
+		lock := config.MonitorsLock{
+			"test-monitor": {
+				ID: 42,
+				Monitor: config.Monitor{
+					Name:      "Uptime Monitor",
+					Active:    true,
+					Frequency: config.The5M,
+					Kind:      config.HTTP,
+					Regions:   []config.Region{config.Iad},
+					Request: config.Request{
+						URL:    "https://openstat.us",
+						Method: config.Get,
+					},
+					Assertions: []config.Assertion{
+						{Compare: config.Eq, Kind: config.StatusCode, Target: 200},
+					},
+				},
+			},
+		}
+
+		if err := config.WriteLockFile(path, lock); err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := config.ReadLockFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !cmp.Equal(lock, out) {
+			t.Errorf("Expected %v, got %v", lock, out)
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0o600 {
+			t.Errorf("Expected permissions 0600, got %v", info.Mode().Perm())
+		}
+	})
+
+	t.Run("Replaces existing content and leaves no temp files", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "openstatus.lock")
+		if err := os.WriteFile(path, []byte(lockfile), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := config.WriteLockFile(path, config.MonitorsLock{}); err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := config.ReadLockFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(out) != 0 {
+			t.Errorf("Expected empty lock, got %v", out)
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 {
+			t.Errorf("Expected only the lock file in %s, got %d entries", dir, len(entries))
+		}
+	})
+}
+
+func Test_MonitorsLock_Move(t *testing.T) {
+	t.Run("Renames the entry and keeps its ID", func(t *testing.T) {
+		lock := config.MonitorsLock{"api-prod": {ID: 42}}
+		ok, err := lock.Move("api-prod", "api-production")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Error("Expected the entry to be moved")
+		}
+		if _, exists := lock["api-prod"]; exists {
+			t.Error("Expected old name to be removed")
+		}
+		if lock["api-production"].ID != 42 {
+			t.Errorf("Expected ID 42, got %d", lock["api-production"].ID)
+		}
+	})
+
+	t.Run("Already applied move is a no-op", func(t *testing.T) {
+		lock := config.MonitorsLock{"api-production": {ID: 42}}
+		ok, err := lock.Move("api-prod", "api-production")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			t.Error("Expected no move")
+		}
+	})
+
+	t.Run("Existing destination returns error", func(t *testing.T) {
+		lock := config.MonitorsLock{"api-prod": {ID: 1}, "api-production": {ID: 2}}
+		if _, err := lock.Move("api-prod", "api-production"); err == nil {
+			t.Error("Expected error when the destination exists, got nil")
+		}
+	})
+}
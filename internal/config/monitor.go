@@ -24,6 +24,10 @@ type Monitor struct {
 	Assertions []Assertion `json:"assertions,omitempty" ,yaml:"assertions,omitempty"`
 	// OpenTelemetry configuration
 	OpenTelemetry OpenTelemetryConfig `json:"openTelemetry,omitempty" ,yaml:"openTelemetry,omitempty"`
+	// Refuse to delete or replace the monitor on apply. Only used by the CLI.
+	PreventDestroy bool `json:"preventDestroy,omitempty" ,yaml:"preventDestroy,omitempty"`
+	// Team owning the monitor, used to split imports. Only used by the CLI.
+	Team string `json:"team,omitempty" ,yaml:"team,omitempty"`
 }
 
 type Assertion struct {
@@ -38,6 +42,8 @@ type Assertion struct {
 	Target any `json:"target" ,yaml:"target"`
 	// Header key to assert
 	Key string `json:"key,omitempty" ,yaml:"key,omitempty"`
+	// DNS record type to assert
+	Record RecordType `json:"record,omitempty" ,yaml:"record,omitempty"`
 }
 
 // The HTTP Request we are sending
@@ -50,7 +56,7 @@ type Request struct {
 	URL string `json:"url,omitempty" ,yaml:"url,omitempty"`
 	// Whether to follow HTTP redirects (defaults to true when not specified)
 	FollowRedirects *bool `json:"followRedirects,omitempty" ,yaml:"followRedirects,omitempty"`
-	// Host to connect to
+	// Host to connect to, or domain to resolve for DNS monitors
 	Host string `json:"host,omitempty" ,yaml:"host,omitempty"`
 	// Port to connect to
 	Port int64 `json:"port,omitempty" ,yaml:"port,omitempty"`
@@ -80,11 +86,24 @@ const (
 type AssertionKind string
 
 const (
+	DNSRecord  AssertionKind = "dnsRecord"
 	Header     AssertionKind = "header"
 	StatusCode AssertionKind = "statusCode"
 	TextBody   AssertionKind = "textBody"
 )
 
+// DNS record type
+type RecordType string
+
+const (
+	RecordA     RecordType = "A"
+	RecordAAAA  RecordType = "AAAA"
+	RecordCNAME RecordType = "CNAME"
+	RecordMX    RecordType = "MX"
+	RecordNS    RecordType = "NS"
+	RecordTXT   RecordType = "TXT"
+)
+
 type Frequency string
 
 const (
@@ -99,6 +118,7 @@ const (
 type CoordinateKind string
 
 const (
+	DNS  CoordinateKind = "dns"
 	HTTP CoordinateKind = "http"
 	TCP  CoordinateKind = "tcp"
 )
@@ -179,7 +199,7 @@ func ConvertAssertionTargets(assertions []Assertion) {
 	for i := range assertions {
 		assertion := &assertions[i]
 		switch assertion.Kind {
-		case Header, TextBody:
+		case Header, TextBody, DNSRecord:
 			if s, ok := assertion.Target.(string); ok {
 				assertion.Target = s
 			}
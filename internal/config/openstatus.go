@@ -1,6 +1,14 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/file"
 	"github.com/knadh/koanf/v2"
@@ -8,26 +16,342 @@ import (
 
 type Monitors map[string]Monitor
 
-func ReadOpenStatus(path string) (Monitors, error) {
+// Move declares that the monitor stored under From in the lock file is now
+// defined under To, so apply renames the lock entry instead of replacing it.
+type Move struct {
+	From string `json:"from" ,yaml:"from"`
+	To   string `json:"to" ,yaml:"to"`
+}
+
+// OpenStatus is the content of an openstatus.yaml file. Monitors are keyed
+// by their logical name at the top level, next to the reserved keys below.
+type OpenStatus struct {
+	Monitors Monitors
+	Moved    []Move
+}
+
+const (
+	movedKey     = "moved"
+	includeKey   = "include"
+	defaultsKey  = "defaults"
+	templatesKey = "templates"
+)
+
+// ReadOptions controls how openstatus.yaml is loaded.
+type ReadOptions struct {
+	// Overlays are merged over the base file in order, e.g. openstatus.prod.yaml.
+	Overlays []string
+	// Vars take precedence over the environment when interpolating ${VAR}.
+	Vars Variables
+}
+
+// ReadOpenStatusFile loads the monitors from path, which may be a file, a
+// directory or a glob, following include lists. Each monitor is expanded from
+// the defaults of its file and the templates it extends, then the overlays
+// are merged on top. ${VAR} references are interpolated; secret references (${secret:NAME})
+// are kept as-is and only resolved by ResolveSecrets when calling the API.
+// A logical name defined in more than one file is an error.
+func ReadOpenStatusFile(path string, opts ReadOptions) (OpenStatus, error) {
+	files, err := ExpandConfigPath(path)
+	if err != nil {
+		return OpenStatus{}, err
+	}
+
+	r := &configReader{
+		vars:            opts.Vars,
+		monitors:        map[string]rawMonitor{},
+		templates:       map[string]map[string]any{},
+		templateSources: map[string]string{},
+		visited:         map[string]bool{},
+	}
+	for _, f := range files {
+		if err := r.readFile(f, nil); err != nil {
+			return OpenStatus{}, err
+		}
+	}
+
+	expanded, err := r.expandMonitors()
+	if err != nil {
+		return OpenStatus{}, err
+	}
+
 	k := koanf.New(".")
+	if err := k.Load(mapProvider(expanded), nil); err != nil {
+		return OpenStatus{}, err
+	}
+	for _, p := range opts.Overlays {
+		doc, err := r.parse(p)
+		if err != nil {
+			return OpenStatus{}, err
+		}
+		for _, key := range []string{includeKey, defaultsKey, templatesKey} {
+			if _, ok := doc[key]; ok {
+				return OpenStatus{}, fmt.Errorf("%s: %q is not supported in overlays", p, key)
+			}
+		}
+		moved, _, err := readMeta(doc)
+		if err != nil {
+			return OpenStatus{}, fmt.Errorf("%s: %w", p, err)
+		}
+		r.moved = append(r.moved, moved...)
+		delete(doc, movedKey)
+		if err := k.Load(mapProvider(doc), nil); err != nil {
+			return OpenStatus{}, err
+		}
+	}
 
-	f := file.Provider(path)
-	if err := k.Load(f, yaml.Parser()); err != nil {
+	out := OpenStatus{Moved: r.moved}
+	if err := k.Unmarshal("", &out.Monitors); err != nil {
+		return OpenStatus{}, err
+	}
+
+	for _, value := range out.Monitors {
+		ConvertAssertionTargets(value.Assertions)
+	}
+
+	return out, nil
+}
+
+// ExpandConfigPath returns the config files designated by path: the YAML
+// files of a directory, the matches of a glob, or path itself.
+func ExpandConfigPath(path string) ([]string, error) {
+	if strings.ContainsAny(path, "*?[") {
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", path, err)
+		}
+		var files []string
+		for _, m := range matches {
+			if info, err := os.Stat(m); err == nil && !info.IsDir() {
+				files = append(files, m)
+			}
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no configuration files match %s", path)
+		}
+		return files, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return []string{path}, nil
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
 		return nil, err
 	}
+	var files []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		if ext := filepath.Ext(name); ext == ".yaml" || ext == ".yml" {
+			files = append(files, filepath.Join(path, name))
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no configuration files found in %s", path)
+	}
+	return files, nil
+}
+
+// configReader accumulates monitors and templates across files and includes.
+type configReader struct {
+	vars            Variables
+	monitors        map[string]rawMonitor
+	templates       map[string]map[string]any
+	templateSources map[string]string
+	moved           []Move
+	visited         map[string]bool
+}
 
-	var out Monitors
-	if err := k.Unmarshal("", &out); err != nil {
+// rawMonitor is a monitor as written, with the defaults in effect for its file.
+type rawMonitor struct {
+	value    map[string]any
+	defaults map[string]any
+	source   string
+}
+
+func (r *configReader) parse(path string) (map[string]any, error) {
+	b, err := file.Provider(path).ReadBytes()
+	if err != nil {
 		return nil, err
 	}
+	doc, err := yaml.Parser().Unmarshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	expanded, err := r.vars.expand(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return expanded.(map[string]any), nil
+}
 
-	for _, value := range out {
-		ConvertAssertionTargets(value.Assertions)
+// readFile reads a config file and the files it includes. Defaults declared
+// in a file apply to its monitors and to the files it includes.
+func (r *configReader) readFile(path string, inherited map[string]any) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if r.visited[abs] {
+		return nil
+	}
+	r.visited[abs] = true
+
+	doc, err := r.parse(path)
+	if err != nil {
+		return err
+	}
+	moved, includes, err := readMeta(doc)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	r.moved = append(r.moved, moved...)
+
+	defaults := inherited
+	if v, ok := doc[defaultsKey]; ok {
+		d, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: %q must be a mapping", path, defaultsKey)
+		}
+		defaults = mergeMaps(inherited, d)
+	}
+
+	if v, ok := doc[templatesKey]; ok {
+		templates, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: %q must be a mapping", path, templatesKey)
+		}
+		for name, t := range templates {
+			tmpl, ok := t.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%s: template %q must be a mapping", path, name)
+			}
+			if prev, ok := r.templateSources[name]; ok {
+				return fmt.Errorf("template %q is defined in both %s and %s", name, prev, path)
+			}
+			r.templateSources[name] = path
+			r.templates[name] = tmpl
+		}
 	}
 
+	for name, value := range doc {
+		switch name {
+		case movedKey, includeKey, defaultsKey, templatesKey:
+			continue
+		}
+		if prev, ok := r.monitors[name]; ok {
+			return fmt.Errorf("monitor %q is defined in both %s and %s", name, prev.source, path)
+		}
+		m, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: monitor %q must be a mapping", path, name)
+		}
+		r.monitors[name] = rawMonitor{value: m, defaults: defaults, source: path}
+	}
+
+	for _, inc := range includes {
+		if !filepath.IsAbs(inc) {
+			inc = filepath.Join(filepath.Dir(path), inc)
+		}
+		files, err := ExpandConfigPath(inc)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		for _, f := range files {
+			if err := r.readFile(f, defaults); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// expandMonitors merges every monitor over its defaults and templates.
+// Later sources win: defaults, then templates in extends order, then the
+// monitor itself. Nested mappings are merged and lists are replaced.
+func (r *configReader) expandMonitors() (map[string]any, error) {
+	out := make(map[string]any, len(r.monitors))
+	for name, m := range r.monitors {
+		value, err := r.applyTemplates(m.value, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s: monitor %q: %w", m.source, name, err)
+		}
+		out[name] = mergeMaps(m.defaults, value)
+	}
 	return out, nil
 }
 
+func (r *configReader) applyTemplates(value map[string]any, chain []string) (map[string]any, error) {
+	names, err := extendsList(value[extendsKey])
+	if err != nil {
+		return nil, err
+	}
+	var base map[string]any
+	for _, name := range names {
+		if slices.Contains(chain, name) {
+			return nil, fmt.Errorf("template cycle: %s", strings.Join(append(chain, name), " -> "))
+		}
+		tmpl, ok := r.templates[name]
+		if !ok {
+			return nil, fmt.Errorf("extends unknown template %q", name)
+		}
+		expanded, err := r.applyTemplates(tmpl, append(slices.Clone(chain), name))
+		if err != nil {
+			return nil, err
+		}
+		base = mergeMaps(base, expanded)
+	}
+	own := maps.Clone(value)
+	delete(own, extendsKey)
+	return mergeMaps(base, own), nil
+}
+
+// readMeta decodes the reserved moved and include sections of a document.
+func readMeta(doc map[string]any) ([]Move, []string, error) {
+	meta := map[string]any{}
+	for _, key := range []string{movedKey, includeKey} {
+		if v, ok := doc[key]; ok {
+			meta[key] = v
+		}
+	}
+	k := koanf.New(".")
+	if err := k.Load(mapProvider(meta), nil); err != nil {
+		return nil, nil, err
+	}
+	var moved []Move
+	if err := k.Unmarshal(movedKey, &moved); err != nil {
+		return nil, nil, fmt.Errorf("invalid %q section: %w", movedKey, err)
+	}
+	var includes []string
+	if err := k.Unmarshal(includeKey, &includes); err != nil {
+		return nil, nil, fmt.Errorf("invalid %q section: %w", includeKey, err)
+	}
+	return moved, includes, nil
+}
+
+// mapProvider feeds an already parsed document to koanf.
+type mapProvider map[string]any
+
+func (m mapProvider) ReadBytes() ([]byte, error) {
+	return nil, errors.New("mapProvider does not support ReadBytes")
+}
+
+func (m mapProvider) Read() (map[string]any, error) {
+	return m, nil
+}
+
+func ReadOpenStatus(path string) (Monitors, error) {
+	out, err := ReadOpenStatusFile(path, ReadOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return out.Monitors, nil
+}
+
 func ParseConfigMonitorsToMonitor(monitors Monitors) []Monitor {
 	var monitor []Monitor
 	for _, value := range monitors {
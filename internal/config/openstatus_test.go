@@ -3,6 +3,7 @@ package config_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/openstatusHQ/cli/internal/config"
@@ -180,6 +181,61 @@ func Test_ReadOpenStatus_FollowRedirects(t *testing.T) {
 	})
 }
 
+func Test_ReadOpenStatus_DNS(t *testing.T) {
+	yaml := `
+"dns-monitor":
+  active: true
+  frequency: 10m
+  kind: dns
+  name: DNS Monitor
+  regions:
+    - iad
+  request:
+    host: example.com
+  assertions:
+    - kind: dnsRecord
+      record: A
+      compare: eq
+      target: 93.184.216.34
+`
+	f, err := os.CreateTemp(t.TempDir(), "openstatus*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(yaml); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := config.ReadOpenStatus(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	monitor, exists := out["dns-monitor"]
+	if !exists {
+		t.Fatal("Expected 'dns-monitor' to exist in output")
+	}
+	if monitor.Kind != config.DNS {
+		t.Errorf("Expected kind 'dns', got %s", monitor.Kind)
+	}
+	if monitor.Request.Host != "example.com" {
+		t.Errorf("Expected host 'example.com', got %s", monitor.Request.Host)
+	}
+	if len(monitor.Assertions) != 1 {
+		t.Fatalf("Expected 1 assertion, got %d", len(monitor.Assertions))
+	}
+	a := monitor.Assertions[0]
+	if a.Kind != config.DNSRecord || a.Record != config.RecordA || a.Compare != config.Eq {
+		t.Errorf("Unexpected assertion %+v", a)
+	}
+	if a.Target != "93.184.216.34" {
+		t.Errorf("Expected target '93.184.216.34', got %v", a.Target)
+	}
+}
+
 func Test_ParseConfigMonitorsToMonitor(t *testing.T) {
 	t.Run("Parse monitors map to slice", func(t *testing.T) {
 		monitors := config.Monitors{
@@ -317,3 +373,155 @@ func Test_ReadOpenStatus_NoStatePollution(t *testing.T) {
 		t.Errorf("Second read: expected 1 monitor, got %d", len(out2))
 	}
 }
+
+func Test_ReadOpenStatusFile_Moved(t *testing.T) {
+	yaml := `
+moved:
+  - from: api-prod
+    to: api-production
+"api-production":
+  active: true
+  frequency: 10m
+  kind: http
+  name: API
+  regions:
+    - iad
+  request:
+    method: GET
+    url: https://example.com
+`
+	f, err := os.CreateTemp(t.TempDir(), "openstatus*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(yaml); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := config.ReadOpenStatusFile(f.Name(), config.ReadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(out.Monitors) != 1 {
+		t.Fatalf("Expected 1 monitor, got %d: %v", len(out.Monitors), out.Monitors)
+	}
+	if _, exists := out.Monitors["moved"]; exists {
+		t.Error("Expected 'moved' not to be read as a monitor")
+	}
+	if len(out.Moved) != 1 || out.Moved[0].From != "api-prod" || out.Moved[0].To != "api-production" {
+		t.Errorf("Unexpected moved section %+v", out.Moved)
+	}
+
+	monitors, err := config.ReadOpenStatus(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(monitors) != 1 {
+		t.Errorf("Expected 1 monitor, got %d", len(monitors))
+	}
+}
+
+func monitorYAML(name string) string {
+	return `
+"` + name + `":
+  active: true
+  frequency: 10m
+  kind: http
+  name: ` + name + `
+  regions:
+    - iad
+  request:
+    method: GET
+    url: https://example.com/` + name + `
+`
+}
+
+func Test_ReadOpenStatusFile_MultipleFiles(t *testing.T) {
+	t.Run("Loads every YAML file in a directory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "payments.yaml"), monitorYAML("checkout"))
+		writeFile(t, filepath.Join(dir, "search.yml"), monitorYAML("search"))
+		writeFile(t, filepath.Join(dir, "README.md"), "not a config")
+
+		out, err := config.ReadOpenStatusFile(dir, config.ReadOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(out.Monitors) != 2 {
+			t.Errorf("Expected 2 monitors, got %v", out.Monitors)
+		}
+	})
+
+	t.Run("Loads files matching a glob", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "payments.yaml"), monitorYAML("checkout"))
+		writeFile(t, filepath.Join(dir, "search.yml"), monitorYAML("search"))
+
+		out, err := config.ReadOpenStatusFile(filepath.Join(dir, "*.yaml"), config.ReadOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := out.Monitors["checkout"]; !ok || len(out.Monitors) != 1 {
+			t.Errorf("Expected only checkout, got %v", out.Monitors)
+		}
+	})
+
+	t.Run("Follows includes relative to the including file", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.Mkdir(filepath.Join(dir, "monitors"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, filepath.Join(dir, "monitors", "payments.yaml"), monitorYAML("checkout"))
+		writeFile(t, filepath.Join(dir, "monitors", "search.yaml"), monitorYAML("search"))
+		root := filepath.Join(dir, "openstatus.yaml")
+		writeFile(t, root, "include:\n  - monitors/\n"+monitorYAML("homepage"))
+
+		out, err := config.ReadOpenStatusFile(root, config.ReadOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range []string{"homepage", "checkout", "search"} {
+			if _, ok := out.Monitors[name]; !ok {
+				t.Errorf("Expected monitor %q, got %v", name, out.Monitors)
+			}
+		}
+		if _, ok := out.Monitors["include"]; ok {
+			t.Error("Expected 'include' not to be read as a monitor")
+		}
+	})
+
+	t.Run("Duplicate names across files return error", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "a.yaml"), monitorYAML("checkout"))
+		writeFile(t, filepath.Join(dir, "b.yaml"), monitorYAML("checkout"))
+
+		_, err := config.ReadOpenStatusFile(dir, config.ReadOptions{})
+		if err == nil || !strings.Contains(err.Error(), "a.yaml") || !strings.Contains(err.Error(), "b.yaml") {
+			t.Errorf("Expected duplicate error naming both files, got %v", err)
+		}
+	})
+
+	t.Run("Include cycles are loaded once", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "a.yaml"), "include: [b.yaml]\n"+monitorYAML("checkout"))
+		writeFile(t, filepath.Join(dir, "b.yaml"), "include: [a.yaml]\n"+monitorYAML("search"))
+
+		out, err := config.ReadOpenStatusFile(filepath.Join(dir, "a.yaml"), config.ReadOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(out.Monitors) != 2 {
+			t.Errorf("Expected 2 monitors, got %v", out.Monitors)
+		}
+	})
+
+	t.Run("Empty directory returns error", func(t *testing.T) {
+		if _, err := config.ReadOpenStatusFile(t.TempDir(), config.ReadOptions{}); err == nil {
+			t.Error("Expected error for empty directory, got nil")
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// enum describes a string type whose values are restricted, e.g. Region.
+type enum struct {
+	name   string
+	values []string
+}
+
+var enums = map[reflect.Type]enum{
+	reflect.TypeFor[Frequency](): {"frequency", []string{
+		string(The30S), string(The1M), string(The5M), string(The10M), string(The30M), string(The1H),
+	}},
+	reflect.TypeFor[CoordinateKind](): {"monitor kind", []string{
+		string(HTTP), string(TCP), string(DNS),
+	}},
+	reflect.TypeFor[Method](): {"method", []string{
+		string(Get), string(Post), string(Put), string(Patch), string(Delete), string(Head), string(Options),
+	}},
+	reflect.TypeFor[Compare](): {"comparison operator", []string{
+		string(Eq), string(NotEq), string(Gt), string(Gte), string(Lt), string(LTE),
+		string(Contains), string(NotContains), string(Empty), string(NotEmpty),
+	}},
+	reflect.TypeFor[AssertionKind](): {"assertion kind", []string{
+		string(StatusCode), string(Header), string(TextBody), string(DNSRecord),
+	}},
+	reflect.TypeFor[RecordType](): {"record type", []string{
+		string(RecordA), string(RecordAAAA), string(RecordCNAME), string(RecordMX), string(RecordNS), string(RecordTXT),
+	}},
+	reflect.TypeFor[Region](): {"region", []string{
+		string(Ams), string(Arn), string(Atl), string(BOM), string(Bog), string(Bos), string(Cdg),
+		string(Den), string(Dfw), string(Ewr), string(Eze), string(Fra), string(Gdl), string(Gig),
+		string(Gru), string(Hkg), string(Iad), string(Jnb), string(Lax), string(Lhr), string(Mad),
+		string(Mia), string(Nrt), string(Ord), string(Otp), string(Phx), string(Private), string(Qro),
+		string(Scl), string(Sea), string(Sin), string(Sjc), string(Syd), string(Waw), string(Yul),
+		string(Yyz), string(KoyebFra), string(KoyebPar), string(KoyebSfo), string(KoyebSin),
+		string(KoyebTyo), string(KoyebWas), string(RailwayUsWest2), string(RailwayUsEast4),
+		string(RailwayEuropeWest4), string(RailwayAsiaSoutheast1),
+	}},
+}
+
+type schemaKind int
+
+const (
+	objectSchema schemaKind = iota
+	arraySchema
+	mapSchema
+	stringSchema
+	integerSchema
+	booleanSchema
+	scalarSchema
+	// nameListSchema is a single string or a list of strings, e.g. extends.
+	nameListSchema
+)
+
+// schema describes the shape of a value in openstatus.yaml. It is derived
+// from the json tags of Monitor so that validation and the JSON Schema
+// never drift from the fields the CLI actually reads.
+type schema struct {
+	kind   schemaKind
+	fields []schemaField
+	items  *schema
+	enum   *enum
+}
+
+type schemaField struct {
+	name   string
+	schema *schema
+}
+
+var (
+	monitorSchema = schemaFor(reflect.TypeFor[Monitor]())
+	// extendableSchema is a monitor or template, which may extend templates.
+	extendableSchema = withField(monitorSchema, extendsKey, &schema{kind: nameListSchema})
+	moveSchema       = schemaFor(reflect.TypeFor[Move]())
+)
+
+func schemaFor(t reflect.Type) *schema {
+	if e, ok := enums[t]; ok {
+		return &schema{kind: stringSchema, enum: &e}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaFor(t.Elem())
+	case reflect.Struct:
+		s := &schema{kind: objectSchema}
+		for i := range t.NumField() {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			s.fields = append(s.fields, schemaField{name: name, schema: schemaFor(f.Type)})
+		}
+		return s
+	case reflect.Slice:
+		return &schema{kind: arraySchema, items: schemaFor(t.Elem())}
+	case reflect.Map:
+		return &schema{kind: mapSchema, items: schemaFor(t.Elem())}
+	case reflect.String:
+		return &schema{kind: stringSchema}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return &schema{kind: integerSchema}
+	case reflect.Bool:
+		return &schema{kind: booleanSchema}
+	default:
+		return &schema{kind: scalarSchema}
+	}
+}
+
+func withField(s *schema, name string, field *schema) *schema {
+	out := *s
+	out.fields = append(append([]schemaField{}, s.fields...), schemaField{name: name, schema: field})
+	return &out
+}
+
+// field finds a property the way the config loader does: case-insensitively.
+func (s *schema) field(name string) (*schema, bool) {
+	for _, f := range s.fields {
+		if strings.EqualFold(f.name, name) {
+			return f.schema, true
+		}
+	}
+	return nil, false
+}
+
+func (s *schema) fieldNames() []string {
+	names := make([]string, len(s.fields))
+	for i, f := range s.fields {
+		names[i] = f.name
+	}
+	return names
+}
+
+func (s *schema) jsonSchema() map[string]any {
+	switch s.kind {
+	case objectSchema:
+		props := make(map[string]any, len(s.fields))
+		for _, f := range s.fields {
+			props[f.name] = f.schema.jsonSchema()
+		}
+		return map[string]any{"type": "object", "properties": props, "additionalProperties": false}
+	case arraySchema:
+		return map[string]any{"type": "array", "items": s.items.jsonSchema()}
+	case mapSchema:
+		return map[string]any{"type": "object", "additionalProperties": s.items.jsonSchema()}
+	case stringSchema:
+		if s.enum != nil {
+			return map[string]any{"type": "string", "enum": s.enum.values}
+		}
+		return map[string]any{"type": "string"}
+	case integerSchema:
+		return map[string]any{"type": "integer"}
+	case booleanSchema:
+		return map[string]any{"type": "boolean"}
+	case nameListSchema:
+		return map[string]any{"oneOf": []any{
+			map[string]any{"type": "string"},
+			map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		}}
+	default:
+		return map[string]any{"type": []string{"string", "integer", "number", "boolean"}}
+	}
+}
+
+// JSONSchema returns a JSON Schema for openstatus.yaml, for editor
+// completion and validation (e.g. with the YAML language server).
+func JSONSchema() ([]byte, error) {
+	monitorRef := map[string]any{"$ref": "#/definitions/monitor"}
+	doc := map[string]any{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"title":   "openstatus.yaml",
+		"type":    "object",
+		"properties": map[string]any{
+			movedKey:    map[string]any{"type": "array", "items": moveSchema.jsonSchema()},
+			includeKey:  (&schema{kind: nameListSchema}).jsonSchema(),
+			defaultsKey: monitorSchema.jsonSchema(),
+			templatesKey: map[string]any{
+				"type":                 "object",
+				"additionalProperties": monitorRef,
+			},
+		},
+		"additionalProperties": monitorRef,
+		"definitions": map[string]any{
+			"monitor": extendableSchema.jsonSchema(),
+		},
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const extendsKey = "extends"
+
+// extendsList accepts a single template name or a list of names.
+func extendsList(v any) ([]string, error) {
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{val}, nil
+	case []any:
+		names := make([]string, len(val))
+		for i, item := range val {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%q must list template names", extendsKey)
+			}
+			names[i] = name
+		}
+		return names, nil
+	default:
+		return nil, fmt.Errorf("%q must be a template name or a list of names", extendsKey)
+	}
+}
+
+// mergeMaps returns a new map with src merged over dst. Nested mappings are
+// merged recursively; any other value in src replaces the one in dst.
+func mergeMaps(dst, src map[string]any) map[string]any {
+	out := make(map[string]any, len(dst)+len(src))
+	for k, v := range dst {
+		out[k] = v
+	}
+	for k, v := range src {
+		if sm, ok := v.(map[string]any); ok {
+			if dm, ok := out[k].(map[string]any); ok {
+				out[k] = mergeMaps(dm, sm)
+				continue
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// ExtractDefaults returns the values shared by every document, recursing into
+// nested mappings, and the documents with those values removed. Keys listed
+// in keep are never factored out. Fewer than two documents yield no defaults.
+func ExtractDefaults(docs map[string]map[string]any, keep ...string) (map[string]any, map[string]map[string]any) {
+	if len(docs) < 2 {
+		return nil, docs
+	}
+	list := make([]map[string]any, 0, len(docs))
+	for _, d := range docs {
+		list = append(list, d)
+	}
+	defaults := commonValues(list)
+	for _, k := range keep {
+		delete(defaults, k)
+	}
+	if len(defaults) == 0 {
+		return nil, docs
+	}
+	stripped := make(map[string]map[string]any, len(docs))
+	for name, d := range docs {
+		stripped[name] = stripValues(d, defaults)
+	}
+	return defaults, stripped
+}
+
+func commonValues(list []map[string]any) map[string]any {
+	common := map[string]any{}
+	for k, first := range list[0] {
+		if fm, ok := first.(map[string]any); ok {
+			nested := []map[string]any{fm}
+			for _, d := range list[1:] {
+				m, ok := d[k].(map[string]any)
+				if !ok {
+					nested = nil
+					break
+				}
+				nested = append(nested, m)
+			}
+			if nested != nil {
+				if c := commonValues(nested); len(c) > 0 {
+					common[k] = c
+				}
+			}
+			continue
+		}
+		shared := true
+		for _, d := range list[1:] {
+			v, ok := d[k]
+			if !ok || !reflect.DeepEqual(v, first) {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			common[k] = first
+		}
+	}
+	return common
+}
+
+func stripValues(doc, defaults map[string]any) map[string]any {
+	out := make(map[string]any, len(doc))
+	for k, v := range doc {
+		d, ok := defaults[k]
+		if !ok {
+			out[k] = v
+			continue
+		}
+		vm, vok := v.(map[string]any)
+		dm, dok := d.(map[string]any)
+		if vok && dok {
+			if rest := stripValues(vm, dm); len(rest) > 0 {
+				out[k] = rest
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,180 @@
+package config_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/openstatusHQ/cli/internal/config"
+)
+
+var templatesConfig = `
+defaults:
+  active: true
+  frequency: 10m
+  kind: http
+  regions: [iad, ams]
+  retry: 3
+  request:
+    method: GET
+    headers:
+      User-Agent: OpenStatus
+
+templates:
+  authenticated:
+    request:
+      headers:
+        Authorization: Bearer ${secret:API_TOKEN}
+  critical:
+    extends: authenticated
+    frequency: 1m
+    regions: [iad, ams, fra, sin]
+    assertions:
+      - kind: statusCode
+        compare: eq
+        target: 200
+
+homepage:
+  name: Homepage
+  request:
+    url: https://example.com
+
+api:
+  name: API
+  extends: critical
+  retry: 5
+  request:
+    url: https://api.example.com
+`
+
+func Test_ReadOpenStatusFile_Templates(t *testing.T) {
+	t.Run("Monitors are expanded from defaults and templates", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openstatus.yaml")
+		writeFile(t, path, templatesConfig)
+
+		out, err := config.ReadOpenStatusFile(path, config.ReadOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(out.Monitors) != 2 {
+			t.Fatalf("Expected 2 monitors, got %v", out.Monitors)
+		}
+
+		homepage := config.Monitor{
+			Name:      "Homepage",
+			Active:    true,
+			Frequency: config.The10M,
+			Kind:      config.HTTP,
+			Regions:   []config.Region{config.Iad, config.Ams},
+			Retry:     3,
+			Request: config.Request{
+				URL:     "https://example.com",
+				Method:  config.Get,
+				Headers: map[string]string{"User-Agent": "OpenStatus"},
+			},
+		}
+		if diff := cmp.Diff(homepage, out.Monitors["homepage"]); diff != "" {
+			t.Errorf("Unexpected homepage monitor (-want +got):\n%s", diff)
+		}
+
+		api := config.Monitor{
+			Name:      "API",
+			Active:    true,
+			Frequency: config.The1M,
+			Kind:      config.HTTP,
+			Regions:   []config.Region{config.Iad, config.Ams, config.Fra, config.Sin},
+			Retry:     5,
+			Request: config.Request{
+				URL:    "https://api.example.com",
+				Method: config.Get,
+				Headers: map[string]string{
+					"User-Agent":    "OpenStatus",
+					"Authorization": "Bearer ${secret:API_TOKEN}",
+				},
+			},
+			Assertions: []config.Assertion{
+				{Kind: config.StatusCode, Compare: config.Eq, Target: 200},
+			},
+		}
+		if diff := cmp.Diff(api, out.Monitors["api"]); diff != "" {
+			t.Errorf("Unexpected api monitor (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Defaults apply to included files", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "openstatus.yaml"), "include: [team.yaml]\ndefaults:\n  frequency: 5m\n")
+		writeFile(t, filepath.Join(dir, "team.yaml"), `
+defaults:
+  retry: 2
+checkout:
+  name: Checkout
+  kind: http
+  regions: [iad]
+  request:
+    url: https://example.com
+`)
+
+		out, err := config.ReadOpenStatusFile(filepath.Join(dir, "openstatus.yaml"), config.ReadOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := out.Monitors["checkout"]
+		if m.Frequency != config.The5M || m.Retry != 2 {
+			t.Errorf("Expected inherited frequency and own retry, got %+v", m)
+		}
+	})
+
+	t.Run("Unknown template returns error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openstatus.yaml")
+		writeFile(t, path, "api:\n  name: API\n  extends: missing\n")
+
+		_, err := config.ReadOpenStatusFile(path, config.ReadOptions{})
+		if err == nil || !strings.Contains(err.Error(), `"missing"`) {
+			t.Errorf("Expected unknown template error, got %v", err)
+		}
+	})
+
+	t.Run("Template cycle returns error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openstatus.yaml")
+		writeFile(t, path, `
+templates:
+  a:
+    extends: b
+  b:
+    extends: a
+api:
+  name: API
+  extends: a
+`)
+
+		_, err := config.ReadOpenStatusFile(path, config.ReadOptions{})
+		if err == nil || !strings.Contains(err.Error(), "cycle") {
+			t.Errorf("Expected template cycle error, got %v", err)
+		}
+	})
+}
+
+func Test_ExtractDefaults(t *testing.T) {
+	docs := map[string]map[string]any{
+		"a": {"name": "A", "frequency": "10m", "request": map[string]any{"method": "GET", "url": "https://a"}},
+		"b": {"name": "B", "frequency": "10m", "request": map[string]any{"method": "GET", "url": "https://b"}},
+	}
+
+	defaults, stripped := config.ExtractDefaults(docs, "name")
+	want := map[string]any{"frequency": "10m", "request": map[string]any{"method": "GET"}}
+	if diff := cmp.Diff(want, defaults); diff != "" {
+		t.Errorf("Unexpected defaults (-want +got):\n%s", diff)
+	}
+	wantA := map[string]any{"name": "A", "request": map[string]any{"url": "https://a"}}
+	if diff := cmp.Diff(wantA, stripped["a"]); diff != "" {
+		t.Errorf("Unexpected stripped document (-want +got):\n%s", diff)
+	}
+
+	single := map[string]map[string]any{"a": docs["a"]}
+	if defaults, _ := config.ExtractDefaults(single); defaults != nil {
+		t.Errorf("Expected no defaults for a single document, got %v", defaults)
+	}
+}
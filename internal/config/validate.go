@@ -0,0 +1,495 @@
+package config
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/agext/levenshtein"
+	"go.yaml.in/yaml/v3"
+)
+
+// ValidationError is a problem found in openstatus.yaml, located at the
+// line and column of the offending key or value when it is known.
+type ValidationError struct {
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	var b strings.Builder
+	if e.File != "" {
+		b.WriteString(e.File)
+		if e.Line > 0 {
+			fmt.Fprintf(&b, ":%d", e.Line)
+			if e.Column > 0 {
+				fmt.Fprintf(&b, ":%d", e.Column)
+			}
+		}
+		b.WriteString(": ")
+	}
+	if e.Path != "" {
+		b.WriteString(e.Path + ": ")
+	}
+	b.WriteString(e.Message)
+	return b.String()
+}
+
+var yamlLinePattern = regexp.MustCompile(`line (\d+)`)
+
+// Validate checks the configuration at path without calling the API. Each
+// file is checked against the schema of Monitor, so unknown fields and
+// values outside of the allowed enums are reported where they are written.
+// If the files are well-formed, the expanded monitors are then checked for
+// missing required fields. The error is only set when path cannot be read.
+func Validate(path string, opts ReadOptions) ([]ValidationError, error) {
+	files, err := ExpandConfigPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &validator{
+		visited:  map[string]bool{},
+		monitors: map[string]ValidationError{},
+		moves:    map[string]ValidationError{},
+	}
+	for _, f := range files {
+		if err := v.readFile(f, false); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range opts.Overlays {
+		if err := v.readFile(p, true); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(v.errs) == 0 {
+		out, err := ReadOpenStatusFile(path, opts)
+		if err != nil {
+			v.errs = append(v.errs, ValidationError{Message: err.Error()})
+		}
+		for name, m := range out.Monitors {
+			at := v.monitors[name]
+			for _, msg := range checkMonitor(m) {
+				at.Message = msg
+				v.errs = append(v.errs, at)
+			}
+		}
+		for _, m := range out.Moved {
+			if _, ok := out.Monitors[m.To]; !ok && m.To != "" {
+				at := v.moves[m.To]
+				at.Message = fmt.Sprintf("monitor %q is not defined", m.To)
+				v.errs = append(v.errs, at)
+			}
+		}
+	}
+
+	slices.SortStableFunc(v.errs, func(a, b ValidationError) int {
+		return cmp.Or(
+			strings.Compare(a.File, b.File),
+			cmp.Compare(a.Line, b.Line),
+			cmp.Compare(a.Column, b.Column),
+			strings.Compare(a.Path, b.Path),
+		)
+	})
+	return v.errs, nil
+}
+
+type validator struct {
+	file    string
+	errs    []ValidationError
+	visited map[string]bool
+	// monitors and moves record where each monitor and moved target is
+	// declared, to locate the errors found after expansion.
+	monitors map[string]ValidationError
+	moves    map[string]ValidationError
+}
+
+func (v *validator) errorf(n *yaml.Node, path, format string, args ...any) {
+	v.errs = append(v.errs, ValidationError{
+		File:    v.file,
+		Line:    n.Line,
+		Column:  n.Column,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) readFile(path string, overlay bool) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if v.visited[abs] {
+		return nil
+	}
+	v.visited[abs] = true
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	prev := v.file
+	v.file = path
+	defer func() { v.file = prev }()
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		e := ValidationError{File: path, Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+		if m := yamlLinePattern.FindStringSubmatch(err.Error()); m != nil {
+			e.Line, _ = strconv.Atoi(m[1])
+		}
+		v.errs = append(v.errs, e)
+		return nil
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	root := resolveAlias(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		if root.Tag != "!!null" {
+			v.errorf(root, "", "expected a mapping of monitor names to monitors")
+		}
+		return nil
+	}
+
+	var includes []string
+	v.eachPair(root, "", func(key, value *yaml.Node) {
+		switch key.Value {
+		case movedKey:
+			v.check(value, &schema{kind: arraySchema, items: moveSchema}, movedKey)
+			v.recordMoves(value)
+		case includeKey, defaultsKey, templatesKey:
+			if overlay {
+				v.errorf(key, key.Value, "%q is not supported in overlays", key.Value)
+				return
+			}
+			switch key.Value {
+			case includeKey:
+				if v.check(value, &schema{kind: nameListSchema}, includeKey) {
+					includes = v.includes(value)
+				}
+			case defaultsKey:
+				v.check(value, monitorSchema, defaultsKey)
+			case templatesKey:
+				v.check(value, &schema{kind: mapSchema, items: extendableSchema}, templatesKey)
+			}
+		default:
+			v.check(value, extendableSchema, key.Value)
+			if _, ok := v.monitors[key.Value]; !ok {
+				v.monitors[key.Value] = ValidationError{File: path, Line: key.Line, Column: key.Column, Path: key.Value}
+			}
+		}
+	})
+
+	for _, inc := range includes {
+		if !filepath.IsAbs(inc) {
+			inc = filepath.Join(filepath.Dir(path), inc)
+		}
+		files, err := ExpandConfigPath(inc)
+		if err == nil {
+			for _, f := range files {
+				if _, statErr := os.Stat(f); statErr != nil {
+					err = statErr
+					break
+				}
+			}
+		}
+		if err != nil {
+			v.errs = append(v.errs, ValidationError{File: path, Path: includeKey, Message: err.Error()})
+			continue
+		}
+		for _, f := range files {
+			if err := v.readFile(f, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (v *validator) recordMoves(n *yaml.Node) {
+	n = resolveAlias(n)
+	if n.Kind != yaml.SequenceNode {
+		return
+	}
+	for i, item := range n.Content {
+		v.eachPair(resolveAlias(item), "", func(key, value *yaml.Node) {
+			if strings.EqualFold(key.Value, "to") && value.Kind == yaml.ScalarNode {
+				v.moves[value.Value] = ValidationError{
+					File: v.file, Line: value.Line, Column: value.Column,
+					Path: fmt.Sprintf("%s[%d].to", movedKey, i),
+				}
+			}
+		})
+	}
+}
+
+func (v *validator) includes(n *yaml.Node) []string {
+	n = resolveAlias(n)
+	if n.Kind == yaml.ScalarNode {
+		return []string{n.Value}
+	}
+	var out []string
+	for _, item := range n.Content {
+		out = append(out, resolveAlias(item).Value)
+	}
+	return out
+}
+
+// eachPair calls fn for every key of a mapping, reporting duplicate keys.
+func (v *validator) eachPair(n *yaml.Node, path string, fn func(key, value *yaml.Node)) {
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], resolveAlias(n.Content[i+1])
+		if key.Value == "<<" {
+			continue
+		}
+		if seen[key.Value] {
+			v.errorf(key, joinPath(path, key.Value), "duplicate key %q", key.Value)
+			continue
+		}
+		seen[key.Value] = true
+		fn(key, value)
+	}
+}
+
+// check validates n against s and reports whether it has the expected shape.
+func (v *validator) check(n *yaml.Node, s *schema, path string) bool {
+	n = resolveAlias(n)
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		return true
+	}
+	switch s.kind {
+	case objectSchema:
+		if n.Kind != yaml.MappingNode {
+			v.errorf(n, path, "expected a mapping")
+			return false
+		}
+		v.eachPair(n, path, func(key, value *yaml.Node) {
+			field, ok := s.field(key.Value)
+			if !ok {
+				msg := fmt.Sprintf("unknown field %q", key.Value)
+				if hint := suggest(key.Value, s.fieldNames()); hint != "" {
+					msg += fmt.Sprintf(" (did you mean %q?)", hint)
+				}
+				v.errorf(key, joinPath(path, key.Value), "%s", msg)
+				return
+			}
+			v.check(value, field, joinPath(path, key.Value))
+		})
+	case arraySchema:
+		if n.Kind != yaml.SequenceNode {
+			v.errorf(n, path, "expected a list")
+			return false
+		}
+		for i, item := range n.Content {
+			v.check(item, s.items, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case mapSchema:
+		if n.Kind != yaml.MappingNode {
+			v.errorf(n, path, "expected a mapping")
+			return false
+		}
+		v.eachPair(n, path, func(key, value *yaml.Node) {
+			v.check(value, s.items, joinPath(path, key.Value))
+		})
+	case nameListSchema:
+		if n.Kind == yaml.ScalarNode {
+			return true
+		}
+		if n.Kind != yaml.SequenceNode {
+			v.errorf(n, path, "expected a name or a list of names")
+			return false
+		}
+		for i, item := range n.Content {
+			if resolveAlias(item).Kind != yaml.ScalarNode {
+				v.errorf(item, fmt.Sprintf("%s[%d]", path, i), "expected a name")
+				return false
+			}
+		}
+	default:
+		if n.Kind != yaml.ScalarNode {
+			v.errorf(n, path, "expected a single value")
+			return false
+		}
+		if strings.Contains(n.Value, "${") {
+			// Checked once interpolated, when the monitors are expanded.
+			return true
+		}
+		switch s.kind {
+		case integerSchema:
+			if _, err := strconv.ParseInt(n.Value, 10, 64); err != nil {
+				v.errorf(n, path, "expected an integer, got %q", n.Value)
+				return false
+			}
+		case booleanSchema:
+			if _, err := strconv.ParseBool(n.Value); err != nil {
+				v.errorf(n, path, "expected true or false, got %q", n.Value)
+				return false
+			}
+		case stringSchema:
+			if s.enum != nil {
+				if msg := checkEnum(s.enum, n.Value); msg != "" {
+					v.errorf(n, path, "%s", msg)
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// checkMonitor reports the problems of an expanded monitor that cannot be
+// seen on a single file: required fields and fields that depend on the kind.
+func checkMonitor(m Monitor) []string {
+	var errs []string
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Sprintf(format, args...))
+	}
+	enumOf := func(value any) *enum {
+		e := enums[reflect.TypeOf(value)]
+		return &e
+	}
+
+	if m.Name == "" {
+		add("name is required")
+	}
+	if m.Kind == "" {
+		add("kind is required")
+	} else if msg := checkEnum(enumOf(m.Kind), string(m.Kind)); msg != "" {
+		add("kind: %s", msg)
+	}
+	if m.Frequency == "" {
+		add("frequency is required")
+	} else if msg := checkEnum(enumOf(m.Frequency), string(m.Frequency)); msg != "" {
+		add("frequency: %s", msg)
+	}
+	if len(m.Regions) == 0 {
+		add("at least one region is required")
+	}
+	for i, r := range m.Regions {
+		if msg := checkEnum(enumOf(r), string(r)); msg != "" {
+			add("regions[%d]: %s", i, msg)
+		}
+	}
+	if m.Request.Method != "" {
+		if msg := checkEnum(enumOf(m.Request.Method), string(m.Request.Method)); msg != "" {
+			add("request.method: %s", msg)
+		}
+	}
+	if m.Timeout < 0 || m.DegradedAfter < 0 || m.Retry < 0 {
+		add("timeout, degradedAfter and retry cannot be negative")
+	}
+	if m.Timeout > 0 && m.DegradedAfter > m.Timeout {
+		add("degradedAfter (%d) must not exceed timeout (%d)", m.DegradedAfter, m.Timeout)
+	}
+
+	switch m.Kind {
+	case HTTP:
+		if m.Request.URL == "" {
+			add("request.url is required for http monitors")
+		} else if !strings.HasPrefix(m.Request.URL, "http://") && !strings.HasPrefix(m.Request.URL, "https://") {
+			add("request.url must start with http:// or https://, got %q", m.Request.URL)
+		}
+	case TCP:
+		if m.Request.Host == "" {
+			add("request.host is required for tcp monitors")
+		}
+		if m.Request.Port <= 0 || m.Request.Port > 65535 {
+			add("request.port must be between 1 and 65535, got %d", m.Request.Port)
+		}
+	case DNS:
+		if m.Request.Host == "" {
+			add("request.host is required for dns monitors")
+		}
+	}
+
+	for i, a := range m.Assertions {
+		at := fmt.Sprintf("assertions[%d]", i)
+		if msg := checkEnum(enumOf(a.Kind), string(a.Kind)); msg != "" {
+			add("%s.kind: %s", at, msg)
+			continue
+		}
+		if msg := checkEnum(enumOf(a.Compare), string(a.Compare)); msg != "" {
+			add("%s.compare: %s", at, msg)
+		}
+		switch {
+		case m.Kind == DNS && a.Kind != DNSRecord:
+			add("%s: dns monitors only support dnsRecord assertions", at)
+		case m.Kind != DNS && a.Kind == DNSRecord:
+			add("%s: dnsRecord assertions are only supported by dns monitors", at)
+		case m.Kind == TCP:
+			add("%s: tcp monitors do not support assertions", at)
+		}
+		switch a.Kind {
+		case StatusCode:
+			if _, ok := a.Target.(int); !ok {
+				add("%s.target must be a status code, got %v", at, a.Target)
+			}
+		case Header:
+			if a.Key == "" {
+				add("%s.key is required for header assertions", at)
+			}
+		case DNSRecord:
+			if a.Record == "" {
+				add("%s.record is required for dnsRecord assertions", at)
+			} else if msg := checkEnum(enumOf(a.Record), string(a.Record)); msg != "" {
+				add("%s.record: %s", at, msg)
+			}
+		}
+	}
+	return errs
+}
+
+func checkEnum(e *enum, value string) string {
+	if e == nil || e.values == nil || slices.Contains(e.values, value) {
+		return ""
+	}
+	msg := fmt.Sprintf("invalid %s %q", e.name, value)
+	if hint := suggest(value, e.values); hint != "" {
+		return msg + fmt.Sprintf(" (did you mean %q?)", hint)
+	}
+	if len(e.values) <= 10 {
+		return msg + ", expected one of: " + strings.Join(e.values, ", ")
+	}
+	return msg
+}
+
+// suggest returns the option closest to value if it looks like a typo.
+func suggest(value string, options []string) string {
+	best, bestDist := "", max(1, len(value)/4)+1
+	for _, o := range options {
+		if d := levenshtein.Distance(strings.ToLower(value), strings.ToLower(o), nil); d < bestDist {
+			best, bestDist = o, d
+		}
+	}
+	return best
+}
+
+func resolveAlias(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,162 @@
+package config_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openstatusHQ/cli/internal/config"
+)
+
+func Test_Validate(t *testing.T) {
+	t.Run("Valid configuration has no errors", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openstatus.yaml")
+		writeFile(t, path, `
+defaults:
+  kind: http
+  frequency: 10m
+  regions: [iad, ams]
+api:
+  name: API
+  request:
+    method: GET
+    url: https://api.example.com
+  assertions:
+    - kind: statusCode
+      compare: eq
+      target: 200
+`)
+		errs, err := config.Validate(path, config.ReadOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(errs) != 0 {
+			t.Errorf("Expected no errors, got %v", errs)
+		}
+	})
+
+	t.Run("Reports unknown fields and invalid enums with their location", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openstatus.yaml")
+		writeFile(t, path, `api:
+  name: API
+  kind: http
+  frequncy: 10m
+  regions: [iad, fra1]
+  request:
+    method: FETCH
+    url: https://api.example.com
+  timeout: soon
+`)
+		errs, err := config.Validate(path, config.ReadOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{
+			path + `:4:3: api.frequncy: unknown field "frequncy" (did you mean "frequency"?)`,
+			path + `:5:18: api.regions[1]: invalid region "fra1" (did you mean "fra"?)`,
+			path + `:7:13: api.request.method: invalid method "FETCH", expected one of: GET, POST, PUT, PATCH, DELETE, HEAD, OPTIONS`,
+			path + `:9:12: api.timeout: expected an integer, got "soon"`,
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("Expected %d errors, got %v", len(want), errs)
+		}
+		for i, e := range errs {
+			if e.Error() != want[i] {
+				t.Errorf("Expected %q, got %q", want[i], e.Error())
+			}
+		}
+	})
+
+	t.Run("Reports missing fields of expanded monitors", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openstatus.yaml")
+		writeFile(t, path, `
+templates:
+  base:
+    kind: tcp
+    frequency: 1m
+    regions: [iad]
+db:
+  name: Database
+  extends: base
+  request:
+    host: db.example.com
+`)
+		errs, err := config.Validate(path, config.ReadOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(errs) != 1 || errs[0].Line != 7 || !strings.Contains(errs[0].Message, "request.port") {
+			t.Errorf("Expected a port error on the db monitor, got %v", errs)
+		}
+	})
+
+	t.Run("Checks included files", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "openstatus.yaml")
+		writeFile(t, path, "include: [team.yaml]\n")
+		writeFile(t, filepath.Join(dir, "team.yaml"), `
+api:
+  name: API
+  kind: http
+  frequency: 2m
+`)
+		errs, err := config.Validate(path, config.ReadOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(errs) != 1 || errs[0].File != filepath.Join(dir, "team.yaml") || errs[0].Line != 5 {
+			t.Errorf("Expected a frequency error in team.yaml, got %v", errs)
+		}
+	})
+
+	t.Run("Reports YAML syntax errors", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openstatus.yaml")
+		writeFile(t, path, "api:\n  name: API\n   kind: http\n")
+		errs, err := config.Validate(path, config.ReadOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(errs) != 1 || errs[0].Line == 0 {
+			t.Errorf("Expected a located syntax error, got %v", errs)
+		}
+	})
+
+	t.Run("Missing file returns error", func(t *testing.T) {
+		if _, err := config.Validate(filepath.Join(t.TempDir(), "missing.yaml"), config.ReadOptions{}); err == nil {
+			t.Error("Expected error for missing file, got nil")
+		}
+	})
+}
+
+func Test_JSONSchema(t *testing.T) {
+	b, err := config.JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Definitions struct {
+			Monitor struct {
+				Properties map[string]struct {
+					Enum  []string `json:"enum"`
+					Items struct {
+						Enum []string `json:"enum"`
+					} `json:"items"`
+				} `json:"properties"`
+			} `json:"monitor"`
+		} `json:"definitions"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+	props := doc.Definitions.Monitor.Properties
+	if len(props["frequency"].Enum) != 6 {
+		t.Errorf("Expected frequency enum, got %v", props["frequency"].Enum)
+	}
+	if len(props["regions"].Items.Enum) == 0 {
+		t.Error("Expected regions enum")
+	}
+	if _, ok := props["extends"]; !ok {
+		t.Error("Expected extends property")
+	}
+}
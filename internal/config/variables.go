@@ -0,0 +1,303 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+// Variables holds values for ${VAR} and ${secret:NAME} references in
+// openstatus.yaml. Values take precedence over the process environment.
+type Variables map[string]string
+
+const secretPrefix = "secret:"
+
+// referencePattern matches ${NAME}, ${NAME:-default} and ${secret:NAME}.
+// A leading $ escapes the reference: $${NAME} is kept as ${NAME}.
+var referencePattern = regexp.MustCompile(`\$?\$\{([^}]+)\}`)
+
+// ReadVarFiles reads dotenv-formatted files, later files overriding earlier ones.
+func ReadVarFiles(paths ...string) (Variables, error) {
+	vars := Variables{}
+	for _, p := range paths {
+		values, err := godotenv.Read(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read var file %s: %w", p, err)
+		}
+		maps.Copy(vars, values)
+	}
+	return vars, nil
+}
+
+func (v Variables) Lookup(name string) (string, bool) {
+	if value, ok := v[name]; ok {
+		return value, true
+	}
+	return os.LookupEnv(name)
+}
+
+// OverlayPath returns the per-environment overlay for a config path,
+// e.g. openstatus.prod.yaml for openstatus.yaml and env "prod". For a
+// directory or glob it is a file next to the directory, e.g. monitors.prod.yaml.
+func OverlayPath(path, env string) string {
+	if strings.ContainsAny(path, "*?[") {
+		path = filepath.Dir(path)
+	}
+	if info, err := os.Stat(path); (err == nil && info.IsDir()) || filepath.Ext(path) == "" {
+		return filepath.Clean(path) + "." + env + ".yaml"
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + env + ext
+}
+
+// LockFilePath returns the lock file for an environment. Each environment
+// manages its own monitors, so they cannot share a lock file.
+func LockFilePath(env string) string {
+	if env == "" {
+		return "openstatus.lock"
+	}
+	return "openstatus." + env + ".lock"
+}
+
+// interpolate replaces ${NAME} and ${NAME:-default} references in s.
+// Secret references are left as-is so they never reach the lock file, and so
+// are escaped secret references, which resolveSecrets unescapes.
+func (v Variables) interpolate(s string) (string, error) {
+	var err error
+	out := referencePattern.ReplaceAllStringFunc(s, func(match string) string {
+		if strings.HasPrefix(match, "$$") {
+			if strings.HasPrefix(match, "$${"+secretPrefix) {
+				return match
+			}
+			return match[1:]
+		}
+		ref := match[2 : len(match)-1]
+		if strings.HasPrefix(ref, secretPrefix) {
+			return match
+		}
+		name, def, hasDefault := strings.Cut(ref, ":-")
+		if value, ok := v.Lookup(name); ok {
+			return value
+		}
+		if hasDefault {
+			return def
+		}
+		if err == nil {
+			err = fmt.Errorf("undefined variable %q", name)
+		}
+		return match
+	})
+	return out, err
+}
+
+// expand interpolates every string in a parsed YAML document.
+func (v Variables) expand(value any) (any, error) {
+	switch val := value.(type) {
+	case string:
+		return v.interpolate(val)
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			expanded, err := v.expand(item)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			out[k] = expanded
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			expanded, err := v.expand(item)
+			if err != nil {
+				return nil, fmt.Errorf("%d: %w", i, err)
+			}
+			out[i] = expanded
+		}
+		return out, nil
+	default:
+		return value, nil
+	}
+}
+
+func (v Variables) resolveSecrets(s string) (string, error) {
+	var err error
+	out := referencePattern.ReplaceAllStringFunc(s, func(match string) string {
+		if strings.HasPrefix(match, "$$") {
+			return match[1:]
+		}
+		name, ok := strings.CutPrefix(match[2:len(match)-1], secretPrefix)
+		if !ok {
+			return match
+		}
+		value, found := v.Lookup(name)
+		if !found && err == nil {
+			err = fmt.Errorf("secret %q is not set", name)
+		}
+		return value
+	})
+	return out, err
+}
+
+// hasSecretRef reports whether s holds a secret reference, escaped or not:
+// both are sent to the API differently from how the lock file stores them.
+func hasSecretRef(s string) bool {
+	for _, m := range referencePattern.FindAllString(s, -1) {
+		if strings.HasPrefix(m, "${"+secretPrefix) || strings.HasPrefix(m, "$${"+secretPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveSecrets returns a copy of the monitor with ${secret:NAME} references
+// replaced by their values. The result is meant to be sent to the API and
+// must never be written to the lock file.
+func ResolveSecrets(m Monitor, vars Variables) (Monitor, error) {
+	var err error
+	resolve := func(s string) string {
+		if err != nil {
+			return s
+		}
+		var out string
+		out, err = vars.resolveSecrets(s)
+		return out
+	}
+
+	m.Request.URL = resolve(m.Request.URL)
+	m.Request.Host = resolve(m.Request.Host)
+	m.Request.Body = resolve(m.Request.Body)
+	m.Request.Headers = resolveMap(m.Request.Headers, resolve)
+	m.OpenTelemetry.Endpoint = resolve(m.OpenTelemetry.Endpoint)
+	m.OpenTelemetry.Headers = resolveMap(m.OpenTelemetry.Headers, resolve)
+	m.Assertions = slices.Clone(m.Assertions)
+	for i, a := range m.Assertions {
+		if target, ok := a.Target.(string); ok {
+			m.Assertions[i].Target = resolve(target)
+		}
+	}
+	if err != nil {
+		return Monitor{}, fmt.Errorf("monitor %q: %w", m.Name, err)
+	}
+	return m, nil
+}
+
+func resolveMap(in map[string]string, resolve func(string) string) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = resolve(v)
+	}
+	return out
+}
+
+// RestoreSecretRefs copies every field of withRefs that holds a secret
+// reference onto m, so that a monitor read back from the API compares equal
+// to the definition it was created from.
+func RestoreSecretRefs(m, withRefs Monitor) Monitor {
+	restore := func(got, ref string) string {
+		if hasSecretRef(ref) {
+			return ref
+		}
+		return got
+	}
+	restoreMap := func(got, refs map[string]string) map[string]string {
+		var out map[string]string
+		for k, ref := range refs {
+			if !hasSecretRef(ref) {
+				continue
+			}
+			if out == nil {
+				out = maps.Clone(got)
+				if out == nil {
+					out = map[string]string{}
+				}
+			}
+			out[k] = ref
+		}
+		if out == nil {
+			return got
+		}
+		return out
+	}
+
+	m.Request.URL = restore(m.Request.URL, withRefs.Request.URL)
+	m.Request.Host = restore(m.Request.Host, withRefs.Request.Host)
+	m.Request.Body = restore(m.Request.Body, withRefs.Request.Body)
+	m.Request.Headers = restoreMap(m.Request.Headers, withRefs.Request.Headers)
+	m.OpenTelemetry.Endpoint = restore(m.OpenTelemetry.Endpoint, withRefs.OpenTelemetry.Endpoint)
+	m.OpenTelemetry.Headers = restoreMap(m.OpenTelemetry.Headers, withRefs.OpenTelemetry.Headers)
+	if len(m.Assertions) == len(withRefs.Assertions) {
+		m.Assertions = slices.Clone(m.Assertions)
+		for i, a := range withRefs.Assertions {
+			if target, ok := a.Target.(string); ok && hasSecretRef(target) {
+				m.Assertions[i].Target = target
+			}
+		}
+	}
+	return m
+}
+
+// sensitiveHeaders carry credentials and must not be written in plaintext
+// to openstatus.yaml.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "X-Api-Key"}
+
+// IsSensitiveHeader reports whether the header carries credentials.
+func IsSensitiveHeader(key string) bool {
+	return slices.ContainsFunc(sensitiveHeaders, func(h string) bool { return strings.EqualFold(h, key) })
+}
+
+// SecretHeaders returns a copy of headers where the value of every header
+// carrying credentials is replaced by a ${secret:NAME} reference, and the
+// names of those secrets. Names are derived from prefix and the header, e.g.
+// API_PING_AUTHORIZATION, and the scheme of an Authorization header is kept:
+// "Bearer ${secret:API_PING_AUTHORIZATION}".
+func SecretHeaders(prefix string, headers map[string]string) (map[string]string, []string) {
+	var out map[string]string
+	var names []string
+	for _, key := range slices.Sorted(maps.Keys(headers)) {
+		value := headers[key]
+		if !IsSensitiveHeader(key) || hasSecretRef(value) {
+			continue
+		}
+		if out == nil {
+			out = maps.Clone(headers)
+		}
+		name := secretName(prefix + "_" + key)
+		ref := "${" + secretPrefix + name + "}"
+		if scheme, _, ok := strings.Cut(value, " "); ok && strings.HasSuffix(strings.ToLower(key), "authorization") {
+			ref = scheme + " " + ref
+		}
+		out[key] = ref
+		names = append(names, name)
+	}
+	if out == nil {
+		return headers, nil
+	}
+	return out, names
+}
+
+// secretName turns s into an environment variable name: uppercase letters,
+// digits and underscores.
+func secretName(s string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToUpper(s) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			underscore = false
+		} else if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
@@ -0,0 +1,274 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openstatusHQ/cli/internal/config"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+var variablesConfig = `
+"api":
+  active: true
+  frequency: 10m
+  kind: http
+  name: API ${STAGE}
+  regions:
+    - iad
+  request:
+    method: GET
+    url: https://${API_HOST}/health
+    headers:
+      Authorization: Bearer ${secret:API_TOKEN}
+      X-Region: ${REGION:-iad}
+      X-Literal: $${NOT_A_VAR}
+      X-Literal-Secret: $${secret:NOT_A_SECRET}
+`
+
+func Test_ReadOpenStatusFile_Variables(t *testing.T) {
+	t.Run("Interpolates variables and keeps secret references", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openstatus.yaml")
+		writeFile(t, path, variablesConfig)
+		t.Setenv("API_HOST", "env.example.com")
+
+		out, err := config.ReadOpenStatusFile(path, config.ReadOptions{
+			Vars: config.Variables{"STAGE": "staging"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m := out.Monitors["api"]
+		if m.Name != "API staging" {
+			t.Errorf("Expected name 'API staging', got %q", m.Name)
+		}
+		if m.Request.URL != "https://env.example.com/health" {
+			t.Errorf("Expected URL from the environment, got %q", m.Request.URL)
+		}
+		if got := m.Request.Headers["Authorization"]; got != "Bearer ${secret:API_TOKEN}" {
+			t.Errorf("Expected secret reference to be kept, got %q", got)
+		}
+		if got := m.Request.Headers["X-Region"]; got != "iad" {
+			t.Errorf("Expected default value 'iad', got %q", got)
+		}
+		if got := m.Request.Headers["X-Literal"]; got != "${NOT_A_VAR}" {
+			t.Errorf("Expected escaped reference, got %q", got)
+		}
+		if got := m.Request.Headers["X-Literal-Secret"]; got != "$${secret:NOT_A_SECRET}" {
+			t.Errorf("Expected escaped secret reference to stay escaped until resolved, got %q", got)
+		}
+	})
+
+	t.Run("Undefined variable returns error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openstatus.yaml")
+		writeFile(t, path, variablesConfig)
+
+		_, err := config.ReadOpenStatusFile(path, config.ReadOptions{})
+		if err == nil {
+			t.Error("Expected error for undefined variable, got nil")
+		}
+	})
+
+	t.Run("Overlay is merged over the base file", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "openstatus.yaml")
+		writeFile(t, path, variablesConfig)
+		overlay := config.OverlayPath(path, "prod")
+		writeFile(t, overlay, `
+"api":
+  frequency: 1m
+  regions:
+    - iad
+    - fra
+`)
+		if overlay != filepath.Join(dir, "openstatus.prod.yaml") {
+			t.Errorf("Unexpected overlay path %s", overlay)
+		}
+
+		out, err := config.ReadOpenStatusFile(path, config.ReadOptions{
+			Overlays: []string{overlay},
+			Vars:     config.Variables{"STAGE": "prod", "API_HOST": "api.example.com"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m := out.Monitors["api"]
+		if m.Frequency != config.The1M {
+			t.Errorf("Expected overlay frequency 1m, got %s", m.Frequency)
+		}
+		if len(m.Regions) != 2 {
+			t.Errorf("Expected overlay regions, got %v", m.Regions)
+		}
+		if m.Request.URL != "https://api.example.com/health" {
+			t.Errorf("Expected base URL to be kept, got %q", m.Request.URL)
+		}
+	})
+}
+
+func Test_ReadVarFiles(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.env")
+	prod := filepath.Join(dir, "prod.env")
+	writeFile(t, base, "API_HOST=staging.example.com\nSTAGE=staging\n")
+	writeFile(t, prod, "API_HOST=api.example.com\n")
+
+	vars, err := config.ReadVarFiles(base, prod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vars["API_HOST"] != "api.example.com" || vars["STAGE"] != "staging" {
+		t.Errorf("Unexpected variables %v", vars)
+	}
+
+	if _, err := config.ReadVarFiles(filepath.Join(dir, "missing.env")); err == nil {
+		t.Error("Expected error for missing var file, got nil")
+	}
+}
+
+func Test_OverlayPath(t *testing.T) {
+	dir := t.TempDir()
+	monitors := filepath.Join(dir, "monitors")
+	if err := os.Mkdir(monitors, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"openstatus.yaml":                        "openstatus.prod.yaml",
+		monitors:                                 filepath.Join(dir, "monitors.prod.yaml"),
+		filepath.Join(monitors, "*.yaml"):        filepath.Join(dir, "monitors.prod.yaml"),
+		filepath.Join(dir, "config", "api.yaml"): filepath.Join(dir, "config", "api.prod.yaml"),
+	}
+	for path, want := range tests {
+		if got := config.OverlayPath(path, "prod"); got != want {
+			t.Errorf("OverlayPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func Test_LockFilePath(t *testing.T) {
+	if got := config.LockFilePath(""); got != "openstatus.lock" {
+		t.Errorf("Expected openstatus.lock, got %s", got)
+	}
+	if got := config.LockFilePath("prod"); got != "openstatus.prod.lock" {
+		t.Errorf("Expected openstatus.prod.lock, got %s", got)
+	}
+}
+
+func Test_ResolveSecrets(t *testing.T) {
+	m := config.Monitor{
+		Name: "API",
+		Request: config.Request{
+			URL:     "https://example.com",
+			Headers: map[string]string{"Authorization": "Bearer ${secret:API_TOKEN}"},
+		},
+		Assertions: []config.Assertion{
+			{Kind: config.Header, Key: "X-Token", Compare: config.Eq, Target: "${secret:API_TOKEN}"},
+			{Kind: config.StatusCode, Compare: config.Eq, Target: 200},
+		},
+	}
+
+	t.Run("Resolves references without touching the original", func(t *testing.T) {
+		resolved, err := config.ResolveSecrets(m, config.Variables{"API_TOKEN": "s3cr3t"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := resolved.Request.Headers["Authorization"]; got != "Bearer s3cr3t" {
+			t.Errorf("Expected resolved header, got %q", got)
+		}
+		if resolved.Assertions[0].Target != "s3cr3t" {
+			t.Errorf("Expected resolved assertion target, got %v", resolved.Assertions[0].Target)
+		}
+		if resolved.Assertions[1].Target != 200 {
+			t.Errorf("Expected status code target to be kept, got %v", resolved.Assertions[1].Target)
+		}
+		if got := m.Request.Headers["Authorization"]; got != "Bearer ${secret:API_TOKEN}" {
+			t.Errorf("Expected original monitor to keep the reference, got %q", got)
+		}
+		if m.Assertions[0].Target != "${secret:API_TOKEN}" {
+			t.Errorf("Expected original assertion to keep the reference, got %v", m.Assertions[0].Target)
+		}
+	})
+
+	t.Run("Escaped references are sent literally", func(t *testing.T) {
+		escaped := m
+		escaped.Request.Headers = map[string]string{"X-Literal": "$${secret:API_TOKEN}"}
+		escaped.Assertions = nil
+		resolved, err := config.ResolveSecrets(escaped, config.Variables{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := resolved.Request.Headers["X-Literal"]; got != "${secret:API_TOKEN}" {
+			t.Errorf("Expected the literal reference, got %q", got)
+		}
+		restored := config.RestoreSecretRefs(resolved, escaped)
+		if got := restored.Request.Headers["X-Literal"]; got != "$${secret:API_TOKEN}" {
+			t.Errorf("Expected the escaped reference to be restored, got %q", got)
+		}
+	})
+
+	t.Run("Missing secret returns error", func(t *testing.T) {
+		if _, err := config.ResolveSecrets(m, config.Variables{}); err == nil {
+			t.Error("Expected error for missing secret, got nil")
+		}
+	})
+
+	t.Run("Restores references on values read back from the API", func(t *testing.T) {
+		resolved, err := config.ResolveSecrets(m, config.Variables{"API_TOKEN": "s3cr3t"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		restored := config.RestoreSecretRefs(resolved, m)
+		if got := restored.Request.Headers["Authorization"]; got != "Bearer ${secret:API_TOKEN}" {
+			t.Errorf("Expected reference to be restored, got %q", got)
+		}
+		if restored.Assertions[0].Target != "${secret:API_TOKEN}" {
+			t.Errorf("Expected assertion reference to be restored, got %v", restored.Assertions[0].Target)
+		}
+		if restored.Request.URL != "https://example.com" {
+			t.Errorf("Expected URL to be kept, got %q", restored.Request.URL)
+		}
+	})
+}
+
+func Test_SecretHeaders(t *testing.T) {
+	headers := map[string]string{
+		"Authorization": "Bearer s3cr3t",
+		"cookie":        "session=abc",
+		"X-API-Key":     "key",
+		"Accept":        "application/json",
+		"X-Token":       "${secret:ALREADY_SET}",
+	}
+	out, names := config.SecretHeaders("api-ping", headers)
+	want := map[string]string{
+		"Authorization": "Bearer ${secret:API_PING_AUTHORIZATION}",
+		"cookie":        "${secret:API_PING_COOKIE}",
+		"X-API-Key":     "${secret:API_PING_X_API_KEY}",
+		"Accept":        "application/json",
+		"X-Token":       "${secret:ALREADY_SET}",
+	}
+	for k, v := range want {
+		if out[k] != v {
+			t.Errorf("%s = %q, want %q", k, out[k], v)
+		}
+	}
+	if strings.Join(names, ",") != "API_PING_AUTHORIZATION,API_PING_X_API_KEY,API_PING_COOKIE" {
+		t.Errorf("unexpected secret names %v", names)
+	}
+	if headers["Authorization"] != "Bearer s3cr3t" {
+		t.Error("SecretHeaders must not modify its input")
+	}
+
+	if out, names := config.SecretHeaders("api", map[string]string{"Accept": "*/*"}); len(names) != 0 || out["Accept"] != "*/*" {
+		t.Errorf("expected headers without credentials to be kept, got %v, %v", out, names)
+	}
+}
@@ -0,0 +1,379 @@
+// Package curl parses curl command lines, as copied from browser devtools,
+// into the request of a check or a monitor.
+package curl
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/openstatusHQ/cli/internal/config"
+)
+
+// Request is the HTTP request described by a curl command.
+type Request struct {
+	Method  string
+	URL     string
+	Headers map[string]string
+	Body    string
+	// FollowRedirects is set by -L.
+	FollowRedirects bool
+	// Insecure is set by -k. Neither the checker nor monitors can skip TLS
+	// verification, so callers warn that it is ignored.
+	Insecure bool
+}
+
+// ConfigRequest returns the request of an HTTP monitor. Redirects are
+// followed by default, so only -L is kept.
+func (r Request) ConfigRequest() config.Request {
+	req := config.Request{
+		URL:     r.URL,
+		Method:  config.Method(r.Method),
+		Headers: r.Headers,
+		Body:    r.Body,
+	}
+	if r.FollowRedirects {
+		follow := true
+		req.FollowRedirects = &follow
+	}
+	return req
+}
+
+// ignoredFlags only change what curl prints or how it connects, not the
+// request it sends.
+var ignoredFlags = map[string]bool{
+	"-s": true, "--silent": true,
+	"-S": true, "--show-error": true,
+	"-v": true, "--verbose": true,
+	"-i": true, "--include": true,
+	"-f": true, "--fail": true,
+	"--compressed": true,
+	"--http1.1":    true,
+	"--http2":      true,
+}
+
+// valueFlags take an argument. The ones not handled by Parse are rejected.
+var valueFlags = map[string]string{
+	"-X": "--request", "-H": "--header", "-d": "--data", "-u": "--user",
+	"-A": "--user-agent", "-e": "--referer", "-b": "--cookie",
+	"-o": "--output", "-F": "--form", "-T": "--upload-file", "-x": "--proxy",
+	"-m": "--max-time",
+}
+
+var flagAliases = map[string]string{
+	"-L": "--location", "-k": "--insecure", "-I": "--head", "-G": "--get",
+}
+
+// Parse parses a curl command line. The leading "curl" is optional, and
+// shell quoting, $'…' strings and backslash line continuations are
+// understood. Options that change the request in a way a check or a monitor
+// cannot reproduce are reported as errors.
+func Parse(command string) (Request, error) {
+	args, err := splitWords(command)
+	if err != nil {
+		return Request{}, err
+	}
+	if len(args) > 0 && args[0] == "curl" {
+		args = args[1:]
+	}
+
+	req := Request{Headers: map[string]string{}}
+	var data []string
+	head := false
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			if req.URL != "" {
+				return Request{}, fmt.Errorf("unexpected argument %q: only one URL is supported", arg)
+			}
+			req.URL = arg
+			continue
+		}
+
+		name, value, hasValue := arg, "", false
+		if strings.HasPrefix(arg, "--") {
+			if k, v, ok := strings.Cut(arg, "="); ok {
+				name, value, hasValue = k, v, true
+			}
+		} else if len(arg) > 2 {
+			// Bundled short flags such as -sSL, or a value glued to its
+			// flag such as -XPOST.
+			if _, ok := valueFlags[arg[:2]]; ok {
+				name, value, hasValue = arg[:2], arg[2:], true
+			} else {
+				expanded := make([]string, 0, len(arg)-1)
+				for _, c := range arg[1:] {
+					expanded = append(expanded, "-"+string(c))
+				}
+				args = append(args[:i], append(expanded, args[i+1:]...)...)
+				i--
+				continue
+			}
+		}
+		if long, ok := valueFlags[name]; ok {
+			name = long
+		} else if long, ok := flagAliases[name]; ok {
+			name = long
+		}
+
+		if ignoredFlags[name] {
+			continue
+		}
+		switch name {
+		case "--location":
+			req.FollowRedirects = true
+			continue
+		case "--insecure":
+			req.Insecure = true
+			continue
+		case "--head":
+			head = true
+			continue
+		case "--get":
+			return Request{}, errors.New("unsupported curl option --get: put the query string in the URL")
+		}
+
+		if !isValueFlag(name) {
+			return Request{}, fmt.Errorf("unsupported curl option %s", name)
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return Request{}, fmt.Errorf("curl option %s requires a value", name)
+			}
+			i++
+			value = args[i]
+		}
+
+		switch name {
+		case "--output", "--max-time":
+			// Only change what curl does with the response.
+		case "--url":
+			if req.URL != "" {
+				return Request{}, fmt.Errorf("unexpected URL %q: only one URL is supported", value)
+			}
+			req.URL = value
+		case "--request":
+			req.Method = strings.ToUpper(value)
+		case "--header":
+			key, v, ok := strings.Cut(value, ":")
+			key = strings.TrimSpace(key)
+			if !ok || key == "" {
+				return Request{}, fmt.Errorf("invalid header %q: expected \"Key: Value\"", value)
+			}
+			req.Headers[key] = strings.TrimSpace(v)
+		case "--user":
+			if !strings.Contains(value, ":") {
+				return Request{}, fmt.Errorf("invalid --user %q: expected user:password, curl would prompt for the password", value)
+			}
+			req.Headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(value))
+		case "--user-agent":
+			req.Headers["User-Agent"] = value
+		case "--referer":
+			req.Headers["Referer"] = value
+		case "--cookie":
+			if !strings.Contains(value, "=") {
+				return Request{}, fmt.Errorf("unsupported --cookie %q: cookie files are not supported, pass name=value pairs", value)
+			}
+			req.Headers["Cookie"] = value
+		case "--data", "--data-ascii", "--data-binary", "--data-raw":
+			body, err := readData(name, value)
+			if err != nil {
+				return Request{}, err
+			}
+			data = append(data, body)
+		default:
+			return Request{}, fmt.Errorf("unsupported curl option %s", name)
+		}
+	}
+
+	if req.URL == "" {
+		return Request{}, errors.New("no URL found in the curl command")
+	}
+	if !strings.Contains(req.URL, "://") {
+		return Request{}, fmt.Errorf("invalid URL %q: missing scheme (did you mean https://%s?)", req.URL, req.URL)
+	}
+	if u, err := url.Parse(req.URL); err != nil || u.Host == "" {
+		return Request{}, fmt.Errorf("invalid URL %q in the curl command", req.URL)
+	}
+
+	// Like curl, data is joined with & and turns the request into a form
+	// POST unless told otherwise.
+	if len(data) > 0 {
+		req.Body = strings.Join(data, "&")
+		if _, ok := lookupHeader(req.Headers, "Content-Type"); !ok {
+			req.Headers["Content-Type"] = "application/x-www-form-urlencoded"
+		}
+	}
+	if req.Method == "" {
+		switch {
+		case head:
+			req.Method = "HEAD"
+		case len(data) > 0:
+			req.Method = "POST"
+		default:
+			req.Method = "GET"
+		}
+	}
+	if len(req.Headers) == 0 {
+		req.Headers = nil
+	}
+	return req, nil
+}
+
+func isValueFlag(name string) bool {
+	if name == "--url" {
+		return true
+	}
+	for _, long := range valueFlags {
+		if long == name {
+			return true
+		}
+	}
+	return strings.HasPrefix(name, "--data")
+}
+
+// readData reads the value of a data option. Like curl, @file reads a file
+// and --data and --data-ascii drop its newlines, while --data-raw never
+// reads files.
+func readData(name, value string) (string, error) {
+	if name == "--data-raw" || !strings.HasPrefix(value, "@") {
+		return value, nil
+	}
+	path := value[1:]
+	if path == "-" {
+		return "", fmt.Errorf("unsupported %s @-: save the body to a file and use @file", name)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read body file %q: %w", path, err)
+	}
+	if name == "--data-binary" {
+		return string(b), nil
+	}
+	return strings.NewReplacer("\r", "", "\n", "").Replace(string(b)), nil
+}
+
+func lookupHeader(headers map[string]string, key string) (string, bool) {
+	for k, v := range headers {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// splitWords splits a command line like a POSIX shell, without expansions.
+func splitWords(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\':
+			if i+1 < len(s) && (s[i+1] == '\n' || s[i+1] == '\r') {
+				// Line continuation.
+				i++
+				if s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n' {
+					i++
+				}
+				continue
+			}
+			if i+1 < len(s) {
+				i++
+				word.WriteByte(s[i])
+			}
+			inWord = true
+		case c == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return nil, errors.New("unterminated ' in the curl command")
+			}
+			word.WriteString(s[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+		case c == '$' && i+1 < len(s) && s[i+1] == '\'':
+			n, err := readANSIC(s[i+2:], &word)
+			if err != nil {
+				return nil, err
+			}
+			i += n + 2
+			inWord = true
+		case c == '"':
+			i++
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("\"\\$`\n", s[i+1]) >= 0 {
+					i++
+					if s[i] == '\n' {
+						continue
+					}
+				}
+				word.WriteByte(s[i])
+			}
+			if i >= len(s) {
+				return nil, errors.New("unterminated \" in the curl command")
+			}
+			inWord = true
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// readANSIC reads a $'…' string, whose opening quote has been consumed, and
+// returns how many bytes it used including the closing quote.
+func readANSIC(s string, word *strings.Builder) (int, error) {
+	escapes := map[byte]byte{'n': '\n', 't': '\t', 'r': '\r', '\\': '\\', '\'': '\'', '"': '"', '?': '?', 'a': '\a', 'b': '\b', 'e': 0x1b, 'f': '\f', 'v': '\v'}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\'':
+			return i + 1, nil
+		case '\\':
+			if i+1 >= len(s) {
+				return 0, errors.New("unterminated $' in the curl command")
+			}
+			i++
+			if e, ok := escapes[s[i]]; ok {
+				word.WriteByte(e)
+				continue
+			}
+			if s[i] == 'x' || s[i] == 'u' {
+				size := 2
+				if s[i] == 'u' {
+					size = 4
+				}
+				if i+size < len(s) {
+					if n, err := strconv.ParseUint(s[i+1:i+1+size], 16, 32); err == nil {
+						if s[i] == 'x' {
+							word.WriteByte(byte(n))
+						} else {
+							word.WriteRune(rune(n))
+						}
+						i += size
+						continue
+					}
+				}
+			}
+			word.WriteByte('\\')
+			word.WriteByte(s[i])
+		default:
+			word.WriteByte(c)
+		}
+	}
+	return 0, errors.New("unterminated $' in the curl command")
+}
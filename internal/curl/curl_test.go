@@ -0,0 +1,145 @@
+package curl
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		command string
+		want    Request
+	}{
+		{
+			name:    "get",
+			command: "curl https://openstat.us",
+			want:    Request{Method: "GET", URL: "https://openstat.us"},
+		},
+		{
+			name: "devtools copy",
+			command: `curl 'https://api.openstat.us/v1/ping' \
+  -H 'accept: application/json' \
+  -H 'content-type: application/json' \
+  --data-raw $'{"msg":"it\'s up\n"}' \
+  --compressed`,
+			want: Request{
+				Method:  "POST",
+				URL:     "https://api.openstat.us/v1/ping",
+				Headers: map[string]string{"accept": "application/json", "content-type": "application/json"},
+				Body:    `{"msg":"it's up` + "\n" + `"}`,
+			},
+		},
+		{
+			name:    "method, basic auth and flags",
+			command: `curl -sSLk -XPUT -u admin:s3cret "https://openstat.us/api" -d "a=1" --data b=2`,
+			want: Request{
+				Method: "PUT",
+				URL:    "https://openstat.us/api",
+				Headers: map[string]string{
+					"Authorization": "Basic YWRtaW46czNjcmV0",
+					"Content-Type":  "application/x-www-form-urlencoded",
+				},
+				Body:            "a=1&b=2",
+				FollowRedirects: true,
+				Insecure:        true,
+			},
+		},
+		{
+			name:    "head without curl prefix",
+			command: `-I --url https://openstat.us -A openstatus -b session=1`,
+			want: Request{
+				Method:  "HEAD",
+				URL:     "https://openstat.us",
+				Headers: map[string]string{"User-Agent": "openstatus", "Cookie": "session=1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.command)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Method != tt.want.Method || got.URL != tt.want.URL || got.Body != tt.want.Body ||
+				got.FollowRedirects != tt.want.FollowRedirects || got.Insecure != tt.want.Insecure ||
+				!maps.Equal(got.Headers, tt.want.Headers) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDataFiles(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "payload.json")
+	if err := os.WriteFile(path, []byte("{\n  \"ping\": true\n}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	binary, err := Parse("curl https://openstat.us -H 'Content-Type: application/json' --data-binary @" + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if binary.Body != "{\n  \"ping\": true\n}\n" || binary.Headers["Content-Type"] != "application/json" {
+		t.Errorf("--data-binary should keep the file as is, got %+v", binary)
+	}
+
+	data, err := Parse("curl https://openstat.us -d @" + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Body != `{  "ping": true}` {
+		t.Errorf("-d should strip newlines, got %q", data.Body)
+	}
+
+	raw, err := Parse("curl https://openstat.us --data-raw @" + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw.Body != "@"+path {
+		t.Errorf("--data-raw should not read files, got %q", raw.Body)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"curl -X POST", "no URL found"},
+		{"curl openstat.us", "missing scheme"},
+		{"curl https://a.example https://b.example", "only one URL"},
+		{"curl -F file=@a.txt https://openstat.us", "unsupported curl option --form"},
+		{"curl --data-urlencode q=1 https://openstat.us", "unsupported curl option --data-urlencode"},
+		{"curl -G https://openstat.us", "--get"},
+		{"curl -u admin https://openstat.us", "expected user:password"},
+		{"curl -b cookies.txt https://openstat.us", "cookie files are not supported"},
+		{"curl https://openstat.us -H", "requires a value"},
+		{"curl 'https://openstat.us", "unterminated '"},
+		{"curl --proxy http://proxy:3128 https://openstat.us", "unsupported curl option --proxy"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.command)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) error = %v, want %q", tt.command, err, tt.want)
+		}
+	}
+}
+
+func TestConfigRequest(t *testing.T) {
+	t.Parallel()
+	req := Request{Method: "POST", URL: "https://openstat.us", Body: "{}"}
+	if got := req.ConfigRequest(); got.FollowRedirects != nil || got.Method != "POST" || got.Body != "{}" {
+		t.Errorf("unexpected request %+v", got)
+	}
+	req.FollowRedirects = true
+	if got := req.ConfigRequest(); got.FollowRedirects == nil || !*got.FollowRedirects {
+		t.Errorf("expected -L to follow redirects, got %+v", got)
+	}
+}
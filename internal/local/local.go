@@ -0,0 +1,296 @@
+// Package local runs monitor definitions from the current machine, without
+// calling the OpenStatus API, and evaluates their assertions.
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openstatusHQ/cli/internal/config"
+)
+
+// defaultTimeout matches the timeout applied by OpenStatus when a monitor
+// does not set one.
+const defaultTimeout = 45 * time.Second
+
+// maxBodySize bounds how much of a response body is kept for textBody assertions.
+const maxBodySize = 10 << 20
+
+type Status string
+
+const (
+	StatusPass     Status = "pass"
+	StatusDegraded Status = "degraded"
+	StatusFail     Status = "fail"
+	StatusSkipped  Status = "skipped"
+)
+
+type AssertionResult struct {
+	Kind    config.AssertionKind `json:"kind"`
+	Compare config.Compare       `json:"compare"`
+	Key     string               `json:"key,omitempty"`
+	Target  any                  `json:"target"`
+	Actual  any                  `json:"actual"`
+	Passed  bool                 `json:"passed"`
+}
+
+type Result struct {
+	Monitor    string                `json:"monitor"`
+	Name       string                `json:"name"`
+	Kind       config.CoordinateKind `json:"kind"`
+	Status     Status                `json:"status"`
+	Latency    int64                 `json:"latency"`
+	StatusCode int                   `json:"statusCode,omitempty"`
+	Attempts   int                   `json:"attempts"`
+	Error      string                `json:"error,omitempty"`
+	Assertions []AssertionResult     `json:"assertions,omitempty"`
+}
+
+type Options struct {
+	// Concurrency is the number of monitors run at the same time.
+	Concurrency int
+	// Transport is used for HTTP monitors; http.DefaultTransport when nil.
+	Transport http.RoundTripper
+}
+
+// RunAll runs every monitor and returns the results ordered by logical name.
+func RunAll(ctx context.Context, monitors config.Monitors, opts Options) []Result {
+	names := slices.Sorted(maps.Keys(monitors))
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	results := make([]Result, len(names))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = Run(ctx, name, monitors[name], opts)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// Run executes a monitor, retrying up to m.Retry times until it does not fail.
+func Run(ctx context.Context, key string, m config.Monitor, opts Options) Result {
+	var res Result
+	for attempt := 1; attempt <= int(m.Retry)+1; attempt++ {
+		switch m.Kind {
+		case config.HTTP:
+			res = runHTTP(ctx, m, opts)
+		case config.TCP:
+			res = runTCP(ctx, m)
+		default:
+			res = Result{Status: StatusSkipped, Error: fmt.Sprintf("%s monitors cannot run locally", m.Kind)}
+		}
+		res.Attempts = attempt
+		if res.Status != StatusFail || ctx.Err() != nil {
+			break
+		}
+	}
+	res.Monitor = key
+	res.Name = m.Name
+	res.Kind = m.Kind
+	return res
+}
+
+func timeout(m config.Monitor) time.Duration {
+	if m.Timeout > 0 {
+		return time.Duration(m.Timeout) * time.Millisecond
+	}
+	return defaultTimeout
+}
+
+// finish marks a successful attempt as degraded when it was slower than
+// degradedAfter.
+func finish(res Result, m config.Monitor) Result {
+	if res.Status == StatusPass && m.DegradedAfter > 0 && res.Latency > m.DegradedAfter {
+		res.Status = StatusDegraded
+	}
+	return res
+}
+
+func runHTTP(ctx context.Context, m config.Monitor, opts Options) Result {
+	ctx, cancel := context.WithTimeout(ctx, timeout(m))
+	defer cancel()
+
+	method := string(m.Request.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+	var body io.Reader
+	if m.Request.Body != "" {
+		body = strings.NewReader(m.Request.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, m.Request.URL, body)
+	if err != nil {
+		return Result{Status: StatusFail, Error: err.Error()}
+	}
+	for k, v := range m.Request.Headers {
+		req.Header.Set(k, v)
+	}
+
+	client := &http.Client{Transport: opts.Transport}
+	if m.Request.FollowRedirects != nil && !*m.Request.FollowRedirects {
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		res := Result{Status: StatusFail, Latency: time.Since(start).Milliseconds(), Error: err.Error()}
+		if errors.Is(err, context.DeadlineExceeded) {
+			res.Error = fmt.Sprintf("timed out after %s", timeout(m))
+		}
+		return res
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	latency := time.Since(start).Milliseconds()
+	if err != nil {
+		return Result{Status: StatusFail, Latency: latency, StatusCode: resp.StatusCode, Error: err.Error()}
+	}
+
+	res := Result{Status: StatusPass, Latency: latency, StatusCode: resp.StatusCode}
+	if len(m.Assertions) == 0 {
+		// Without assertions, OpenStatus expects a successful status code.
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			res.Status = StatusFail
+			res.Error = fmt.Sprintf("unexpected status code %d", resp.StatusCode)
+		}
+		return finish(res, m)
+	}
+	for _, a := range m.Assertions {
+		ar := evaluate(a, resp, string(b))
+		res.Assertions = append(res.Assertions, ar)
+		if !ar.Passed {
+			res.Status = StatusFail
+		}
+	}
+	if res.Status == StatusFail {
+		res.Error = "assertions failed"
+	}
+	return finish(res, m)
+}
+
+func runTCP(ctx context.Context, m config.Monitor) Result {
+	d := net.Dialer{Timeout: timeout(m)}
+	addr := net.JoinHostPort(m.Request.Host, strconv.FormatInt(m.Request.Port, 10))
+	start := time.Now()
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	latency := time.Since(start).Milliseconds()
+	if err != nil {
+		return Result{Status: StatusFail, Latency: latency, Error: err.Error()}
+	}
+	conn.Close()
+	return finish(Result{Status: StatusPass, Latency: latency}, m)
+}
+
+func evaluate(a config.Assertion, resp *http.Response, body string) AssertionResult {
+	ar := AssertionResult{Kind: a.Kind, Compare: a.Compare, Key: a.Key, Target: a.Target}
+	switch a.Kind {
+	case config.StatusCode:
+		ar.Actual = resp.StatusCode
+		target, ok := toInt(a.Target)
+		ar.Passed = ok && compareNumber(a.Compare, int64(resp.StatusCode), target)
+	case config.Header:
+		value := resp.Header.Get(a.Key)
+		ar.Actual = value
+		ar.Passed = compareString(a.Compare, value, fmt.Sprint(targetOrEmpty(a.Target)))
+	case config.TextBody:
+		ar.Actual = truncate(body, 200)
+		ar.Passed = compareString(a.Compare, body, fmt.Sprint(targetOrEmpty(a.Target)))
+	default:
+		ar.Actual = fmt.Sprintf("%s assertions are not supported on HTTP monitors", a.Kind)
+	}
+	return ar
+}
+
+func toInt(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	case float64:
+		return int64(n), true
+	case string:
+		i, err := strconv.ParseInt(n, 10, 64)
+		return i, err == nil
+	}
+	return 0, false
+}
+
+func targetOrEmpty(v any) any {
+	if v == nil {
+		return ""
+	}
+	return v
+}
+
+func compareNumber(c config.Compare, got, want int64) bool {
+	switch c {
+	case config.NotEq:
+		return got != want
+	case config.Gt:
+		return got > want
+	case config.Gte:
+		return got >= want
+	case config.Lt:
+		return got < want
+	case config.LTE:
+		return got <= want
+	default:
+		return got == want
+	}
+}
+
+func compareString(c config.Compare, got, want string) bool {
+	switch c {
+	case config.NotEq:
+		return got != want
+	case config.Contains:
+		return strings.Contains(got, want)
+	case config.NotContains:
+		return !strings.Contains(got, want)
+	case config.Empty:
+		return got == ""
+	case config.NotEmpty:
+		return got != ""
+	case config.Gt:
+		return got > want
+	case config.Gte:
+		return got >= want
+	case config.Lt:
+		return got < want
+	case config.LTE:
+		return got <= want
+	default:
+		return got == want
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "…"
+}
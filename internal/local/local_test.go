@@ -0,0 +1,221 @@
+package local
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openstatusHQ/cli/internal/config"
+)
+
+func httpMonitor(url string, assertions ...config.Assertion) config.Monitor {
+	return config.Monitor{
+		Name:       "API",
+		Kind:       config.HTTP,
+		Request:    config.Request{URL: url, Method: config.Get},
+		Assertions: assertions,
+	}
+}
+
+func TestRunHTTPAssertions(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Version", "v2")
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	pass := Run(context.Background(), "api", httpMonitor(srv.URL,
+		config.Assertion{Kind: config.StatusCode, Compare: config.Eq, Target: 200},
+		config.Assertion{Kind: config.Header, Key: "X-Version", Compare: config.Eq, Target: "v2"},
+		config.Assertion{Kind: config.TextBody, Compare: config.Contains, Target: `"ok"`},
+	), Options{})
+	if pass.Status != StatusPass {
+		t.Fatalf("status = %s (%s), want pass", pass.Status, pass.Error)
+	}
+	if pass.Monitor != "api" || pass.StatusCode != 200 || len(pass.Assertions) != 3 {
+		t.Errorf("unexpected result %+v", pass)
+	}
+
+	fail := Run(context.Background(), "api", httpMonitor(srv.URL,
+		config.Assertion{Kind: config.StatusCode, Compare: config.Gte, Target: 300},
+		config.Assertion{Kind: config.TextBody, Compare: config.NotContains, Target: "ok"},
+	), Options{})
+	if fail.Status != StatusFail {
+		t.Fatalf("status = %s, want fail", fail.Status)
+	}
+	for _, a := range fail.Assertions {
+		if a.Passed {
+			t.Errorf("assertion %+v passed, want failure", a)
+		}
+	}
+}
+
+func TestRunHTTPDefaultsToSuccessfulStatus(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	res := Run(context.Background(), "api", httpMonitor(srv.URL), Options{})
+	if res.Status != StatusFail || res.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("got %s/%d, want fail/503", res.Status, res.StatusCode)
+	}
+}
+
+func TestRunHTTPTimeoutAndDegraded(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(60 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	m := httpMonitor(srv.URL)
+	m.DegradedAfter = 10
+	if res := Run(context.Background(), "api", m, Options{}); res.Status != StatusDegraded {
+		t.Errorf("status = %s (%s), want degraded", res.Status, res.Error)
+	}
+
+	m.Timeout = 20
+	res := Run(context.Background(), "api", m, Options{})
+	if res.Status != StatusFail || res.Error == "" {
+		t.Errorf("status = %s (%s), want timeout failure", res.Status, res.Error)
+	}
+}
+
+func TestRunHTTPRetry(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	m := httpMonitor(srv.URL)
+	m.Retry = 1
+	if res := Run(context.Background(), "api", m, Options{}); res.Status != StatusFail || res.Attempts != 2 {
+		t.Errorf("got %s after %d attempts, want fail after 2", res.Status, res.Attempts)
+	}
+	m.Retry = 3
+	if res := Run(context.Background(), "api", m, Options{}); res.Status != StatusPass || res.Attempts != 1 {
+		t.Errorf("got %s after %d attempts, want pass after 1", res.Status, res.Attempts)
+	}
+}
+
+func TestRunHTTPFollowRedirects(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	status := config.Assertion{Kind: config.StatusCode, Compare: config.Eq, Target: 301}
+	m := httpMonitor(srv.URL+"/old", status)
+	if res := Run(context.Background(), "api", m, Options{}); res.StatusCode != http.StatusOK {
+		t.Errorf("status code = %d, want redirect to be followed", res.StatusCode)
+	}
+	follow := false
+	m.Request.FollowRedirects = &follow
+	if res := Run(context.Background(), "api", m, Options{}); res.Status != StatusPass {
+		t.Errorf("status = %s (code %d), want pass on the redirect itself", res.Status, res.StatusCode)
+	}
+}
+
+func TestRunTCP(t *testing.T) {
+	t.Parallel()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	p, _ := strconv.ParseInt(port, 10, 64)
+	m := config.Monitor{Name: "DB", Kind: config.TCP, Request: config.Request{Host: host, Port: p}}
+	if res := Run(context.Background(), "db", m, Options{}); res.Status != StatusPass {
+		t.Errorf("status = %s (%s), want pass", res.Status, res.Error)
+	}
+
+	ln.Close()
+	if res := Run(context.Background(), "db", m, Options{}); res.Status != StatusFail {
+		t.Errorf("status = %s, want fail on closed port", res.Status)
+	}
+}
+
+func TestRunAllOrdersByName(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	results := RunAll(context.Background(), config.Monitors{
+		"web": httpMonitor(srv.URL),
+		"api": httpMonitor(srv.URL),
+		"dns": {Name: "DNS", Kind: config.DNS},
+	}, Options{Concurrency: 2})
+	if len(results) != 3 || results[0].Monitor != "api" || results[1].Monitor != "dns" || results[2].Monitor != "web" {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	if results[1].Status != StatusSkipped {
+		t.Errorf("dns status = %s, want skipped", results[1].Status)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+	numbers := []struct {
+		c         config.Compare
+		got, want int64
+		ok        bool
+	}{
+		{config.Eq, 200, 200, true},
+		{config.NotEq, 200, 200, false},
+		{config.Gt, 500, 499, true},
+		{config.Gte, 499, 499, true},
+		{config.Lt, 200, 300, true},
+		{config.LTE, 301, 300, false},
+	}
+	for _, n := range numbers {
+		if got := compareNumber(n.c, n.got, n.want); got != n.ok {
+			t.Errorf("compareNumber(%s, %d, %d) = %v, want %v", n.c, n.got, n.want, got, n.ok)
+		}
+	}
+	strs := []struct {
+		c         config.Compare
+		got, want string
+		ok        bool
+	}{
+		{config.Eq, "a", "a", true},
+		{config.NotEq, "a", "b", true},
+		{config.Contains, "hello", "ell", true},
+		{config.NotContains, "hello", "ell", false},
+		{config.Empty, "", "", true},
+		{config.NotEmpty, "", "", false},
+		{config.Gt, "b", "a", true},
+		{config.Lt, "b", "a", false},
+	}
+	for _, s := range strs {
+		if got := compareString(s.c, s.got, s.want); got != s.ok {
+			t.Errorf("compareString(%s, %q, %q) = %v, want %v", s.c, s.got, s.want, got, s.ok)
+		}
+	}
+}
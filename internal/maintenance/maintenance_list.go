@@ -15,7 +15,7 @@ import (
 	output "github.com/openstatusHQ/cli/internal/cli"
 )
 
-type maintenanceListEntry struct {
+type MaintenanceListEntry struct {
 	ID         string   `json:"id"`
 	Title      string   `json:"title"`
 	Message    string   `json:"message"`
@@ -49,9 +49,9 @@ func ListMaintenances(ctx context.Context, client maintenancev1connect.Maintenan
 	maintenances := resp.GetMaintenances()
 
 	if output.IsJSONOutput() {
-		entries := make([]maintenanceListEntry, 0, len(maintenances))
+		entries := make([]MaintenanceListEntry, 0, len(maintenances))
 		for _, m := range maintenances {
-			entries = append(entries, maintenanceListEntry{
+			entries = append(entries, MaintenanceListEntry{
 				ID:         m.GetId(),
 				Title:      m.GetTitle(),
 				Message:    m.GetMessage(),
@@ -92,6 +92,37 @@ func ListMaintenances(ctx context.Context, client maintenancev1connect.Maintenan
 	return nil
 }
 
+// InProgressMaintenances returns the maintenances whose window includes
+// the current time.
+func InProgressMaintenances(ctx context.Context, client maintenancev1connect.MaintenanceServiceClient) ([]MaintenanceListEntry, error) {
+	req := &maintenancev1.ListMaintenancesRequest{}
+	req.SetLimit(100)
+	resp, err := client.ListMaintenances(ctx, req)
+	if err != nil {
+		return nil, output.FormatError(err, "maintenance", "")
+	}
+	var entries []MaintenanceListEntry
+	for _, m := range resp.GetMaintenances() {
+		status := timeWindowStatus(m.GetFrom(), m.GetTo())
+		if status != "in_progress" {
+			continue
+		}
+		entries = append(entries, MaintenanceListEntry{
+			ID:         m.GetId(),
+			Title:      m.GetTitle(),
+			Message:    m.GetMessage(),
+			Status:     status,
+			From:       m.GetFrom(),
+			To:         m.GetTo(),
+			PageID:     m.GetPageId(),
+			Components: m.GetPageComponentIds(),
+			CreatedAt:  m.GetCreatedAt(),
+			UpdatedAt:  m.GetUpdatedAt(),
+		})
+	}
+	return entries, nil
+}
+
 func ListMaintenancesWithHTTPClient(ctx context.Context, httpClient *http.Client, apiKey string, pageId string, limit int) error {
 	client := NewMaintenanceClientWithHTTPClient(httpClient, apiKey)
 	return ListMaintenances(ctx, client, pageId, limit, nil)
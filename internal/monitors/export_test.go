@@ -4,4 +4,47 @@ var (
 	RegionToString     = regionToString
 	StringToRegion     = stringToRegion
 	ConfigToTCPMonitor = configToTCPMonitor
+	ConfigToDNSMonitor = configToDNSMonitor
 )
+
+var (
+	BuildPlan      = buildPlan
+	BuildApplyPlan = buildApplyPlan
+	ApplyMoves     = applyMoves
+	RenderPlan     = renderPlan
+)
+
+var AlignRemoteMonitor = alignRemoteMonitor
+
+var (
+	GroupMonitors         = groupMonitors
+	WriteMonitorsFile     = writeMonitorsFile
+	RemoveStaleGroupFiles = removeStaleGroupFiles
+)
+
+var (
+	SelectMonitors     = selectMonitors
+	RenderLocalResults = renderLocalResults
+)
+
+type MonitorRef = monitorRef
+
+var MatchMonitor = matchMonitor
+
+var (
+	ParseSince       = parseSince
+	ExportRetryDelay = &exportRetryDelay
+)
+
+var (
+	BuildCharts  = buildCharts
+	WriteCharts  = writeCharts
+	StackedCells = stackedCells
+)
+
+var (
+	ComputeLogStats = computeLogStats
+	WriteLogStats   = writeLogStats
+)
+
+var MonitorFromCurl = monitorFromCurl
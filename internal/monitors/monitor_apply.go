@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
 
-	"github.com/google/go-cmp/cmp"
+	"github.com/fatih/color"
 	"github.com/urfave/cli/v3"
-	"sigs.k8s.io/yaml"
 
 	"github.com/openstatusHQ/cli/internal/api"
 	"github.com/openstatusHQ/cli/internal/auth"
@@ -16,91 +20,273 @@ import (
 	"github.com/openstatusHQ/cli/internal/config"
 )
 
-// countChanges computes the number of creates, updates, and deletes without making API calls.
-func countChanges(lock config.MonitorsLock, configData config.Monitors) (created, updated, deleted int) {
-	for v, configValue := range configData {
-		value, exist := lock[v]
-		if !exist {
-			created++
-		} else if !cmp.Equal(configValue, value.Monitor) {
-			updated++
+const defaultApplyConcurrency = 4
+
+// ApplyOptions controls how ApplyChanges executes a plan.
+type ApplyOptions struct {
+	// Concurrency bounds the number of API calls in flight. Values below one
+	// fall back to defaultApplyConcurrency.
+	Concurrency int
+	// LockPath, when set, is rewritten after every successful operation so
+	// that an interrupted apply can be resumed by running it again.
+	LockPath string
+	// HTTPClient defaults to api.DefaultHTTPClient.
+	HTTPClient *http.Client
+	// Targets restricts the apply to the given logical monitor names.
+	Targets []string
+	// NoDelete skips deletions and replacements, leaving the monitors and
+	// their lock entries in place.
+	NoDelete bool
+	// Vars resolves ${secret:NAME} references before calling the API.
+	Vars config.Variables
+}
+
+// buildApplyPlan computes the plan apply will execute for the given options.
+// It fails if a target is unknown or if the plan would delete or replace a
+// monitor marked with preventDestroy.
+func buildApplyPlan(lock config.MonitorsLock, configData config.Monitors, opts ApplyOptions) (Plan, error) {
+	plan := buildPlan(lock, configData)
+
+	if len(opts.Targets) > 0 {
+		targets := make(map[string]bool, len(opts.Targets))
+		for _, name := range opts.Targets {
+			_, inConfig := configData[name]
+			_, inLock := lock[name]
+			if !inConfig && !inLock {
+				return Plan{}, fmt.Errorf("unknown target %q: no monitor with this name in the configuration or lock file", name)
+			}
+			targets[name] = true
 		}
+		plan = plan.filter(func(e PlanEntry) bool { return targets[e.Name] })
 	}
-	for v := range lock {
-		if _, exist := configData[v]; !exist {
-			deleted++
+	if opts.NoDelete {
+		plan = plan.filter(func(e PlanEntry) bool {
+			return e.Action != planActionDelete && e.Action != planActionReplace
+		})
+	}
+
+	for _, entry := range plan.Changes {
+		if entry.Action != planActionDelete && entry.Action != planActionReplace {
+			continue
+		}
+		if lock[entry.Name].Monitor.PreventDestroy || configData[entry.Name].PreventDestroy {
+			verb := "deleted"
+			if entry.Action == planActionReplace {
+				verb = "replaced"
+			}
+			return Plan{}, fmt.Errorf("monitor %q (id %d) has preventDestroy set and cannot be %s; set preventDestroy: false and apply first, or use --no-delete", entry.Name, entry.ID, verb)
 		}
 	}
-	return
+	return plan, nil
+}
+
+// ApplyResult records the outcome of a single create, update or delete.
+type ApplyResult struct {
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	ID     int    `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
 }
 
 // ApplyChanges applies the changes between the lock file and the config data, making API calls.
-// It works on a copy of the lock map so that partial failures leave the caller's map untouched.
-func ApplyChanges(ctx context.Context, apiKey string, lock config.MonitorsLock, configData config.Monitors) (config.MonitorsLock, error) {
-	working := make(config.MonitorsLock, len(lock))
-	for k, v := range lock {
-		working[k] = v
+// Creates, updates and replacements run first, then deletes, each with bounded concurrency.
+// It works on a copy of the lock map and returns it with every successful
+// operation recorded, even when some operations fail; in that case the
+// returned error summarises the failures and the results carry the details.
+// When nothing needs to change it returns a nil lock.
+func ApplyChanges(ctx context.Context, apiKey string, lock config.MonitorsLock, configData config.Monitors, opts ApplyOptions) (config.MonitorsLock, []ApplyResult, error) {
+	plan, err := buildApplyPlan(lock, configData, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !plan.HasChanges() {
+		return nil, nil, nil
 	}
 
-	var created, updated, deleted int
+	if opts.Concurrency < 1 {
+		opts.Concurrency = defaultApplyConcurrency
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = api.DefaultHTTPClient
+	}
 
-	for v, configValue := range configData {
-		value, exist := working[v]
-		if !exist {
-			result, err := CreateMonitor(ctx, api.DefaultHTTPClient, apiKey, configValue)
-			if err != nil {
-				return nil, err
-			}
-			working[v] = config.Lock{
-				ID:      result.ID,
-				Monitor: configValue,
-			}
-			created++
+	resolved := make(map[string]config.Monitor, len(plan.Changes))
+	for _, entry := range plan.Changes {
+		if entry.Action == planActionDelete {
 			continue
 		}
-		if !cmp.Equal(configValue, value.Monitor) {
-			result, err := UpdateMonitor(ctx, api.DefaultHTTPClient, apiKey, value.ID, configValue)
-			if err != nil {
-				return nil, err
-			}
-			working[v] = config.Lock{
-				ID:      result.ID,
-				Monitor: configValue,
-			}
-			updated++
+		m, err := config.ResolveSecrets(configData[entry.Name], opts.Vars)
+		if err != nil {
+			return nil, nil, err
+		}
+		resolved[entry.Name] = m
+	}
+
+	state := &applyState{
+		working:  make(config.MonitorsLock, len(lock)),
+		lockPath: opts.LockPath,
+	}
+	for k, v := range lock {
+		state.working[k] = v
+	}
+
+	var upserts, deletes []int
+	for i, entry := range plan.Changes {
+		if entry.Action == planActionDelete {
+			deletes = append(deletes, i)
+		} else {
+			upserts = append(upserts, i)
 		}
 	}
 
-	var toDelete []string
-	for v := range working {
-		if _, exist := configData[v]; !exist {
-			toDelete = append(toDelete, v)
+	results := make([]ApplyResult, len(plan.Changes))
+	for _, phase := range [][]int{upserts, deletes} {
+		sem := make(chan struct{}, opts.Concurrency)
+		var wg sync.WaitGroup
+		for _, idx := range phase {
+			wg.Add(1)
+			go func(idx int) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				entry := plan.Changes[idx]
+				results[idx] = state.apply(ctx, opts.HTTPClient, apiKey, entry, configData[entry.Name], resolved[entry.Name])
+			}(idx)
 		}
+		wg.Wait()
 	}
-	for _, v := range toDelete {
-		err := DeleteMonitorWithHTTPClient(ctx, api.DefaultHTTPClient, apiKey, fmt.Sprintf("%d", working[v].ID))
-		if err != nil {
-			return nil, fmt.Errorf("failed to delete monitor %d: %w", working[v].ID, err)
+
+	var failed int
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
 		}
-		delete(working, v)
-		deleted++
 	}
+	if failed > 0 {
+		return state.working, results, fmt.Errorf("%d of %d operations failed", failed, len(results))
+	}
+	return state.working, results, nil
+}
 
-	if created == 0 && updated == 0 && deleted == 0 {
-		return nil, nil
+// applyState guards the working lock shared by concurrent operations.
+type applyState struct {
+	mu       sync.Mutex
+	working  config.MonitorsLock
+	lockPath string
+}
+
+// commit records the outcome of an operation in the working lock and
+// persists it. A nil entry removes the monitor from the lock.
+func (s *applyState) commit(name string, entry *config.Lock) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry == nil {
+		delete(s.working, name)
+	} else {
+		s.working[name] = *entry
+	}
+	if s.lockPath == "" {
+		return nil
 	}
+	return config.WriteLockFile(s.lockPath, s.working)
+}
 
-	fmt.Println("Changes applied successfully")
-	if created > 0 {
-		fmt.Println("  Created:", created)
+// apply executes a single plan entry. m is recorded in the lock while
+// resolved, which has its secret references filled in, is sent to the API.
+func (s *applyState) apply(ctx context.Context, httpClient *http.Client, apiKey string, entry PlanEntry, m, resolved config.Monitor) ApplyResult {
+	result := ApplyResult{
+		Name:   entry.Name,
+		Action: entry.Action,
+		ID:     entry.ID,
 	}
-	if updated > 0 {
-		fmt.Println("  Updated:", updated)
+	if err := ctx.Err(); err != nil {
+		result.Error = fmt.Sprintf("skipped: %v", err)
+		return result
 	}
-	if deleted > 0 {
-		fmt.Println("  Deleted:", deleted)
+
+	switch entry.Action {
+	case planActionCreate:
+		created, err := CreateMonitor(ctx, httpClient, apiKey, resolved)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.ID = created.ID
+		if err := s.commit(entry.Name, &config.Lock{ID: created.ID, Monitor: m}); err != nil {
+			result.Error = fmt.Sprintf("monitor %d was created but the lock file could not be saved: %v", created.ID, err)
+		}
+	case planActionUpdate:
+		if localOnly(entry.Fields) {
+			if err := s.commit(entry.Name, &config.Lock{ID: entry.ID, Monitor: m}); err != nil {
+				result.Error = fmt.Sprintf("failed to save the lock file: %v", err)
+			}
+			return result
+		}
+		updated, err := UpdateMonitor(ctx, httpClient, apiKey, entry.ID, resolved)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		if err := s.commit(entry.Name, &config.Lock{ID: updated.ID, Monitor: m}); err != nil {
+			result.Error = fmt.Sprintf("monitor %d was updated but the lock file could not be saved: %v", updated.ID, err)
+		}
+	case planActionReplace:
+		created, err := CreateMonitor(ctx, httpClient, apiKey, resolved)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.ID = created.ID
+		if err := s.commit(entry.Name, &config.Lock{ID: created.ID, Monitor: m}); err != nil {
+			result.Error = fmt.Sprintf("monitor %d was created but the lock file could not be saved: %v", created.ID, err)
+			return result
+		}
+		if err := DeleteMonitorWithHTTPClient(ctx, httpClient, apiKey, strconv.Itoa(entry.ID)); err != nil {
+			result.Error = fmt.Sprintf("monitor %d replaced %d but the old monitor could not be deleted: %v", created.ID, entry.ID, err)
+		}
+	case planActionDelete:
+		if err := DeleteMonitorWithHTTPClient(ctx, httpClient, apiKey, strconv.Itoa(entry.ID)); err != nil {
+			result.Error = fmt.Sprintf("failed to delete monitor %d: %v", entry.ID, err)
+			return result
+		}
+		if err := s.commit(entry.Name, nil); err != nil {
+			result.Error = fmt.Sprintf("monitor %d was deleted but the lock file could not be saved: %v", entry.ID, err)
+		}
 	}
-	return working, nil
+	return result
+}
+
+// renderApplyResults prints one line per operation followed by a summary.
+func renderApplyResults(w io.Writer, results []ApplyResult) {
+	var created, updated, replaced, deleted, failed int
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+			fmt.Fprintf(w, "  %s %s: failed to %s: %s\n", color.RedString("✗"), r.Name, r.Action, r.Error)
+			continue
+		}
+		switch r.Action {
+		case planActionCreate:
+			created++
+			fmt.Fprintf(w, "  %s %s created (id %d)\n", color.GreenString("✓"), r.Name, r.ID)
+		case planActionUpdate:
+			updated++
+			fmt.Fprintf(w, "  %s %s updated (id %d)\n", color.GreenString("✓"), r.Name, r.ID)
+		case planActionReplace:
+			replaced++
+			fmt.Fprintf(w, "  %s %s replaced (id %d)\n", color.GreenString("✓"), r.Name, r.ID)
+		case planActionDelete:
+			deleted++
+			fmt.Fprintf(w, "  %s %s deleted (id %d)\n", color.GreenString("✓"), r.Name, r.ID)
+		}
+	}
+	if replaced > 0 {
+		fmt.Fprintf(w, "\nApplied: %d created, %d updated, %d replaced, %d deleted, %d failed.\n", created, updated, replaced, deleted, failed)
+		return
+	}
+	fmt.Fprintf(w, "\nApplied: %d created, %d updated, %d deleted, %d failed.\n", created, updated, deleted, failed)
 }
 
 func GetMonitorsApplyCmd() *cli.Command {
@@ -108,14 +294,55 @@ func GetMonitorsApplyCmd() *cli.Command {
 		Name:  "apply",
 		Usage: "Create or update monitors",
 		Description: `Creates or updates monitors according to the OpenStatus configuration file.
-Compares your openstatus.yaml with the current state and applies changes.`,
+Compares your openstatus.yaml with the current state and applies changes.
+Before planning, every monitor in openstatus.lock is refreshed from the
+workspace so that edits made in the dashboard are reconciled; pass
+--refresh=false to plan against the lock file only.
+The plan lists every monitor that will be created, updated or deleted, with
+the old and new value of each changed field. Combine --dry-run with --json
+to get the plan as a machine-readable document.
+Changes are applied concurrently (see --parallelism) and openstatus.lock is
+saved after every successful operation. If some operations fail, the ones
+that succeeded are kept in the lock and running apply again retries the rest.
+
+Use --target to apply only the named monitors and --no-delete to leave
+monitors that were removed from the configuration in place. Apply refuses to
+delete or replace a monitor that sets preventDestroy: true.
+
+To rename a monitor without recreating it, declare the rename in a top-level
+moved section; the lock entry is renamed and the monitor keeps its ID:
+
+  moved:
+    - from: api-prod
+      to: api-production
+
+String values may reference variables as ${VAR} or ${VAR:-default}, read
+from --var-file, the environment or a .env file. Secrets should be written as
+${secret:NAME}: they are resolved when calling the API but the reference, not
+the value, is stored in the lock file.
+
+--config also accepts a directory or a glob, and any file may list other
+files, directories or globs to load in a top-level include section. All of
+them share a single lock file; a monitor name defined twice is an error.
+
+With --env prod, openstatus.prod.yaml (if present) is merged over the config
+and the state is kept in openstatus.prod.lock.
+
+The configuration is checked as by 'openstatus monitors validate' first, and
+nothing is applied if it has errors.`,
 		UsageText: `openstatus monitors apply
   openstatus monitors apply --config custom.yaml -y
-  openstatus monitors apply --dry-run`,
+  openstatus monitors apply --dry-run
+  openstatus monitors apply --dry-run --json > plan.json
+  openstatus monitors apply --target api-prod --target web-prod
+  openstatus monitors apply --no-delete
+  openstatus monitors apply --env prod --var-file prod.env
+  openstatus monitors apply --config monitors/
+  openstatus monitors apply --config 'monitors/*.yaml'`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:        "config",
-				Usage:       "The configuration file containing monitor information",
+				Usage:       "The configuration file, directory or glob containing monitor information",
 				Aliases:     []string{"c"},
 				DefaultText: "openstatus.yaml",
 				Value:       "openstatus.yaml",
@@ -137,6 +364,32 @@ Compares your openstatus.yaml with the current state and applies changes.`,
 				Usage:   "Show what would be changed without applying",
 				Aliases: []string{"n"},
 			},
+			&cli.StringFlag{
+				Name:  "env",
+				Usage: "Environment name: merges openstatus.<env>.yaml over the config and uses openstatus.<env>.lock",
+			},
+			&cli.StringSliceFlag{
+				Name:  "var-file",
+				Usage: "Dotenv file with values for ${VAR} and ${secret:NAME} references (repeatable)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "target",
+				Usage: "Only apply changes to the monitor with this name (repeatable)",
+			},
+			&cli.BoolFlag{
+				Name:  "no-delete",
+				Usage: "Do not delete or replace monitors removed from the configuration",
+			},
+			&cli.IntFlag{
+				Name:  "parallelism",
+				Usage: "Number of monitors to create, update or delete concurrently",
+				Value: defaultApplyConcurrency,
+			},
+			&cli.BoolFlag{
+				Name:  "refresh",
+				Usage: "Refresh openstatus.lock from the workspace before planning",
+				Value: true,
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			apiKey, err := auth.ResolveAccessToken(cmd)
@@ -146,42 +399,121 @@ Compares your openstatus.yaml with the current state and applies changes.`,
 
 			path := cmd.String("config")
 
-			if path != "" {
+			if path != "" && !strings.ContainsAny(path, "*?[") {
 				if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
 					return cli.Exit("Config does not exist", 1)
 				}
 			}
 
-			monitors, err := config.ReadOpenStatus(path)
+			env := cmd.String("env")
+			lockPath := config.LockFilePath(env)
+			vars, err := config.ReadVarFiles(cmd.StringSlice("var-file")...)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			readOpts := config.ReadOptions{Vars: vars}
+			if env != "" {
+				if overlay := config.OverlayPath(path, env); fileExists(overlay) {
+					readOpts.Overlays = append(readOpts.Overlays, overlay)
+				}
+			}
+
+			errs, err := config.Validate(path, readOpts)
 			if err != nil {
-				return cli.Exit("Unable to read config file", 1)
+				return cli.Exit(fmt.Sprintf("Unable to read config file: %v", err), 1)
+			}
+			if len(errs) > 0 {
+				if output.IsJSONOutput() {
+					if err := output.PrintJSON(ValidationResult{Errors: errs}); err != nil {
+						return err
+					}
+				} else {
+					renderValidationErrors(os.Stderr, errs)
+				}
+				return cli.Exit("Invalid configuration, nothing was applied", 1)
 			}
 
-			lock, err := config.ReadLockFile("openstatus.lock")
+			file, err := config.ReadOpenStatusFile(path, readOpts)
+			if err != nil {
+				return cli.Exit(fmt.Sprintf("Unable to read config file: %v", err), 1)
+			}
+			monitors := file.Monitors
+
+			lock, err := config.ReadLockFile(lockPath)
 			if err != nil {
 				return cli.Exit("Unable to read lock file", 1)
 			}
 
-			created, updated, deleted := countChanges(lock, monitors)
-			if created == 0 && updated == 0 && deleted == 0 {
-				fmt.Println("No changes found")
-				return nil
+			lock, moved, err := applyMoves(lock, file.Moved, monitors)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+
+			var refreshed bool
+			if cmd.Bool("refresh") && len(lock) > 0 {
+				s := output.StartSpinner("Refreshing state...")
+				report, err := DetectDrift(ctx, NewMonitorClient(apiKey), lock)
+				output.StopSpinner(s)
+				if err != nil {
+					return cli.Exit(fmt.Sprintf("Failed to refresh state: %v", err), 1)
+				}
+				// Unmanaged monitors are not in the lock, so there is
+				// nothing to refresh.
+				report = report.Managed()
+				if report.HasDrift() {
+					if !output.IsJSONOutput() {
+						fmt.Println("Detected changes made outside of openstatus.yaml:")
+						fmt.Println()
+						renderDrift(os.Stdout, report)
+						fmt.Println()
+					}
+					lock = RefreshLock(lock, report)
+					refreshed = true
+				}
 			}
 
-			fmt.Println("This will apply the following changes:")
-			if created > 0 {
-				fmt.Println("  Create:", created)
+			opts := ApplyOptions{
+				Concurrency: int(cmd.Int("parallelism")),
+				LockPath:    lockPath,
+				Targets:     cmd.StringSlice("target"),
+				NoDelete:    cmd.Bool("no-delete"),
+				Vars:        vars,
 			}
-			if updated > 0 {
-				fmt.Println("  Update:", updated)
+			plan, err := buildApplyPlan(lock, monitors, opts)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
 			}
-			if deleted > 0 {
-				fmt.Println("  Delete:", deleted)
+			plan.Moved = moved
+			if output.IsJSONOutput() {
+				// When changes are applied, the per-operation results are
+				// printed instead of the plan.
+				if cmd.Bool("dry-run") || !plan.HasChanges() {
+					if err := output.PrintJSON(plan); err != nil {
+						return err
+					}
+				}
+			} else {
+				if !plan.HasChanges() && len(plan.Moved) == 0 {
+					fmt.Println("No changes found")
+				} else {
+					fmt.Println("This will apply the following changes:")
+					fmt.Println()
+					renderPlan(os.Stdout, plan)
+				}
 			}
 
 			if cmd.Bool("dry-run") {
 				return nil
 			}
+			saveLock := refreshed || len(moved) > 0
+			if !plan.HasChanges() {
+				if saveLock {
+					if err := config.WriteLockFile(lockPath, lock); err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+				}
+				return nil
+			}
 
 			if !cmd.Bool("auto-accept") {
 				confirmed, err := output.AskForConfirmation("Do you want to continue?")
@@ -192,33 +524,28 @@ Compares your openstatus.yaml with the current state and applies changes.`,
 					return nil
 				}
 			}
+			if saveLock {
+				if err := config.WriteLockFile(lockPath, lock); err != nil {
+					return cli.Exit(err.Error(), 1)
+				}
+			}
 
 			s := output.StartSpinner("Applying changes...")
-			newLock, err := ApplyChanges(ctx, apiKey, lock, monitors)
+			_, results, applyErr := ApplyChanges(ctx, apiKey, lock, monitors, opts)
 			output.StopSpinner(s)
-			if err != nil {
-				return cli.Exit(fmt.Sprintf("Failed to apply changes: %v", err), 1)
-			}
-			if newLock == nil {
-				fmt.Println("No changes found")
-				return nil
-			}
-			y, err := yaml.Marshal(&newLock)
-			if err != nil {
-				return cli.Exit("Failed to marshal lock file", 1)
-			}
-			file, err := os.OpenFile("openstatus.lock", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
-			if err != nil {
-				return cli.Exit("Failed to open lock file", 1)
+			if output.IsJSONOutput() {
+				if err := output.PrintJSON(results); err != nil {
+					return err
+				}
+			} else {
+				fmt.Println()
+				renderApplyResults(os.Stdout, results)
 			}
-			defer file.Close()
-
-			_, err = file.Write(y)
-			if err != nil {
-				return cli.Exit("Failed to write lock file", 1)
+			if applyErr != nil {
+				return cli.Exit(fmt.Sprintf("Failed to apply changes: %v. Completed operations were saved to %s; run 'openstatus monitors apply' again to retry the rest", applyErr, lockPath), 1)
 			}
-			if err := file.Sync(); err != nil {
-				return cli.Exit("Failed to sync lock file", 1)
+			if output.IsJSONOutput() {
+				return nil
 			}
 			fmt.Println("\nRun 'openstatus monitors list' to see your monitors")
 			return nil
@@ -226,3 +553,8 @@ Compares your openstatus.yaml with the current state and applies changes.`,
 	}
 	return &monitorsApplyCmd
 }
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -2,6 +2,11 @@ package monitors_test
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/openstatusHQ/cli/internal/config"
@@ -33,7 +38,7 @@ func Test_ApplyChanges(t *testing.T) {
 			"test-monitor": monitor,
 		}
 
-		result, err := monitors.ApplyChanges(context.Background(), "test-api-key", lock, configData)
+		result, results, err := monitors.ApplyChanges(context.Background(), "test-api-key", lock, configData, monitors.ApplyOptions{})
 		if err != nil {
 			t.Fatalf("Expected no error, got %v", err)
 		}
@@ -41,5 +46,200 @@ func Test_ApplyChanges(t *testing.T) {
 		if result != nil {
 			t.Errorf("Expected nil result when no changes, got %v", result)
 		}
+		if len(results) != 0 {
+			t.Errorf("Expected no operations, got %v", results)
+		}
+	})
+	t.Run("Keeps successful operations when others fail", func(t *testing.T) {
+		lockPath := filepath.Join(t.TempDir(), "openstatus.lock")
+
+		var calls atomic.Int32
+		interceptor := &interceptorHTTPClient{
+			f: func(req *http.Request) (*http.Response, error) {
+				calls.Add(1)
+				switch {
+				case strings.HasSuffix(req.URL.Path, "/CreateHTTPMonitor"):
+					bodyBytes, _ := io.ReadAll(req.Body)
+					if strings.Contains(string(bodyBytes), "broken.example.com") {
+						return jsonResponse(http.StatusInternalServerError, `{"code":"internal","message":"boom"}`), nil
+					}
+					return jsonResponse(http.StatusOK, `{"monitor":{"id":"456","name":"Healthy","url":"https://healthy.example.com","periodicity":"PERIODICITY_10M","method":"HTTP_METHOD_GET","regions":["REGION_FLY_IAD"],"active":true}}`), nil
+				case strings.HasSuffix(req.URL.Path, "/DeleteMonitor"):
+					return jsonResponse(http.StatusOK, `{"success":true}`), nil
+				}
+				t.Errorf("Unexpected request to %s", req.URL.Path)
+				return jsonResponse(http.StatusNotFound, `{}`), nil
+			},
+		}
+
+		healthy := config.Monitor{
+			Name:      "Healthy",
+			Active:    true,
+			Frequency: config.The10M,
+			Kind:      config.HTTP,
+			Regions:   []config.Region{config.Iad},
+			Request: config.Request{
+				URL:    "https://healthy.example.com",
+				Method: config.Get,
+			},
+		}
+		broken := healthy
+		broken.Name = "Broken"
+		broken.Request.URL = "https://broken.example.com"
+
+		lock := config.MonitorsLock{
+			"stale": {ID: 789, Monitor: healthy},
+		}
+		configData := config.Monitors{
+			"healthy": healthy,
+			"broken":  broken,
+		}
+
+		result, results, err := monitors.ApplyChanges(context.Background(), "test-api-key", lock, configData, monitors.ApplyOptions{
+			Concurrency: 2,
+			LockPath:    lockPath,
+			HTTPClient:  interceptor.GetHTTPClient(),
+		})
+		if err == nil {
+			t.Fatal("Expected error when an operation fails, got nil")
+		}
+		if calls.Load() != 3 {
+			t.Errorf("Expected 3 API calls, got %d", calls.Load())
+		}
+
+		if len(results) != 3 {
+			t.Fatalf("Expected 3 results, got %d", len(results))
+		}
+		for _, r := range results {
+			failed := r.Error != ""
+			if failed != (r.Name == "broken") {
+				t.Errorf("Unexpected result for %s: %+v", r.Name, r)
+			}
+		}
+
+		if _, ok := result["broken"]; ok {
+			t.Error("Expected failed monitor to be absent from the lock")
+		}
+		if result["healthy"].ID != 456 {
+			t.Errorf("Expected healthy monitor with ID 456, got %+v", result["healthy"])
+		}
+		if _, ok := result["stale"]; ok {
+			t.Error("Expected deleted monitor to be removed from the lock")
+		}
+		if _, ok := lock["healthy"]; ok {
+			t.Error("Expected caller's lock to be left untouched")
+		}
+
+		saved, err := config.ReadLockFile(lockPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(saved) != 1 || saved["healthy"].ID != 456 {
+			t.Errorf("Expected lock file to contain only the created monitor, got %v", saved)
+		}
+	})
+}
+
+func Test_BuildApplyPlan(t *testing.T) {
+	t.Parallel()
+
+	base := config.Monitor{
+		Name:      "Test Monitor",
+		Active:    true,
+		Frequency: config.The10M,
+		Kind:      config.HTTP,
+		Regions:   []config.Region{config.Iad},
+		Request: config.Request{
+			URL:    "https://example.com",
+			Method: config.Get,
+		},
+	}
+	changed := base
+	changed.Frequency = config.The5M
+	protected := base
+	protected.PreventDestroy = true
+
+	lock := config.MonitorsLock{
+		"api":     {ID: 1, Monitor: base},
+		"web":     {ID: 2, Monitor: base},
+		"removed": {ID: 3, Monitor: base},
+	}
+	configData := config.Monitors{
+		"api": changed,
+		"web": changed,
+		"new": base,
+	}
+
+	t.Run("Targets restrict the plan", func(t *testing.T) {
+		plan, err := monitors.BuildApplyPlan(lock, configData, monitors.ApplyOptions{Targets: []string{"api", "removed"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(plan.Changes) != 2 || plan.Changes[0].Name != "api" || plan.Changes[1].Name != "removed" {
+			t.Errorf("Unexpected changes %+v", plan.Changes)
+		}
+		if plan.Summary.Update != 1 || plan.Summary.Delete != 1 || plan.Summary.Create != 0 {
+			t.Errorf("Unexpected summary %+v", plan.Summary)
+		}
+	})
+
+	t.Run("Unknown target returns error", func(t *testing.T) {
+		_, err := monitors.BuildApplyPlan(lock, configData, monitors.ApplyOptions{Targets: []string{"missing"}})
+		if err == nil || !strings.Contains(err.Error(), `"missing"`) {
+			t.Errorf("Expected unknown target error, got %v", err)
+		}
+	})
+
+	t.Run("No delete skips deletions", func(t *testing.T) {
+		plan, err := monitors.BuildApplyPlan(lock, configData, monitors.ApplyOptions{NoDelete: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range plan.Changes {
+			if e.Action == "delete" {
+				t.Errorf("Expected no deletions, got %+v", e)
+			}
+		}
+		if plan.Summary.Create != 1 || plan.Summary.Update != 2 {
+			t.Errorf("Unexpected summary %+v", plan.Summary)
+		}
+	})
+
+	t.Run("Prevent destroy blocks deletion", func(t *testing.T) {
+		guarded := config.MonitorsLock{"removed": {ID: 3, Monitor: protected}}
+		_, err := monitors.BuildApplyPlan(guarded, config.Monitors{}, monitors.ApplyOptions{})
+		if err == nil || !strings.Contains(err.Error(), "preventDestroy") {
+			t.Errorf("Expected preventDestroy error, got %v", err)
+		}
+
+		plan, err := monitors.BuildApplyPlan(guarded, config.Monitors{}, monitors.ApplyOptions{NoDelete: true})
+		if err != nil {
+			t.Fatalf("Expected --no-delete to bypass the deletion, got %v", err)
+		}
+		if plan.HasChanges() {
+			t.Errorf("Expected no changes, got %+v", plan.Changes)
+		}
+	})
+
+	t.Run("Prevent destroy blocks replacement", func(t *testing.T) {
+		tcp := protected
+		tcp.Kind = config.TCP
+		tcp.Request = config.Request{Host: "example.com", Port: 443}
+		_, err := monitors.BuildApplyPlan(config.MonitorsLock{"api": {ID: 1, Monitor: protected}}, config.Monitors{"api": tcp}, monitors.ApplyOptions{})
+		if err == nil || !strings.Contains(err.Error(), "cannot be replaced") {
+			t.Errorf("Expected preventDestroy error, got %v", err)
+		}
+	})
+
+	t.Run("Prevent destroy does not block updates", func(t *testing.T) {
+		updated := protected
+		updated.Frequency = config.The5M
+		plan, err := monitors.BuildApplyPlan(config.MonitorsLock{"api": {ID: 1, Monitor: protected}}, config.Monitors{"api": updated}, monitors.ApplyOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if plan.Summary.Update != 1 {
+			t.Errorf("Unexpected summary %+v", plan.Summary)
+		}
 	})
 }
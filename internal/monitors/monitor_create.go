@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 
 	"buf.build/gen/go/openstatus/api/connectrpc/gosimple/openstatus/monitor/v1/monitorv1connect"
 	monitorv1 "buf.build/gen/go/openstatus/api/protocolbuffers/go/openstatus/monitor/v1"
@@ -16,6 +18,7 @@ import (
 	"github.com/openstatusHQ/cli/internal/auth"
 	output "github.com/openstatusHQ/cli/internal/cli"
 	"github.com/openstatusHQ/cli/internal/config"
+	"github.com/openstatusHQ/cli/internal/curl"
 )
 
 // CreateMonitor creates a monitor using the SDK, dispatching to the appropriate type
@@ -27,6 +30,8 @@ func CreateMonitor(ctx context.Context, httpClient *http.Client, apiKey string,
 		return CreateHTTPMonitor(ctx, client, monitor)
 	case config.TCP:
 		return CreateTCPMonitor(ctx, client, monitor)
+	case config.DNS:
+		return CreateDNSMonitor(ctx, client, monitor)
 	default:
 		return Monitor{}, fmt.Errorf("unsupported monitor kind: %s", monitor.Kind)
 	}
@@ -64,6 +69,24 @@ func CreateTCPMonitor(ctx context.Context, client monitorv1connect.MonitorServic
 	return tcpMonitorToLocal(resp.GetMonitor())
 }
 
+// CreateDNSMonitor creates a DNS monitor using the SDK
+func CreateDNSMonitor(ctx context.Context, client monitorv1connect.MonitorServiceClient, monitor config.Monitor) (Monitor, error) {
+	dnsMonitor, err := configToDNSMonitor(monitor)
+	if err != nil {
+		return Monitor{}, err
+	}
+	req := &monitorv1.CreateDNSMonitorRequest{
+		Monitor: dnsMonitor,
+	}
+
+	resp, err := client.CreateDNSMonitor(ctx, req)
+	if err != nil {
+		return Monitor{}, fmt.Errorf("failed to create DNS monitor: %w", err)
+	}
+
+	return dnsMonitorToLocal(resp.GetMonitor())
+}
+
 func httpMonitorToLocal(m *monitorv1.HTTPMonitor) (Monitor, error) {
 	id, err := strconv.Atoi(m.GetId())
 	if err != nil {
@@ -140,6 +163,74 @@ func tcpMonitorToLocal(m *monitorv1.TCPMonitor) (Monitor, error) {
 	}, nil
 }
 
+func dnsMonitorToLocal(m *monitorv1.DNSMonitor) (Monitor, error) {
+	id, err := strconv.Atoi(m.GetId())
+	if err != nil {
+		return Monitor{}, fmt.Errorf("invalid monitor ID %q: %w", m.GetId(), err)
+	}
+
+	var assertions []Assertion
+	for _, a := range m.GetRecordAssertions() {
+		assertions = append(assertions, Assertion{
+			Type:    "dns_record",
+			Compare: string(convertRecordComparator(a.GetComparator())),
+			Target:  a.GetTarget(),
+			Key:     a.GetRecord(),
+		})
+	}
+
+	return Monitor{
+		ID:            id,
+		Name:          m.GetName(),
+		Description:   m.GetDescription(),
+		URL:           m.GetUri(),
+		Periodicity:   periodicityToString(m.GetPeriodicity()),
+		Regions:       regionsToStrings(m.GetRegions()),
+		Active:        m.GetActive(),
+		Public:        m.GetPublic(),
+		Timeout:       int(m.GetTimeout()),
+		DegradedAfter: int(m.GetDegradedAt()),
+		Assertions:    assertions,
+		Retry:         int(m.GetRetry()),
+		JobType:       "dns",
+	}, nil
+}
+
+// monitorFromCurl builds an HTTP monitor from the request of a curl command.
+// Regions are required since a curl command only describes the request.
+func monitorFromCurl(req curl.Request, name string, regions []string, frequency string) (config.Monitor, error) {
+	if name == "" {
+		return config.Monitor{}, errors.New("--name is required with --from-curl")
+	}
+	if len(regions) == 0 {
+		return config.Monitor{}, errors.New("--regions is required with --from-curl, e.g. --regions iad,ams")
+	}
+	methods := []config.Method{config.Get, config.Post, config.Put, config.Patch, config.Delete, config.Head, config.Options}
+	if !slices.Contains(methods, config.Method(req.Method)) {
+		return config.Monitor{}, fmt.Errorf("unsupported method %s in --from-curl", req.Method)
+	}
+	frequencies := []config.Frequency{config.The30S, config.The1M, config.The5M, config.The10M, config.The30M, config.The1H}
+	if !slices.Contains(frequencies, config.Frequency(frequency)) {
+		return config.Monitor{}, fmt.Errorf("invalid --frequency %q: must be one of 30s, 1m, 5m, 10m, 30m, 1h", frequency)
+	}
+
+	m := config.Monitor{
+		Name:      name,
+		Frequency: config.Frequency(frequency),
+		Active:    true,
+		Kind:      config.HTTP,
+		Request:   req.ConfigRequest(),
+	}
+	for _, r := range regions {
+		region := config.Region(strings.TrimSpace(r))
+		if stringToRegion(region) == monitorv1.Region_REGION_UNSPECIFIED {
+			return config.Monitor{}, fmt.Errorf("unknown region %q", r)
+		}
+		m.Regions = append(m.Regions, region)
+	}
+	return m, nil
+}
+
 func GetMonitorCreateCmd() *cli.Command {
 	monitorCreateCmd := cli.Command{
 		Name:            "create",
@@ -147,11 +238,21 @@ func GetMonitorCreateCmd() *cli.Command {
 		Hidden:          true,
 		HideHelp:        true,
 		HideHelpCommand: true,
-		Description:     "Create the monitors defined in the openstatus.yaml file",
+		Description: `Create the monitors defined in the openstatus.yaml file.
+
+With --from-curl, add an HTTP monitor built from a curl command, e.g. one
+copied from the browser devtools, to the config file instead. --name and
+--regions are required. Credentials are saved as ${secret:NAME} references.
+Run 'openstatus monitors apply' to create it.`,
 		UsageText: `openstatus monitors create
-  openstatus monitors create --config custom.yaml -y`,
+  openstatus monitors create --config custom.yaml -y
+  openstatus monitors create --from-curl "curl -X POST https://openstat.us/api -H 'Authorization: Bearer …'" --name api --regions iad,ams`,
 
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if command := cmd.String("from-curl"); command != "" {
+				return saveMonitorFromCurl(cmd, command)
+			}
+
 			apiKey, err := auth.ResolveAccessToken(cmd)
 			if err != nil {
 				return cli.Exit(err.Error(), 1)
@@ -183,6 +284,11 @@ func GetMonitorCreateCmd() *cli.Command {
 			}
 			s := output.StartSpinner("Creating monitors...")
 			for _, value := range monitors {
+				value, err = config.ResolveSecrets(value, nil)
+				if err != nil {
+					output.StopSpinner(s)
+					return cli.Exit(err.Error(), 1)
+				}
 				_, err = CreateMonitor(ctx, api.DefaultHTTPClient, apiKey, value)
 				if err != nil {
 					output.StopSpinner(s)
@@ -214,7 +320,54 @@ func GetMonitorCreateCmd() *cli.Command {
 				Aliases:  []string{"y"},
 				Required: false,
 			},
+			&cli.StringFlag{
+				Name:  "from-curl",
+				Usage: "Add an HTTP monitor built from a curl command to the config file",
+			},
+			&cli.StringFlag{
+				Name:  "name",
+				Usage: "Name of the monitor added with --from-curl",
+			},
+			&cli.StringSliceFlag{
+				Name:  "regions",
+				Usage: "Regions of the monitor added with --from-curl, e.g. iad,ams",
+			},
+			&cli.StringFlag{
+				Name:  "frequency",
+				Usage: "Frequency of the monitor added with --from-curl",
+				Value: string(config.The10M),
+			},
 		},
 	}
 	return &monitorCreateCmd
 }
+
+// saveMonitorFromCurl appends the monitor of a curl command to the config
+// file, to be created by apply with a lock entry.
+func saveMonitorFromCurl(cmd *cli.Command, command string) error {
+	req, err := curl.Parse(command)
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("invalid --from-curl: %s", err), 1)
+	}
+	name := cmd.String("name")
+	monitor, err := monitorFromCurl(req, name, cmd.StringSlice("regions"), cmd.String("frequency"))
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+	if req.Insecure {
+		fmt.Fprintln(os.Stderr, "Warning: -k/--insecure is not supported by monitors and is ignored.")
+	}
+
+	var secrets []string
+	monitor.Request.Headers, secrets = config.SecretHeaders(name, monitor.Request.Headers)
+	path := cmd.String("config")
+	if err := config.AppendMonitor(path, name, monitor); err != nil {
+		return cli.Exit(fmt.Sprintf("Failed to save monitor: %s", err), 1)
+	}
+	if len(secrets) > 0 {
+		fmt.Fprintf(os.Stderr, "Credentials were saved as secret references: set %s in a --var-file or the environment before applying.\n", strings.Join(secrets, ", "))
+	}
+	fmt.Printf("Monitor %q saved to %s\n", name, path)
+	fmt.Println("Run 'openstatus monitors apply' to create it")
+	return nil
+}
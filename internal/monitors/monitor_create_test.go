@@ -6,9 +6,11 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/openstatusHQ/cli/internal/config"
+	"github.com/openstatusHQ/cli/internal/curl"
 	"github.com/openstatusHQ/cli/internal/monitors"
 )
 
@@ -311,3 +313,48 @@ func Test_CreateMonitor_FollowRedirects(t *testing.T) {
 		}
 	})
 }
+
+func Test_monitorFromCurl(t *testing.T) {
+	t.Parallel()
+	req, err := curl.Parse(`curl -L -X POST https://openstat.us/api -u admin:s3cret -H 'Content-Type: application/json' --data-raw '{"ping":true}'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := monitors.MonitorFromCurl(req, "api", []string{"iad", "koyeb_fra"}, "5m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Name != "api" || m.Kind != config.HTTP || m.Frequency != config.The5M || !m.Active {
+		t.Errorf("unexpected monitor %+v", m)
+	}
+	if len(m.Regions) != 2 || m.Regions[1] != config.KoyebFra {
+		t.Errorf("unexpected regions %v", m.Regions)
+	}
+	if m.Request.Method != config.Post || m.Request.Body != `{"ping":true}` || m.Request.Headers["Authorization"] != "Basic YWRtaW46czNjcmV0" {
+		t.Errorf("unexpected request %+v", m.Request)
+	}
+	if m.Request.FollowRedirects == nil || !*m.Request.FollowRedirects {
+		t.Error("expected -L to follow redirects")
+	}
+
+	for _, tt := range []struct {
+		name, frequency string
+		regions         []string
+		want            string
+	}{
+		{"", "10m", []string{"iad"}, "--name is required"},
+		{"api", "10m", nil, "--regions is required"},
+		{"api", "10m", []string{"mars"}, `unknown region "mars"`},
+		{"api", "2m", []string{"iad"}, "invalid --frequency"},
+	} {
+		if _, err := monitors.MonitorFromCurl(req, tt.name, tt.regions, tt.frequency); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("expected error %q, got %v", tt.want, err)
+		}
+	}
+
+	req.Method = "TRACE"
+	if _, err := monitors.MonitorFromCurl(req, "api", []string{"iad"}, "10m"); err == nil || !strings.Contains(err.Error(), "unsupported method TRACE") {
+		t.Errorf("expected a method error, got %v", err)
+	}
+}
@@ -46,8 +46,9 @@ func GetMonitorDeleteCmd() *cli.Command {
 		Hidden:          true,
 		HideHelpCommand: true,
 		HideHelp:        true,
-		UsageText: `openstatus monitors delete <MonitorID>
-  openstatus monitors delete 12345 -y`,
+		UsageText: `openstatus monitors delete <MonitorID|name>
+  openstatus monitors delete 12345 -y
+  openstatus monitors delete api-prod -y`,
 
 		Flags: []cli.Flag{
 			&cli.StringFlag{
@@ -62,20 +63,32 @@ func GetMonitorDeleteCmd() *cli.Command {
 				Aliases:  []string{"y"},
 				Required: false,
 			},
+			ResolveEnvFlag(),
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			apiKey, err := auth.ResolveAccessToken(cmd)
 			if err != nil {
 				return cli.Exit(err.Error(), 1)
 			}
-			monitorId := cmd.Args().Get(0)
+			client := NewMonitorClient(apiKey)
+			// Only exact and normalized names, so a typo never deletes
+			// another monitor.
+			monitor, err := ResolveMonitor(ctx, client, cmd.Args().Get(0), ResolveOptions{Env: cmd.String("env"), Exact: true})
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			monitorId := monitor.ID
 			if monitorId == "" {
 				fmt.Fprintln(os.Stderr, "Usage: openstatus monitors delete <monitor-id>")
 				return cli.Exit("monitor ID is required", 1)
 			}
 
 			if !cmd.Bool("auto-accept") {
-				confirmed, err := output.AskForConfirmation(fmt.Sprintf("You are about to delete monitor: %s, do you want to continue", monitorId))
+				target := monitorId
+				if monitor.Name != "" {
+					target = monitor.String()
+				}
+				confirmed, err := output.AskForConfirmation(fmt.Sprintf("You are about to delete monitor: %s, do you want to continue", target))
 				if err != nil {
 					return cli.Exit(fmt.Sprintf("Failed to read input: %v", err), 1)
 				}
@@ -83,7 +96,6 @@ func GetMonitorDeleteCmd() *cli.Command {
 					return nil
 				}
 			}
-			client := NewMonitorClient(apiKey)
 			s := output.StartSpinner("Deleting monitor...")
 			err = DeleteMonitor(ctx, client, monitorId)
 			output.StopSpinner(s)
@@ -0,0 +1,429 @@
+package monitors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+
+	"buf.build/gen/go/openstatus/api/connectrpc/gosimple/openstatus/monitor/v1/monitorv1connect"
+	monitorv1 "buf.build/gen/go/openstatus/api/protocolbuffers/go/openstatus/monitor/v1"
+	"connectrpc.com/connect"
+	"github.com/fatih/color"
+	"github.com/google/go-cmp/cmp"
+	"github.com/urfave/cli/v3"
+
+	"github.com/openstatusHQ/cli/internal/api"
+	"github.com/openstatusHQ/cli/internal/auth"
+	output "github.com/openstatusHQ/cli/internal/cli"
+	"github.com/openstatusHQ/cli/internal/config"
+)
+
+const (
+	driftChanged   = "changed"
+	driftDeleted   = "deleted"
+	driftUnmanaged = "unmanaged"
+)
+
+// driftConcurrency bounds the number of GetMonitor calls in flight.
+const driftConcurrency = 8
+
+// API defaults applied to fields left empty in openstatus.yaml.
+const (
+	apiDefaultTimeout int64 = 45000
+	apiDefaultRetry   int64 = 3
+)
+
+// DriftReport lists the monitors whose live state differs from openstatus.lock.
+type DriftReport struct {
+	Entries []DriftEntry `json:"drift"`
+}
+
+type DriftEntry struct {
+	Name   string        `json:"name,omitempty"`
+	ID     int           `json:"id"`
+	Kind   string        `json:"kind"`
+	Fields []FieldChange `json:"fields,omitempty"`
+
+	remote config.Monitor
+}
+
+// HasDrift reports whether any monitor was changed, deleted or created out-of-band.
+func (r DriftReport) HasDrift() bool {
+	return len(r.Entries) > 0
+}
+
+// Managed returns the report without the unmanaged monitors, which the lock
+// file knows nothing about.
+func (r DriftReport) Managed() DriftReport {
+	var managed DriftReport
+	for _, e := range r.Entries {
+		if e.Kind != driftUnmanaged {
+			managed.Entries = append(managed.Entries, e)
+		}
+	}
+	return managed
+}
+
+// DetectDrift fetches every monitor recorded in the lock file and compares it
+// with the live workspace. Monitors that exist remotely but are not tracked in
+// the lock are reported as unmanaged.
+func DetectDrift(ctx context.Context, client monitorv1connect.MonitorServiceClient, lock config.MonitorsLock) (DriftReport, error) {
+	type fetchResult struct {
+		name    string
+		remote  config.Monitor
+		deleted bool
+		err     error
+	}
+
+	names := make([]string, 0, len(lock))
+	for name := range lock {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := make([]fetchResult, len(names))
+	sem := make(chan struct{}, driftConcurrency)
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(idx int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			id := strconv.Itoa(lock[name].ID)
+			resp, err := client.GetMonitor(ctx, &monitorv1.GetMonitorRequest{Id: id})
+			if err != nil {
+				if connect.CodeOf(err) == connect.CodeNotFound {
+					results[idx] = fetchResult{name: name, deleted: true}
+					return
+				}
+				results[idx] = fetchResult{name: name, err: output.FormatError(err, "monitor", id)}
+				return
+			}
+
+			mc := resp.GetMonitor()
+			var remote config.Monitor
+			switch {
+			case mc.HasHttp():
+				remote = convertHTTPMonitorToConfig(mc.GetHttp())
+			case mc.HasTcp():
+				remote = convertTCPMonitorToConfig(mc.GetTcp())
+			case mc.HasDns():
+				remote = convertDNSMonitorToConfig(mc.GetDns())
+			default:
+				results[idx] = fetchResult{name: name, err: fmt.Errorf("unknown monitor type for monitor ID: %s", id)}
+				return
+			}
+			results[idx] = fetchResult{name: name, remote: remote}
+		}(i, name)
+	}
+	wg.Wait()
+
+	var report DriftReport
+	for _, r := range results {
+		if r.err != nil {
+			return DriftReport{}, r.err
+		}
+		locked := lock[r.name]
+		if r.deleted {
+			report.Entries = append(report.Entries, DriftEntry{
+				Name: r.name,
+				ID:   locked.ID,
+				Kind: driftDeleted,
+			})
+			continue
+		}
+		remote := alignRemoteMonitor(r.remote, locked.Monitor)
+		if fields := diffMonitor(locked.Monitor, remote); len(fields) > 0 {
+			report.Entries = append(report.Entries, DriftEntry{
+				Name:   r.name,
+				ID:     locked.ID,
+				Kind:   driftChanged,
+				Fields: fields,
+				remote: remote,
+			})
+		}
+	}
+
+	resp, err := client.ListMonitors(ctx, &monitorv1.ListMonitorsRequest{})
+	if err != nil {
+		return DriftReport{}, output.FormatError(err, "monitors", "")
+	}
+	tracked := make(map[int]bool, len(lock))
+	for _, l := range lock {
+		tracked[l.ID] = true
+	}
+	var unmanaged []DriftEntry
+	addUnmanaged := func(id, name string) {
+		i, err := strconv.Atoi(id)
+		if err != nil || tracked[i] {
+			return
+		}
+		unmanaged = append(unmanaged, DriftEntry{Name: name, ID: i, Kind: driftUnmanaged})
+	}
+	for _, m := range resp.GetHttpMonitors() {
+		addUnmanaged(m.GetId(), m.GetName())
+	}
+	for _, m := range resp.GetTcpMonitors() {
+		addUnmanaged(m.GetId(), m.GetName())
+	}
+	for _, m := range resp.GetDnsMonitors() {
+		addUnmanaged(m.GetId(), m.GetName())
+	}
+	sort.Slice(unmanaged, func(i, j int) bool { return unmanaged[i].ID < unmanaged[j].ID })
+	report.Entries = append(report.Entries, unmanaged...)
+
+	return report, nil
+}
+
+// alignRemoteMonitor fills in the parts of a converted remote monitor that the
+// API does not round-trip, and clears server-side defaults that the locked
+// definition left empty, so only real changes are reported as drift.
+func alignRemoteMonitor(remote, locked config.Monitor) config.Monitor {
+	remote.Request.FollowRedirects = locked.Request.FollowRedirects
+	remote.OpenTelemetry = locked.OpenTelemetry
+	remote.PreventDestroy = locked.PreventDestroy
+	remote.Team = locked.Team
+	remote = config.RestoreSecretRefs(remote, locked)
+	remote.Assertions = alignAssertions(remote.Assertions, locked.Assertions)
+	if locked.Timeout == 0 && remote.Timeout == apiDefaultTimeout {
+		remote.Timeout = 0
+	}
+	if locked.Retry == 0 && remote.Retry == apiDefaultRetry {
+		remote.Retry = 0
+	}
+	if locked.Request.Method == "" && remote.Request.Method == config.Get {
+		remote.Request.Method = ""
+	}
+	return remote
+}
+
+// alignAssertions puts the remote assertions in the order of the locked
+// ones. The API groups assertions by kind, so the order written in
+// openstatus.yaml is lost and must not read as drift. Remote assertions
+// missing from the lock keep their order, after the others.
+func alignAssertions(remote, locked []config.Assertion) []config.Assertion {
+	if len(remote) == 0 {
+		return remote
+	}
+	used := make([]bool, len(remote))
+	aligned := make([]config.Assertion, 0, len(remote))
+	for _, l := range locked {
+		for i, r := range remote {
+			if !used[i] && cmp.Equal(l, r) {
+				used[i] = true
+				aligned = append(aligned, r)
+				break
+			}
+		}
+	}
+	for i, r := range remote {
+		if !used[i] {
+			aligned = append(aligned, r)
+		}
+	}
+	return aligned
+}
+
+// RefreshLock returns a copy of the lock updated with the live state:
+// changed monitors take their remote definition and deleted ones are dropped.
+// Unmanaged monitors are left out, since they have no logical name.
+func RefreshLock(lock config.MonitorsLock, report DriftReport) config.MonitorsLock {
+	refreshed := make(config.MonitorsLock, len(lock))
+	for k, v := range lock {
+		refreshed[k] = v
+	}
+	for _, e := range report.Entries {
+		switch e.Kind {
+		case driftChanged:
+			refreshed[e.Name] = config.Lock{ID: e.ID, Monitor: e.remote}
+		case driftDeleted:
+			delete(refreshed, e.Name)
+		}
+	}
+	return refreshed
+}
+
+// overwriteRemote pushes the locked definition back to the workspace for every
+// drifted monitor, recreating deleted ones. It returns the updated lock, even
+// on error, so that the monitors recreated before the failure are not lost.
+func overwriteRemote(ctx context.Context, apiKey string, lock config.MonitorsLock, report DriftReport, vars config.Variables) (config.MonitorsLock, error) {
+	working := make(config.MonitorsLock, len(lock))
+	for k, v := range lock {
+		working[k] = v
+	}
+	for _, e := range report.Entries {
+		if e.Kind == driftUnmanaged {
+			continue
+		}
+		locked := working[e.Name]
+		resolved, err := config.ResolveSecrets(locked.Monitor, vars)
+		if err != nil {
+			return working, err
+		}
+		switch e.Kind {
+		case driftChanged:
+			if _, err := UpdateMonitor(ctx, api.DefaultHTTPClient, apiKey, locked.ID, resolved); err != nil {
+				return working, fmt.Errorf("failed to overwrite monitor %s: %w", e.Name, err)
+			}
+		case driftDeleted:
+			result, err := CreateMonitor(ctx, api.DefaultHTTPClient, apiKey, resolved)
+			if err != nil {
+				return working, fmt.Errorf("failed to recreate monitor %s: %w", e.Name, err)
+			}
+			working[e.Name] = config.Lock{ID: result.ID, Monitor: locked.Monitor}
+		}
+	}
+	return working, nil
+}
+
+func renderDrift(w io.Writer, report DriftReport) {
+	for _, e := range report.Entries {
+		switch e.Kind {
+		case driftChanged:
+			fmt.Fprintf(w, "  %s %s (id %d) was changed outside of openstatus.yaml\n", color.YellowString("~"), e.Name, e.ID)
+			for _, f := range e.Fields {
+				fmt.Fprintf(w, "      %s: %s → %s\n", f.Field, formatPlanValue(f.Old), formatPlanValue(f.New))
+			}
+		case driftDeleted:
+			fmt.Fprintf(w, "  %s %s (id %d) was deleted outside of openstatus.yaml\n", color.RedString("-"), e.Name, e.ID)
+		case driftUnmanaged:
+			fmt.Fprintf(w, "  %s %q (id %d) exists in the workspace but not in openstatus.lock\n", color.CyanString("?"), e.Name, e.ID)
+		}
+	}
+}
+
+func GetMonitorDriftCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "drift",
+		Usage: "Detect monitors changed outside of openstatus.yaml",
+		UsageText: `openstatus monitors drift
+  openstatus monitors drift --refresh-lock
+  openstatus monitors drift --overwrite-remote -y
+  openstatus monitors drift --exit-code`,
+		Description: `Compares every monitor recorded in openstatus.lock with the live workspace
+and reports monitors that were changed or deleted in the dashboard, as well
+as monitors that exist in the workspace but are not managed by the lock file.
+
+Use --refresh-lock to accept the live state into openstatus.lock, or
+--overwrite-remote to push the locked definitions back to the workspace.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "access-token",
+				Usage:   "OpenStatus API Access Token",
+				Aliases: []string{"t"},
+				Sources: cli.EnvVars("OPENSTATUS_API_TOKEN"),
+			},
+			&cli.StringFlag{
+				Name:  "env",
+				Usage: "Environment name: checks the monitors in openstatus.<env>.lock",
+			},
+			&cli.StringSliceFlag{
+				Name:  "var-file",
+				Usage: "Dotenv file with values for ${secret:NAME} references, used by --overwrite-remote (repeatable)",
+			},
+			&cli.BoolFlag{
+				Name:  "refresh-lock",
+				Usage: "Update openstatus.lock with the live state of drifted monitors",
+			},
+			&cli.BoolFlag{
+				Name:  "overwrite-remote",
+				Usage: "Restore drifted monitors in the workspace from openstatus.lock",
+			},
+			&cli.BoolFlag{
+				Name:  "exit-code",
+				Usage: "Exit with status 2 when drift is detected",
+			},
+			&cli.BoolFlag{
+				Name:    "auto-accept",
+				Usage:   "Automatically accept the prompt",
+				Aliases: []string{"y"},
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			apiKey, err := auth.ResolveAccessToken(cmd)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			if cmd.Bool("refresh-lock") && cmd.Bool("overwrite-remote") {
+				return cli.Exit("--refresh-lock and --overwrite-remote cannot be used together", 1)
+			}
+
+			lockPath := config.LockFilePath(cmd.String("env"))
+			lock, err := config.ReadLockFile(lockPath)
+			if err != nil {
+				return cli.Exit("Unable to read lock file", 1)
+			}
+
+			s := output.StartSpinner("Checking for drift...")
+			report, err := DetectDrift(ctx, NewMonitorClient(apiKey), lock)
+			output.StopSpinner(s)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+
+			if output.IsJSONOutput() {
+				if err := output.PrintJSON(report); err != nil {
+					return err
+				}
+			} else if !report.HasDrift() {
+				fmt.Println("No drift detected")
+				return nil
+			} else {
+				fmt.Println("Drift detected:")
+				fmt.Println()
+				renderDrift(os.Stdout, report)
+			}
+
+			switch {
+			case cmd.Bool("refresh-lock"):
+				if err := config.WriteLockFile(lockPath, RefreshLock(lock, report)); err != nil {
+					return cli.Exit(err.Error(), 1)
+				}
+				if !output.IsJSONOutput() {
+					fmt.Printf("\n%s refreshed from the workspace\n", lockPath)
+				}
+				return nil
+			case cmd.Bool("overwrite-remote"):
+				if !cmd.Bool("auto-accept") {
+					confirmed, err := output.AskForConfirmation(fmt.Sprintf("Overwrite the workspace with the definitions from %s?", lockPath))
+					if err != nil {
+						return cli.Exit(fmt.Sprintf("Failed to read input: %v", err), 1)
+					}
+					if !confirmed {
+						return nil
+					}
+				}
+				vars, err := config.ReadVarFiles(cmd.StringSlice("var-file")...)
+				if err != nil {
+					return cli.Exit(err.Error(), 1)
+				}
+				s := output.StartSpinner("Overwriting remote monitors...")
+				newLock, overwriteErr := overwriteRemote(ctx, apiKey, lock, report, vars)
+				output.StopSpinner(s)
+				// Write the lock even on error, to keep the IDs of the
+				// monitors recreated so far.
+				if err := config.WriteLockFile(lockPath, newLock); err != nil {
+					return cli.Exit(err.Error(), 1)
+				}
+				if overwriteErr != nil {
+					return cli.Exit(overwriteErr.Error(), 1)
+				}
+				if !output.IsJSONOutput() {
+					fmt.Printf("\nWorkspace restored from %s\n", lockPath)
+				}
+				return nil
+			}
+
+			if cmd.Bool("exit-code") && report.HasDrift() {
+				return cli.Exit("", 2)
+			}
+			return nil
+		},
+	}
+}
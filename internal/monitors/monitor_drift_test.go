@@ -0,0 +1,150 @@
+package monitors_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/openstatusHQ/cli/internal/config"
+	"github.com/openstatusHQ/cli/internal/monitors"
+)
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+	}
+}
+
+func Test_DetectDrift(t *testing.T) {
+	t.Parallel()
+
+	locked := config.Monitor{
+		Name:      "API",
+		Active:    true,
+		Frequency: config.The10M,
+		Kind:      config.HTTP,
+		Regions:   []config.Region{config.Iad},
+		Request: config.Request{
+			URL:    "https://example.com",
+			Method: config.Get,
+		},
+	}
+	lock := config.MonitorsLock{
+		"api":  {ID: 1, Monitor: locked},
+		"gone": {ID: 2, Monitor: locked},
+	}
+
+	interceptor := &interceptorHTTPClient{
+		f: func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			switch {
+			case strings.HasSuffix(req.URL.Path, "GetMonitor") && strings.Contains(string(body), `"1"`):
+				return jsonResponse(http.StatusOK, `{"monitor":{"http":{"id":"1","name":"API","url":"https://example.com","periodicity":"PERIODICITY_1M","method":"HTTP_METHOD_GET","regions":["REGION_FLY_IAD"],"active":true,"timeout":45000,"retry":3}}}`), nil
+			case strings.HasSuffix(req.URL.Path, "GetMonitor"):
+				return jsonResponse(http.StatusNotFound, `{"code":"not_found","message":"monitor not found"}`), nil
+			case strings.HasSuffix(req.URL.Path, "ListMonitors"):
+				return jsonResponse(http.StatusOK, `{"httpMonitors":[{"id":"1","name":"API"},{"id":"3","name":"Created in dashboard"}]}`), nil
+			}
+			return jsonResponse(http.StatusInternalServerError, `{"code":"internal","message":"unexpected call"}`), nil
+		},
+	}
+
+	client := monitors.NewMonitorClientWithHTTPClient(interceptor.GetHTTPClient(), "test-api-key")
+	report, err := monitors.DetectDrift(context.Background(), client, lock)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(report.Entries) != 3 {
+		t.Fatalf("Expected 3 drift entries, got %+v", report.Entries)
+	}
+
+	changed := report.Entries[0]
+	if changed.Name != "api" || changed.Kind != "changed" {
+		t.Fatalf("Unexpected first entry %+v", changed)
+	}
+	if len(changed.Fields) != 1 || changed.Fields[0].Field != "frequency" {
+		t.Errorf("Expected only frequency to drift, got %+v", changed.Fields)
+	}
+
+	if report.Entries[1].Name != "gone" || report.Entries[1].Kind != "deleted" {
+		t.Errorf("Unexpected second entry %+v", report.Entries[1])
+	}
+	if report.Entries[2].ID != 3 || report.Entries[2].Kind != "unmanaged" {
+		t.Errorf("Unexpected third entry %+v", report.Entries[2])
+	}
+
+	managed := report.Managed()
+	if len(managed.Entries) != 2 || managed.Entries[1].Kind != "deleted" {
+		t.Errorf("Expected only the changed and deleted monitors, got %+v", managed.Entries)
+	}
+
+	refreshed := monitors.RefreshLock(lock, report)
+	if _, ok := refreshed["gone"]; ok {
+		t.Error("Expected deleted monitor to be removed from the refreshed lock")
+	}
+	if refreshed["api"].Monitor.Frequency != config.The1M {
+		t.Errorf("Expected refreshed frequency 1m, got %s", refreshed["api"].Monitor.Frequency)
+	}
+	if lock["api"].Monitor.Frequency != config.The10M {
+		t.Error("RefreshLock must not modify the input lock")
+	}
+}
+
+func Test_AlignRemoteMonitor(t *testing.T) {
+	followRedirects := false
+	locked := config.Monitor{
+		Request: config.Request{URL: "https://example.com", FollowRedirects: &followRedirects},
+	}
+	remote := config.Monitor{
+		Timeout: 45000,
+		Retry:   3,
+		Request: config.Request{URL: "https://example.com", Method: config.Get},
+	}
+
+	aligned := monitors.AlignRemoteMonitor(remote, locked)
+	if aligned.Timeout != 0 || aligned.Retry != 0 || aligned.Request.Method != "" {
+		t.Errorf("Expected API defaults to be cleared, got %+v", aligned)
+	}
+	if aligned.Request.FollowRedirects != locked.Request.FollowRedirects {
+		t.Error("Expected followRedirects to be carried over from the lock")
+	}
+
+	locked.Timeout = 30000
+	aligned = monitors.AlignRemoteMonitor(remote, locked)
+	if aligned.Timeout != 45000 {
+		t.Errorf("Expected explicit timeout drift to be kept, got %d", aligned.Timeout)
+	}
+
+	locked.Request.Headers = map[string]string{"Authorization": "Bearer ${secret:API_TOKEN}"}
+	remote.Request.Headers = map[string]string{"Authorization": "Bearer s3cr3t"}
+	aligned = monitors.AlignRemoteMonitor(remote, locked)
+	if got := aligned.Request.Headers["Authorization"]; got != "Bearer ${secret:API_TOKEN}" {
+		t.Errorf("Expected secret reference to be restored, got %q", got)
+	}
+
+	locked.Assertions = []config.Assertion{
+		{Kind: config.Header, Compare: config.Eq, Key: "Content-Type", Target: "application/json"},
+		{Kind: config.TextBody, Compare: config.Contains, Target: "ok"},
+		{Kind: config.StatusCode, Compare: config.Eq, Target: 200},
+	}
+	// The API returns assertions grouped by kind.
+	remote.Assertions = []config.Assertion{locked.Assertions[2], locked.Assertions[1], locked.Assertions[0]}
+	aligned = monitors.AlignRemoteMonitor(remote, locked)
+	if diff := cmp.Diff(locked.Assertions, aligned.Assertions); diff != "" {
+		t.Errorf("Expected assertions in the locked order (-want +got):\n%s", diff)
+	}
+
+	remote.Assertions = []config.Assertion{{Kind: config.StatusCode, Compare: config.Eq, Target: 201}, locked.Assertions[0]}
+	aligned = monitors.AlignRemoteMonitor(remote, locked)
+	if aligned.Assertions[0] != locked.Assertions[0] || aligned.Assertions[1].Target != 201 {
+		t.Errorf("Expected a changed assertion to be kept after the matching ones, got %+v", aligned.Assertions)
+	}
+}
@@ -0,0 +1,123 @@
+package monitors
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"buf.build/gen/go/openstatus/api/connectrpc/gosimple/openstatus/monitor/v1/monitorv1connect"
+	monitorv1 "buf.build/gen/go/openstatus/api/protocolbuffers/go/openstatus/monitor/v1"
+
+	output "github.com/openstatusHQ/cli/internal/cli"
+)
+
+const healthConcurrency = 8
+
+// MonitorHealth is the live state of a monitor across its regions.
+type MonitorHealth struct {
+	ID      string               `json:"id"`
+	Name    string               `json:"name"`
+	Kind    string               `json:"kind"`
+	URL     string               `json:"url"`
+	Status  string               `json:"status"`
+	Regions []RegionStatusOutput `json:"regions,omitempty"`
+	// Latencies are those of the most recent checks, oldest first. Only
+	// HTTP monitors have response logs to take them from.
+	Latencies []int64 `json:"latencies_ms,omitempty"`
+	// Error is set when the status of the monitor could not be fetched.
+	Error string `json:"error,omitempty"`
+}
+
+// RecentResponseLogs returns the latest response logs of a monitor, newest
+// first.
+func RecentResponseLogs(ctx context.Context, client monitorv1connect.MonitorServiceClient, monitorId string, limit int32) ([]ResponseLogEntry, error) {
+	req := &monitorv1.ListMonitorHTTPResponseLogsRequest{Id: monitorId}
+	req.SetLimit(limit)
+	resp, err := client.ListMonitorHTTPResponseLogs(ctx, req)
+	if err != nil {
+		return nil, output.FormatError(err, "response logs", monitorId)
+	}
+	logs := slices.Clone(resp.GetLogs())
+	slices.SortStableFunc(logs, func(a, b *monitorv1.HTTPResponseLog) int {
+		return cmp.Compare(b.GetTimestamp(), a.GetTimestamp())
+	})
+	entries := make([]ResponseLogEntry, len(logs))
+	for i, l := range logs {
+		entries[i] = toResponseLogEntry(l)
+	}
+	return entries, nil
+}
+
+// WorkspaceHealth returns the status of every active monitor of the
+// workspace, ordered by name, with the latencies of the last samples checks
+// of HTTP monitors.
+func WorkspaceHealth(ctx context.Context, client monitorv1connect.MonitorServiceClient, samples int32) ([]MonitorHealth, error) {
+	resp, err := client.ListMonitors(ctx, &monitorv1.ListMonitorsRequest{})
+	if err != nil {
+		return nil, output.FormatError(err, "monitors", "")
+	}
+
+	var health []MonitorHealth
+	for _, m := range resp.GetHttpMonitors() {
+		if m.GetActive() {
+			health = append(health, MonitorHealth{ID: m.GetId(), Name: m.GetName(), Kind: "http", URL: m.GetUrl()})
+		}
+	}
+	for _, m := range resp.GetTcpMonitors() {
+		if m.GetActive() {
+			health = append(health, MonitorHealth{ID: m.GetId(), Name: m.GetName(), Kind: "tcp", URL: m.GetUri()})
+		}
+	}
+	for _, m := range resp.GetDnsMonitors() {
+		if m.GetActive() {
+			health = append(health, MonitorHealth{ID: m.GetId(), Name: m.GetName(), Kind: "dns", URL: m.GetUri()})
+		}
+	}
+
+	sem := make(chan struct{}, healthConcurrency)
+	var wg sync.WaitGroup
+	for i := range health {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			h := &health[i]
+
+			status, err := client.GetMonitorStatus(ctx, &monitorv1.GetMonitorStatusRequest{Id: h.ID})
+			if err != nil {
+				h.Status = "unknown"
+				h.Error = output.FormatError(err, "monitor", h.ID).Error()
+				return
+			}
+			h.Status = deriveGlobalStatus(status.GetRegions())
+			for _, rs := range status.GetRegions() {
+				h.Regions = append(h.Regions, RegionStatusOutput{
+					Region:   regionToString(rs.GetRegion()),
+					Provider: regionProvider(rs.GetRegion()),
+					Status:   monitorStatusToString(rs.GetStatus()),
+				})
+			}
+
+			if h.Kind != "http" || samples <= 0 {
+				return
+			}
+			// Latencies are best effort: the status is shown without them.
+			logs, err := RecentResponseLogs(ctx, client, h.ID, samples)
+			if err != nil {
+				return
+			}
+			for _, l := range slices.Backward(logs) {
+				h.Latencies = append(h.Latencies, int64(l.Latency))
+			}
+		}()
+	}
+	wg.Wait()
+
+	slices.SortFunc(health, func(a, b MonitorHealth) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return health, nil
+}
@@ -1,12 +1,17 @@
 package monitors
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"buf.build/gen/go/openstatus/api/connectrpc/gosimple/openstatus/monitor/v1/monitorv1connect"
 	monitorv1 "buf.build/gen/go/openstatus/api/protocolbuffers/go/openstatus/monitor/v1"
@@ -18,8 +23,33 @@ import (
 	"github.com/openstatusHQ/cli/internal/config"
 )
 
+const (
+	splitByKind   = "kind"
+	splitByTeam   = "team"
+	splitByRegion = "region"
+)
+
+// ExportOptions controls how ExportMonitorWithOptions lays out the config.
+type ExportOptions struct {
+	// SplitBy writes one file per kind, team or region into Dir, and makes
+	// the main file include that directory.
+	SplitBy string
+	Dir     string
+	// FactorDefaults moves the values shared by every monitor of a file into
+	// its defaults block.
+	FactorDefaults bool
+	// Env selects the lock file, as with apply --env.
+	Env string
+}
+
 // ExportMonitor exports all monitors to a YAML file using the SDK
 func ExportMonitor(ctx context.Context, client monitorv1connect.MonitorServiceClient, path string) error {
+	return ExportMonitorWithOptions(ctx, client, path, ExportOptions{})
+}
+
+// ExportMonitorWithOptions exports all monitors like ExportMonitor, laid out
+// according to opts. Teams are kept from the existing lock file of opts.Env.
+func ExportMonitorWithOptions(ctx context.Context, client monitorv1connect.MonitorServiceClient, path string, opts ExportOptions) error {
 	resp, err := client.ListMonitors(ctx, &monitorv1.ListMonitorsRequest{})
 	if err != nil {
 		return fmt.Errorf("failed to list monitors: %w", err)
@@ -35,26 +65,135 @@ func ExportMonitor(ctx context.Context, client monitorv1connect.MonitorServiceCl
 		t[monitor.GetId()] = convertTCPMonitorToConfig(monitor)
 	}
 
-	configYAML, err := yaml.Marshal(&t)
+	for _, monitor := range resp.GetDnsMonitors() {
+		t[monitor.GetId()] = convertDNSMonitorToConfig(monitor)
+	}
+
+	lockPath := config.LockFilePath(opts.Env)
+	previous, err := config.ReadLockFile(lockPath)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to read lock file: %w", err)
+	}
+	teams := make(map[int]string, len(previous))
+	for _, l := range previous {
+		teams[l.ID] = l.Monitor.Team
 	}
 
-	lock := make(map[string]config.Lock, len(t))
+	lock := make(config.MonitorsLock, len(t))
 	for id, monitor := range t {
 		i, err := strconv.Atoi(id)
 		if err != nil {
 			return fmt.Errorf("invalid monitor ID %q: %w", id, err)
 		}
+		monitor.Team = teams[i]
+		t[id] = monitor
 		lock[id] = config.Lock{
 			ID:      i,
 			Monitor: monitor,
 		}
 	}
 
-	lockYAML, err := yaml.Marshal(&lock)
+	if opts.SplitBy == "" {
+		if err := writeMonitorsFile(path, t, opts.FactorDefaults); err != nil {
+			return err
+		}
+	} else {
+		groups, err := groupMonitors(t, opts.SplitBy)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", opts.Dir, err)
+		}
+		for group, monitors := range groups {
+			if err := writeMonitorsFile(filepath.Join(opts.Dir, group+".yaml"), monitors, opts.FactorDefaults); err != nil {
+				return err
+			}
+		}
+		removed, err := removeStaleGroupFiles(opts.Dir, groups)
+		if err != nil {
+			return err
+		}
+		for _, path := range removed {
+			fmt.Fprintf(os.Stderr, "Removed %s: no monitor is left in this group\n", path)
+		}
+		include, err := filepath.Rel(filepath.Dir(path), opts.Dir)
+		if err != nil {
+			include = opts.Dir
+		}
+		if err := writeConfigFile(path, map[string][]string{"include": {filepath.ToSlash(include)}}); err != nil {
+			return err
+		}
+	}
+
+	return config.WriteLockFile(lockPath, lock)
+}
+
+// removeStaleGroupFiles removes the files of dir written by a previous import
+// for groups that no longer have monitors, which would otherwise still be
+// included. Files not starting with config.ConfigHeader are left alone.
+func removeStaleGroupFiles(dir string, groups map[string]map[string]config.Monitor) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
 	if err != nil {
-		return fmt.Errorf("failed to marshal lock file: %w", err)
+		return nil, err
+	}
+	var removed []string
+	for _, path := range paths {
+		if _, ok := groups[strings.TrimSuffix(filepath.Base(path), ".yaml")]; ok {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return removed, err
+		}
+		if !bytes.HasPrefix(content, []byte(config.ConfigHeader)) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, fmt.Errorf("failed to remove stale file %s: %w", path, err)
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
+
+// writeMonitorsFile writes monitors to path, optionally factoring the values
+// they all share into a defaults block.
+func writeMonitorsFile(path string, monitors map[string]config.Monitor, factorDefaults bool) error {
+	if !factorDefaults {
+		return writeConfigFile(path, monitors)
+	}
+
+	docs := make(map[string]map[string]any, len(monitors))
+	for name, m := range monitors {
+		b, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+		var doc map[string]any
+		if err := json.Unmarshal(b, &doc); err != nil {
+			return err
+		}
+		docs[name] = doc
+	}
+	defaults, stripped := config.ExtractDefaults(docs, "name")
+	if defaults == nil {
+		return writeConfigFile(path, monitors)
+	}
+	return writeConfigFile(path, map[string]any{"defaults": defaults}, stripped)
+}
+
+func writeConfigFile(path string, docs ...any) error {
+	var configYAML []byte
+	for i, v := range docs {
+		b, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			configYAML = append(configYAML, '\n')
+		}
+		configYAML = append(configYAML, b...)
 	}
 
 	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
@@ -63,24 +202,63 @@ func ExportMonitor(ctx context.Context, client monitorv1connect.MonitorServiceCl
 	}
 	defer file.Close()
 
-	if _, err := file.WriteString("# yaml-language-server: $schema=https://www.openstatus.dev/schema.json\n\n"); err != nil {
+	if _, err := file.WriteString(config.ConfigHeader); err != nil {
 		return err
 	}
 	if _, err := file.Write(configYAML); err != nil {
 		return err
 	}
+	return nil
+}
 
-	lockFile, err := os.OpenFile("openstatus.lock", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
-	if err != nil {
-		return fmt.Errorf("failed to create lock file: %w", err)
+func validateSplitBy(splitBy string) error {
+	switch splitBy {
+	case splitByKind, splitByTeam, splitByRegion:
+		return nil
 	}
-	defer lockFile.Close()
+	return fmt.Errorf("invalid --split-by value %q: must be one of %s, %s, %s", splitBy, splitByKind, splitByTeam, splitByRegion)
+}
 
-	if _, err := lockFile.Write(lockYAML); err != nil {
-		return fmt.Errorf("failed to write lock file: %w", err)
-	}
+var groupNameReplacer = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
 
-	return nil
+// groupMonitors splits monitors into named groups. Monitors running in more
+// than one region are grouped under "multi-region", and monitors without a
+// team under "unassigned".
+func groupMonitors(monitors map[string]config.Monitor, splitBy string) (map[string]map[string]config.Monitor, error) {
+	if err := validateSplitBy(splitBy); err != nil {
+		return nil, err
+	}
+	groups := map[string]map[string]config.Monitor{}
+	for name, m := range monitors {
+		var group string
+		switch splitBy {
+		case splitByKind:
+			group = string(m.Kind)
+		case splitByTeam:
+			group = m.Team
+			if group == "" {
+				group = "unassigned"
+			}
+		case splitByRegion:
+			switch len(m.Regions) {
+			case 0:
+				group = "no-region"
+			case 1:
+				group = string(m.Regions[0])
+			default:
+				group = "multi-region"
+			}
+		}
+		group = strings.Trim(groupNameReplacer.ReplaceAllString(group, "-"), "-")
+		if group == "" {
+			group = "other"
+		}
+		if groups[group] == nil {
+			groups[group] = map[string]config.Monitor{}
+		}
+		groups[group][name] = m
+	}
+	return groups, nil
 }
 
 // convertHTTPMonitorToConfig converts an SDK HTTPMonitor to config.Monitor
@@ -179,6 +357,41 @@ func convertTCPMonitorToConfig(m *monitorv1.TCPMonitor) config.Monitor {
 	}
 }
 
+// convertDNSMonitorToConfig converts an SDK DNSMonitor to config.Monitor
+func convertDNSMonitorToConfig(m *monitorv1.DNSMonitor) config.Monitor {
+	regions := make([]config.Region, len(m.GetRegions()))
+	for i, r := range m.GetRegions() {
+		regions[i] = config.Region(regionToString(r))
+	}
+
+	var assertions []config.Assertion
+	for _, a := range m.GetRecordAssertions() {
+		assertions = append(assertions, config.Assertion{
+			Kind:    config.DNSRecord,
+			Record:  config.RecordType(a.GetRecord()),
+			Target:  a.GetTarget(),
+			Compare: convertRecordComparator(a.GetComparator()),
+		})
+	}
+
+	return config.Monitor{
+		Name:          m.GetName(),
+		Description:   m.GetDescription(),
+		Active:        m.GetActive(),
+		Public:        m.GetPublic(),
+		Frequency:     convertPeriodicity(m.GetPeriodicity()),
+		DegradedAfter: m.GetDegradedAt(),
+		Timeout:       m.GetTimeout(),
+		Retry:         m.GetRetry(),
+		Kind:          config.DNS,
+		Regions:       regions,
+		Assertions:    assertions,
+		Request: config.Request{
+			Host: m.GetUri(),
+		},
+	}
+}
+
 // convertPeriodicity converts SDK Periodicity enum to config.Frequency string
 func convertPeriodicity(p monitorv1.Periodicity) config.Frequency {
 	switch p {
@@ -269,6 +482,22 @@ func convertStringComparator(c monitorv1.StringComparator) config.Compare {
 	}
 }
 
+// convertRecordComparator converts SDK RecordComparator to config.Compare
+func convertRecordComparator(c monitorv1.RecordComparator) config.Compare {
+	switch c {
+	case monitorv1.RecordComparator_RECORD_COMPARATOR_EQUAL:
+		return config.Eq
+	case monitorv1.RecordComparator_RECORD_COMPARATOR_NOT_EQUAL:
+		return config.NotEq
+	case monitorv1.RecordComparator_RECORD_COMPARATOR_CONTAINS:
+		return config.Contains
+	case monitorv1.RecordComparator_RECORD_COMPARATOR_NOT_CONTAINS:
+		return config.NotContains
+	default:
+		return config.Eq
+	}
+}
+
 // ExportMonitorWithHTTPClient is a convenience function that creates a client and exports monitors
 func ExportMonitorWithHTTPClient(ctx context.Context, httpClient *http.Client, apiKey string, path string) error {
 	client := NewMonitorClientWithHTTPClient(httpClient, apiKey)
@@ -280,22 +509,56 @@ func GetMonitorImportCmd() *cli.Command {
 		Name:  "import",
 		Usage: "Import all your monitors",
 		UsageText: `openstatus monitors import
-  openstatus monitors import --output monitors.yaml`,
-		Description: "Import all your monitors from your workspace to a YAML file; it will also create a lock file to manage your monitors with 'apply'.",
+  openstatus monitors import --output monitors.yaml
+  openstatus monitors import --split-by team --output-dir monitors
+  openstatus monitors import --factor-defaults
+  openstatus monitors import --env prod --output openstatus.prod.yaml`,
+		Description: `Import all your monitors from your workspace to a YAML file; it will also create a lock file to manage your monitors with 'apply'.
+
+With --split-by kind, team or region, monitors are written to one file per
+group in --output-dir, and the --output file includes that directory. Teams
+are a CLI-only field: set team on your monitors and re-import to regroup them.
+Files of --output-dir written by a previous import whose group no longer has
+monitors are removed.
+
+With --env, the lock file is openstatus.<env>.lock, as with 'apply --env',
+and teams are kept from it.
+
+With --factor-defaults, the values shared by every monitor of a file are
+written once in a defaults block instead of being repeated.`,
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			apiKey, err := auth.ResolveAccessToken(cmd)
 			if err != nil {
 				return cli.Exit(err.Error(), 1)
 			}
+			splitBy := cmd.String("split-by")
+			if splitBy != "" {
+				if err := validateSplitBy(splitBy); err != nil {
+					return cli.Exit(err.Error(), 1)
+				}
+			}
 			s := output.StartSpinner("Importing monitors...")
 			client := NewMonitorClient(apiKey)
-			err = ExportMonitor(ctx, client, cmd.String("output"))
+			err = ExportMonitorWithOptions(ctx, client, cmd.String("output"), ExportOptions{
+				SplitBy:        splitBy,
+				Dir:            cmd.String("output-dir"),
+				FactorDefaults: cmd.Bool("factor-defaults"),
+				Env:            cmd.String("env"),
+			})
 			output.StopSpinner(s)
 			if err != nil {
 				return cli.Exit(err.Error(), 1)
 			}
-			fmt.Printf("Monitors successfully imported to: %s\n", cmd.String("output"))
-			fmt.Println("Run 'openstatus monitors apply' to sync changes")
+			if splitBy != "" {
+				fmt.Printf("Monitors successfully imported to: %s (included from %s)\n", cmd.String("output-dir"), cmd.String("output"))
+			} else {
+				fmt.Printf("Monitors successfully imported to: %s\n", cmd.String("output"))
+			}
+			if env := cmd.String("env"); env != "" {
+				fmt.Printf("Run 'openstatus monitors apply --env %s' to sync changes\n", env)
+			} else {
+				fmt.Println("Run 'openstatus monitors apply' to sync changes")
+			}
 			return nil
 		},
 		Flags: []cli.Flag{
@@ -305,6 +568,10 @@ func GetMonitorImportCmd() *cli.Command {
 				Aliases: []string{"t"},
 				Sources: cli.EnvVars("OPENSTATUS_API_TOKEN"),
 			},
+			&cli.StringFlag{
+				Name:  "env",
+				Usage: "Environment name: reads and writes openstatus.<env>.lock",
+			},
 			&cli.StringFlag{
 				Name:        "output",
 				Usage:       "The output file name ",
@@ -312,6 +579,19 @@ func GetMonitorImportCmd() *cli.Command {
 				Value:       "openstatus.yaml",
 				Aliases:     []string{"o"},
 			},
+			&cli.StringFlag{
+				Name:  "split-by",
+				Usage: "Write one file per group: kind, team or region",
+			},
+			&cli.StringFlag{
+				Name:  "output-dir",
+				Usage: "Directory for the files written by --split-by",
+				Value: "monitors",
+			},
+			&cli.BoolFlag{
+				Name:  "factor-defaults",
+				Usage: "Write the values shared by all monitors of a file once, in a defaults block",
+			},
 		},
 	}
 	return &monitorImportCmd
@@ -6,8 +6,13 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/openstatusHQ/cli/internal/config"
 	"github.com/openstatusHQ/cli/internal/monitors"
 )
 
@@ -87,6 +92,82 @@ func Test_ExportMonitor(t *testing.T) {
 		}
 	})
 
+	t.Run("Export DNS monitors successfully", func(t *testing.T) {
+		body := `{"dnsMonitors":[{"id":"789","name":"DNS Monitor","uri":"example.com","periodicity":"PERIODICITY_10M","active":true,"recordAssertions":[{"record":"A","comparator":"RECORD_COMPARATOR_EQUAL","target":"93.184.216.34"}]}]}`
+		r := io.NopCloser(bytes.NewReader([]byte(body)))
+
+		interceptor := &interceptorHTTPClient{
+			f: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       r,
+					Header: http.Header{
+						"Content-Type": []string{"application/json"},
+					},
+				}, nil
+			},
+		}
+
+		outputFile, err := os.CreateTemp(".", "export_dns*.yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(outputFile.Name())
+		defer os.Remove("openstatus.lock")
+		outputFile.Close()
+
+		err = monitors.ExportMonitorWithHTTPClient(context.Background(), interceptor.GetHTTPClient(), "test-api-key", outputFile.Name())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		content, err := os.ReadFile(outputFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{"kind: dns", "host: example.com", "kind: dnsRecord", "record: A"} {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("Expected output to contain %q, got:\n%s", want, content)
+			}
+		}
+	})
+
+	t.Run("Export for an environment uses its lock file", func(t *testing.T) {
+		body := `{"httpMonitors":[{"id":"123","name":"HTTP Monitor","url":"https://example.com","periodicity":"PERIODICITY_10M","active":true}]}`
+		interceptor := &interceptorHTTPClient{
+			f: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(body)),
+					Header: http.Header{
+						"Content-Type": []string{"application/json"},
+					},
+				}, nil
+			},
+		}
+		if err := config.WriteLockFile("openstatus.staging.lock", config.MonitorsLock{"123": {ID: 123, Monitor: config.Monitor{Team: "payments"}}}); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove("openstatus.staging.lock")
+		outputFile := filepath.Join(t.TempDir(), "openstatus.staging.yaml")
+
+		client := monitors.NewMonitorClientWithHTTPClient(interceptor.GetHTTPClient(), "test-api-key")
+		if err := monitors.ExportMonitorWithOptions(context.Background(), client, outputFile, monitors.ExportOptions{Env: "staging"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := os.Stat("openstatus.lock"); !os.IsNotExist(err) {
+			os.Remove("openstatus.lock")
+			t.Error("Expected the default lock file not to be written")
+		}
+		lock, err := config.ReadLockFile("openstatus.staging.lock")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lock["123"].Monitor.Team != "payments" {
+			t.Errorf("Expected the team to be kept from the environment lock, got %+v", lock["123"])
+		}
+	})
+
 	t.Run("Export fails with error status", func(t *testing.T) {
 		body := `{"code":"permission_denied","message":"unauthorized"}`
 		r := io.NopCloser(bytes.NewReader([]byte(body)))
@@ -139,3 +220,125 @@ func Test_ExportMonitor(t *testing.T) {
 		}
 	})
 }
+
+func Test_GroupMonitors(t *testing.T) {
+	t.Parallel()
+
+	all := map[string]config.Monitor{
+		"1": {Name: "API", Kind: config.HTTP, Team: "payments", Regions: []config.Region{config.Iad}},
+		"2": {Name: "DB", Kind: config.TCP, Team: "Platform Team", Regions: []config.Region{config.Iad, config.Fra}},
+		"3": {Name: "DNS", Kind: config.DNS, Regions: []config.Region{config.Fra}},
+	}
+
+	tests := []struct {
+		splitBy string
+		want    map[string][]string
+	}{
+		{"kind", map[string][]string{"http": {"1"}, "tcp": {"2"}, "dns": {"3"}}},
+		{"team", map[string][]string{"payments": {"1"}, "Platform-Team": {"2"}, "unassigned": {"3"}}},
+		{"region", map[string][]string{"iad": {"1"}, "multi-region": {"2"}, "fra": {"3"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.splitBy, func(t *testing.T) {
+			groups, err := monitors.GroupMonitors(all, tt.splitBy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(groups) != len(tt.want) {
+				t.Fatalf("Expected %d groups, got %v", len(tt.want), groups)
+			}
+			for group, ids := range tt.want {
+				for _, id := range ids {
+					if _, ok := groups[group][id]; !ok {
+						t.Errorf("Expected monitor %s in group %q, got %v", id, group, groups)
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("Invalid split returns error", func(t *testing.T) {
+		if _, err := monitors.GroupMonitors(all, "owner"); err == nil {
+			t.Error("Expected error for invalid split, got nil")
+		}
+	})
+}
+
+func Test_RemoveStaleGroupFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	groups := map[string]map[string]config.Monitor{"http": {"api": {Name: "API"}}}
+	if err := monitors.WriteMonitorsFile(filepath.Join(dir, "http.yaml"), groups["http"], false); err != nil {
+		t.Fatal(err)
+	}
+	if err := monitors.WriteMonitorsFile(filepath.Join(dir, "tcp.yaml"), map[string]config.Monitor{"db": {Name: "DB"}}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "custom.yaml"), []byte("extra:\n  name: Extra\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := monitors.RemoveStaleGroupFiles(dir, groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{filepath.Join(dir, "tcp.yaml")}, removed); diff != "" {
+		t.Errorf("Unexpected removed files (-want +got):\n%s", diff)
+	}
+	for _, name := range []string{"http.yaml", "custom.yaml"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Expected %s to be kept: %v", name, err)
+		}
+	}
+}
+
+func Test_WriteMonitorsFile_FactorDefaults(t *testing.T) {
+	t.Parallel()
+
+	base := config.Monitor{
+		Active:    true,
+		Frequency: config.The10M,
+		Kind:      config.HTTP,
+		Regions:   []config.Region{config.Iad, config.Ams},
+		Retry:     3,
+		Request: config.Request{
+			Method:  config.Get,
+			Headers: map[string]string{"User-Agent": "OpenStatus"},
+		},
+		Assertions: []config.Assertion{
+			{Kind: config.StatusCode, Compare: config.Eq, Target: 200},
+		},
+	}
+	a := base
+	a.Name = "A"
+	a.Request.URL = "https://a.example.com"
+	b := base
+	b.Name = "B"
+	b.Request.URL = "https://b.example.com"
+	all := map[string]config.Monitor{"1": a, "2": b}
+
+	path := filepath.Join(t.TempDir(), "openstatus.yaml")
+	if err := monitors.WriteMonitorsFile(path, all, true); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "defaults:") {
+		t.Errorf("Expected a defaults block, got:\n%s", content)
+	}
+	if strings.Count(string(content), "frequency:") != 1 {
+		t.Errorf("Expected frequency to be written once, got:\n%s", content)
+	}
+
+	out, err := config.ReadOpenStatus(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(config.Monitors(all), out); diff != "" {
+		t.Errorf("Expected factored file to expand to the original monitors (-want +got):\n%s", diff)
+	}
+}
@@ -26,6 +26,7 @@ type MonitorInfoOutput struct {
 	Status  string               `json:"status,omitempty"`
 	Regions []RegionStatusOutput `json:"regions,omitempty"`
 	Summary *SummaryOutput       `json:"summary,omitempty"`
+	Charts  *ChartsOutput        `json:"charts,omitempty"`
 }
 
 type RegionStatusOutput struct {
@@ -167,7 +168,7 @@ func newBlueprintTable() *tablewriter.Table {
 	)
 }
 
-func GetMonitorInfo(ctx context.Context, httpClient *http.Client, apiKey string, monitorId string, timeRange monitorv1.TimeRange, timeRangeStr string, s *output.Spinner) error {
+func GetMonitorInfo(ctx context.Context, httpClient *http.Client, apiKey string, monitorId string, timeRange monitorv1.TimeRange, timeRangeStr string, charts bool, s *output.Spinner) error {
 	if monitorId == "" {
 		output.StopSpinner(s)
 		fmt.Fprintln(os.Stderr, "Usage: openstatus monitors info <monitor-id>")
@@ -204,20 +205,36 @@ func GetMonitorInfo(ctx context.Context, httpClient *http.Client, apiKey string,
 			return err
 		}
 		regions = monitorConfig.GetTcp().GetRegions()
-	default:
-		if monitorConfig.HasDns() {
-			return fmt.Errorf("DNS monitors are not yet supported in the CLI. Monitor ID: %s", monitorId)
+	case monitorConfig.HasDns():
+		monitor, err = dnsMonitorToLocal(monitorConfig.GetDns())
+		if err != nil {
+			return err
 		}
+		regions = monitorConfig.GetDns().GetRegions()
+	default:
 		return fmt.Errorf("unknown monitor type for monitor ID: %s", monitorId)
 	}
 
 	var (
 		statusResp  *monitorv1.GetMonitorStatusResponse
 		summaryResp *monitorv1.GetMonitorSummaryResponse
+		chartsOut   *ChartsOutput
 		statusErr   error
 		summaryErr  error
+		chartsErr   error
 		wg          sync.WaitGroup
 	)
+	if charts && !monitorConfig.HasHttp() {
+		fmt.Fprintln(os.Stderr, "Warning: charts are only available for HTTP monitors, which have response logs")
+		charts = false
+	}
+	if charts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			chartsOut, chartsErr = fetchCharts(ctx, client, monitorId, timeRangeStr)
+		}()
+	}
 	wg.Add(2)
 	go func() {
 		defer wg.Done()
@@ -240,6 +257,10 @@ func GetMonitorInfo(ctx context.Context, httpClient *http.Client, apiKey string,
 		fmt.Fprintln(os.Stderr, "Warning: could not fetch monitor summary:", summaryErr)
 		summaryResp = nil
 	}
+	if chartsErr != nil {
+		fmt.Fprintln(os.Stderr, "Warning: could not fetch response logs for charts:", chartsErr)
+		chartsOut = nil
+	}
 
 	var globalStatus string
 	if statusResp != nil {
@@ -274,6 +295,7 @@ func GetMonitorInfo(ctx context.Context, httpClient *http.Client, apiKey string,
 				LastPingAt:      summaryResp.GetLastPingAt(),
 			}
 		}
+		infoOutput.Charts = chartsOut
 		return output.PrintJSON(infoOutput)
 	}
 
@@ -358,7 +380,7 @@ func GetMonitorInfo(ctx context.Context, httpClient *http.Client, apiKey string,
 		regionTable.Render()
 	}
 
-	// Section 3: Summary table
+	// Section 4: Summary table
 	if summaryResp != nil {
 		fmt.Println()
 		fmt.Println(aurora.Bold(fmt.Sprintf("Summary (%s):", timeRangeStr)))
@@ -380,6 +402,12 @@ func GetMonitorInfo(ctx context.Context, httpClient *http.Client, apiKey string,
 		summaryTable.Render()
 	}
 
+	// Section 5: Charts
+	if chartsOut != nil {
+		fmt.Println()
+		writeCharts(os.Stdout, chartsOut, color.NoColor)
+	}
+
 	return nil
 }
 
@@ -387,23 +415,34 @@ func GetMonitorInfoCmd() *cli.Command {
 	monitorInfoCmd := cli.Command{
 		Name:  "info",
 		Usage: "Get a monitor information",
-		UsageText: `openstatus monitors info <MonitorID>
+		UsageText: `openstatus monitors info <MonitorID|name>
   openstatus monitors info 12345
-  openstatus monitors info 12345 --time-range 7d`,
-		Description: "Fetch the monitor information including configuration, live status per region, and summary metrics.",
+  openstatus monitors info 12345 --time-range 7d
+  openstatus monitors info 12345 --time-range 7d --charts
+  openstatus monitors info "API Production"`,
+		Description: `Fetch the monitor information including configuration, live status per region, and summary metrics.
+
+With --charts, the response logs of the time range are fetched to chart the
+p95 latency trend, the checks per hour (1d) or per day (7d, 14d) stacked by
+success, degraded and error, and the latency of every region. The buckets
+are included in --json output. Fetching a week or two of logs can take a
+while for frequent monitors.`,
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			apiKey, err := auth.ResolveAccessToken(cmd)
 			if err != nil {
 				return cli.Exit(err.Error(), 1)
 			}
-			monitorId := cmd.Args().Get(0)
+			monitorId, err := ResolveMonitorID(ctx, NewMonitorClientWithHTTPClient(api.DefaultHTTPClient, apiKey), cmd.Args().Get(0), cmd.String("env"))
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
 			timeRangeStr := cmd.String("time-range")
 			timeRange, err := parseTimeRange(timeRangeStr)
 			if err != nil {
 				return cli.Exit(err.Error(), 1)
 			}
 			s := output.StartSpinner("Fetching monitor details...")
-			err = GetMonitorInfo(ctx, api.DefaultHTTPClient, apiKey, monitorId, timeRange, timeRangeStr, s)
+			err = GetMonitorInfo(ctx, api.DefaultHTTPClient, apiKey, monitorId, timeRange, timeRangeStr, cmd.Bool("charts"), s)
 			if err != nil {
 				return cli.Exit(err.Error(), 1)
 			}
@@ -416,11 +455,16 @@ func GetMonitorInfoCmd() *cli.Command {
 				Aliases: []string{"t"},
 				Sources: cli.EnvVars("OPENSTATUS_API_TOKEN"),
 			},
+			ResolveEnvFlag(),
 			&cli.StringFlag{
 				Name:  "time-range",
 				Usage: "Time range for summary metrics (1d, 7d, 14d)",
 				Value: "1d",
 			},
+			&cli.BoolFlag{
+				Name:  "charts",
+				Usage: "Chart latency and check results over the time range from the response logs (HTTP monitors)",
+			},
 		},
 	}
 	return &monitorInfoCmd
@@ -0,0 +1,272 @@
+package monitors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"buf.build/gen/go/openstatus/api/connectrpc/gosimple/openstatus/monitor/v1/monitorv1connect"
+	monitorv1 "buf.build/gen/go/openstatus/api/protocolbuffers/go/openstatus/monitor/v1"
+	"github.com/fatih/color"
+	"github.com/logrusorgru/aurora/v4"
+
+	output "github.com/openstatusHQ/cli/internal/cli"
+)
+
+// chartWidth is the width in cells of the bars drawn by monitors info.
+const chartWidth = 40
+
+// ChartBucket aggregates the checks of one hour or one day.
+type ChartBucket struct {
+	Start    string `json:"start"`
+	Total    int    `json:"total"`
+	Success  int    `json:"success"`
+	Degraded int    `json:"degraded"`
+	Error    int    `json:"error"`
+	P50      int64  `json:"p50_ms"`
+	P95      int64  `json:"p95_ms"`
+	Max      int64  `json:"max_ms"`
+}
+
+type RegionLatencyOutput struct {
+	Region string `json:"region"`
+	Total  int    `json:"total"`
+	P50    int64  `json:"p50_ms"`
+	P95    int64  `json:"p95_ms"`
+}
+
+// ChartsOutput holds the series drawn by monitors info --charts.
+type ChartsOutput struct {
+	Bucket  string                `json:"bucket"`
+	From    string                `json:"from"`
+	To      string                `json:"to"`
+	Buckets []ChartBucket         `json:"buckets"`
+	Regions []RegionLatencyOutput `json:"regions"`
+}
+
+// chartBucketSize buckets a day per hour and longer ranges per day.
+func chartBucketSize(timeRange time.Duration) time.Duration {
+	if timeRange <= 24*time.Hour {
+		return time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// percentile returns the nearest-rank percentile p of sorted latencies.
+func percentile(sorted []int64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
+
+// buildCharts buckets response logs between from and to. Empty buckets are
+// kept so that the series have one point per hour or day.
+func buildCharts(logs []*monitorv1.HTTPResponseLog, from, to time.Time, size time.Duration) *ChartsOutput {
+	start := from.UTC().Truncate(size)
+	n := int(to.Sub(start)/size) + 1
+	buckets := make([]ChartBucket, n)
+	bucketLatencies := make([][]int64, n)
+	for i := range buckets {
+		buckets[i].Start = start.Add(time.Duration(i) * size).Format(time.RFC3339)
+	}
+	regionLatencies := map[string][]int64{}
+
+	for _, l := range logs {
+		at := time.UnixMilli(l.GetTimestamp())
+		if at.Before(from) || at.After(to) {
+			continue
+		}
+		i := int(at.Sub(start) / size)
+		b := &buckets[i]
+		b.Total++
+		switch requestStatusToString(l.GetRequestStatus()) {
+		case "success":
+			b.Success++
+		case "degraded":
+			b.Degraded++
+		case "error":
+			b.Error++
+		}
+		latency := int64(l.GetLatency())
+		bucketLatencies[i] = append(bucketLatencies[i], latency)
+		region := regionToString(l.GetRegion())
+		regionLatencies[region] = append(regionLatencies[region], latency)
+	}
+
+	for i, latencies := range bucketLatencies {
+		if len(latencies) == 0 {
+			continue
+		}
+		slices.Sort(latencies)
+		buckets[i].P50 = percentile(latencies, 50)
+		buckets[i].P95 = percentile(latencies, 95)
+		buckets[i].Max = latencies[len(latencies)-1]
+	}
+
+	regions := make([]RegionLatencyOutput, 0, len(regionLatencies))
+	for region, latencies := range regionLatencies {
+		slices.Sort(latencies)
+		regions = append(regions, RegionLatencyOutput{
+			Region: region,
+			Total:  len(latencies),
+			P50:    percentile(latencies, 50),
+			P95:    percentile(latencies, 95),
+		})
+	}
+	slices.SortFunc(regions, func(a, b RegionLatencyOutput) int { return strings.Compare(a.Region, b.Region) })
+
+	bucket := "1d"
+	if size == time.Hour {
+		bucket = "1h"
+	}
+	return &ChartsOutput{
+		Bucket:  bucket,
+		From:    from.UTC().Format(time.RFC3339),
+		To:      to.UTC().Format(time.RFC3339),
+		Buckets: buckets,
+		Regions: regions,
+	}
+}
+
+// fetchCharts buckets the response logs of the time range of monitors info,
+// which ends now.
+func fetchCharts(ctx context.Context, client monitorv1connect.MonitorServiceClient, monitorId string, timeRange string) (*ChartsOutput, error) {
+	d, err := parseSince(timeRange)
+	if err != nil {
+		return nil, err
+	}
+	to := time.Now()
+	from := to.Add(-d)
+	logs, err := fetchAllResponseLogs(ctx, client, monitorId, from.UnixMilli(), to.UnixMilli(), defaultExportConcurrency)
+	if err != nil {
+		return nil, err
+	}
+	return buildCharts(logs, from, to, chartBucketSize(d)), nil
+}
+
+// stackedCells splits width cells between counts in proportion, giving at
+// least one cell to every non-zero count so that rare errors stay visible.
+func stackedCells(counts []int, width int) []int {
+	cells := make([]int, len(counts))
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	if total == 0 {
+		return cells
+	}
+	used, largest := 0, 0
+	for i, c := range counts {
+		if c > 0 {
+			cells[i] = max(1, c*width/total)
+		}
+		used += cells[i]
+		if cells[i] > cells[largest] {
+			largest = i
+		}
+	}
+	cells[largest] += width - used
+	return cells
+}
+
+// writeCharts draws the latency trend, the checks of every bucket stacked
+// by status and the latency of every region. Without colors, the statuses
+// are told apart by their characters and only ASCII is used.
+func writeCharts(w io.Writer, c *ChartsOutput, plain bool) {
+	per, label := "hour", "01-02 15:04"
+	if c.Bucket == "1d" {
+		per, label = "day", "2006-01-02"
+	}
+	labelWidth := len(label)
+
+	heading := func(s string) {
+		if plain {
+			fmt.Fprintln(w, s)
+			return
+		}
+		fmt.Fprintln(w, aurora.Bold(s))
+	}
+
+	// Hours or days without checks have no latency to draw.
+	var p95s []int64
+	for _, b := range c.Buckets {
+		if b.Total > 0 {
+			p95s = append(p95s, b.P95)
+		}
+	}
+	if len(p95s) == 0 {
+		heading("Charts:")
+		fmt.Fprintln(w, "No response logs in this time range")
+		return
+	}
+
+	heading(fmt.Sprintf("Latency Trend (p95 per %s, UTC):", per))
+	spark := output.Sparkline(p95s, 0)
+	if plain {
+		spark = output.ASCIISparkline(p95s, 0)
+	}
+	fmt.Fprintf(w, "%s  min %d ms, max %d ms\n", spark, slices.Min(p95s), slices.Max(p95s))
+
+	blocks := [3]string{"█", "▒", "░"}
+	if plain {
+		blocks = [3]string{"#", "~", "x"}
+	}
+	paint := [3]func(string, ...any) string{color.GreenString, color.YellowString, color.RedString}
+
+	fmt.Fprintln(w)
+	heading(fmt.Sprintf("Checks per %s (UTC):", per))
+	fmt.Fprintf(w, "%-*s  %-*s  %8s  %8s  %6s\n", labelWidth, "", chartWidth,
+		fmt.Sprintf("%s success %s degraded %s error", blocks[0], blocks[1], blocks[2]), "p50", "p95", "checks")
+	for _, b := range c.Buckets {
+		start, _ := time.Parse(time.RFC3339, b.Start)
+		if b.Total == 0 {
+			fmt.Fprintf(w, "%-*s  %-*s  %8s  %8s  %6d\n", labelWidth, start.Format(label), chartWidth, "", "-", "-", 0)
+			continue
+		}
+		// Checks of an unknown status are counted but not drawn.
+		bar := strings.Builder{}
+		cells := stackedCells([]int{b.Success, b.Degraded, b.Error}, chartWidth)
+		if slices.Max(cells) == 0 {
+			bar.WriteString(strings.Repeat(" ", chartWidth))
+		}
+		for i, n := range cells {
+			switch {
+			case n == 0:
+			case plain:
+				bar.WriteString(strings.Repeat(blocks[i], n))
+			default:
+				bar.WriteString(paint[i]("%s", strings.Repeat(blocks[i], n)))
+			}
+		}
+		fmt.Fprintf(w, "%-*s  %s  %5d ms  %5d ms  %6d\n", labelWidth, start.Format(label), bar.String(), b.P50, b.P95, b.Total)
+	}
+
+	if len(c.Regions) == 0 {
+		return
+	}
+	fill := "█"
+	if plain {
+		fill = "="
+	}
+	var slowest int64
+	regionWidth := 0
+	for _, r := range c.Regions {
+		slowest = max(slowest, r.P95)
+		regionWidth = max(regionWidth, len(r.Region))
+	}
+	fmt.Fprintln(w)
+	heading("Latency by Region (p95):")
+	for _, r := range c.Regions {
+		n := 0
+		if slowest > 0 {
+			n = max(1, int(r.P95*chartWidth/slowest))
+		}
+		fmt.Fprintf(w, "%-*s  %-*s  %5d ms  p50 %d ms\n", regionWidth, r.Region, chartWidth, strings.Repeat(fill, n), r.P95, r.P50)
+	}
+}
@@ -0,0 +1,115 @@
+package monitors_test
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	monitorv1 "buf.build/gen/go/openstatus/api/protocolbuffers/go/openstatus/monitor/v1"
+
+	"github.com/openstatusHQ/cli/internal/monitors"
+)
+
+func chartLog(at time.Time, latency int32, region monitorv1.Region, status monitorv1.HTTPResponseLogRequestStatus) *monitorv1.HTTPResponseLog {
+	l := &monitorv1.HTTPResponseLog{}
+	l.SetTimestamp(at.UnixMilli())
+	l.SetLatency(latency)
+	l.SetRegion(region)
+	l.SetRequestStatus(status)
+	return l
+}
+
+func chartFixture() *monitors.ChartsOutput {
+	to := time.Date(2026, 5, 1, 11, 30, 0, 0, time.UTC)
+	from := to.Add(-2 * time.Hour)
+	success := monitorv1.HTTPResponseLogRequestStatus_HTTP_RESPONSE_LOG_REQUEST_STATUS_SUCCESS
+	failed := monitorv1.HTTPResponseLogRequestStatus_HTTP_RESPONSE_LOG_REQUEST_STATUS_ERROR
+	logs := []*monitorv1.HTTPResponseLog{
+		chartLog(from.Add(-time.Minute), 5000, monitorv1.Region_REGION_FLY_IAD, success),
+		chartLog(time.Date(2026, 5, 1, 9, 40, 0, 0, time.UTC), 100, monitorv1.Region_REGION_FLY_IAD, success),
+		chartLog(time.Date(2026, 5, 1, 9, 50, 0, 0, time.UTC), 300, monitorv1.Region_REGION_FLY_AMS, failed),
+		chartLog(time.Date(2026, 5, 1, 11, 10, 0, 0, time.UTC), 200, monitorv1.Region_REGION_FLY_IAD, success),
+	}
+	return monitors.BuildCharts(logs, from, to, time.Hour)
+}
+
+func Test_buildCharts(t *testing.T) {
+	t.Parallel()
+	c := chartFixture()
+
+	if c.Bucket != "1h" || len(c.Buckets) != 3 {
+		t.Fatalf("expected 3 hourly buckets, got %s/%d", c.Bucket, len(c.Buckets))
+	}
+	first, empty, last := c.Buckets[0], c.Buckets[1], c.Buckets[2]
+	if first.Start != "2026-05-01T09:00:00Z" || first.Total != 2 || first.Success != 1 || first.Error != 1 {
+		t.Errorf("unexpected first bucket %+v", first)
+	}
+	if first.P50 != 100 || first.P95 != 300 || first.Max != 300 {
+		t.Errorf("unexpected first bucket latencies %+v", first)
+	}
+	if empty.Total != 0 || empty.P95 != 0 {
+		t.Errorf("expected an empty bucket, got %+v", empty)
+	}
+	if last.Total != 1 || last.P95 != 200 {
+		t.Errorf("unexpected last bucket %+v", last)
+	}
+
+	want := []monitors.RegionLatencyOutput{
+		{Region: "ams", Total: 1, P50: 300, P95: 300},
+		{Region: "iad", Total: 2, P50: 100, P95: 200},
+	}
+	if !slices.Equal(c.Regions, want) {
+		t.Errorf("regions = %+v, want %+v", c.Regions, want)
+	}
+}
+
+func Test_stackedCells(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		counts []int
+		want   []int
+	}{
+		{[]int{0, 0, 0}, []int{0, 0, 0}},
+		{[]int{3, 1, 0}, []int{30, 10, 0}},
+		{[]int{999, 0, 1}, []int{39, 0, 1}},
+	}
+	for _, tt := range tests {
+		if got := monitors.StackedCells(tt.counts, 40); !slices.Equal(got, tt.want) {
+			t.Errorf("stackedCells(%v) = %v, want %v", tt.counts, got, tt.want)
+		}
+	}
+}
+
+func Test_writeCharts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("plain", func(t *testing.T) {
+		var buf bytes.Buffer
+		monitors.WriteCharts(&buf, chartFixture(), true)
+		out := buf.String()
+		if strings.ContainsAny(out, "█▒░▁\x1b") {
+			t.Errorf("plain charts should be ASCII without escapes:\n%s", out)
+		}
+		for _, want := range []string{
+			"Latency Trend (p95 per hour, UTC):\n#_  min 200 ms, max 300 ms\n",
+			"05-01 09:00  " + strings.Repeat("#", 20) + strings.Repeat("x", 20) + "    100 ms    300 ms       2\n",
+			"05-01 10:00  " + strings.Repeat(" ", 40) + "         -         -       0\n",
+			"ams  " + strings.Repeat("=", 40) + "    300 ms  p50 300 ms\n",
+			"iad  " + strings.Repeat("=", 26) + strings.Repeat(" ", 14) + "    200 ms  p50 100 ms\n",
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("output missing %q:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("no logs", func(t *testing.T) {
+		var buf bytes.Buffer
+		monitors.WriteCharts(&buf, monitors.BuildCharts(nil, time.Now().Add(-time.Hour), time.Now(), time.Hour), true)
+		if !strings.Contains(buf.String(), "No response logs in this time range") {
+			t.Errorf("unexpected output:\n%s", buf.String())
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package monitors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v3"
+
+	output "github.com/openstatusHQ/cli/internal/cli"
+	"github.com/openstatusHQ/cli/internal/config"
+	"github.com/openstatusHQ/cli/internal/local"
+)
+
+// selectMonitors returns the monitors with the given logical names, or all
+// of them when names is empty.
+func selectMonitors(monitors config.Monitors, names []string) (config.Monitors, error) {
+	if len(names) == 0 {
+		return monitors, nil
+	}
+	out := make(config.Monitors, len(names))
+	for _, name := range names {
+		m, ok := monitors[name]
+		if !ok {
+			return nil, fmt.Errorf("monitor %q is not defined in the configuration", name)
+		}
+		out[name] = m
+	}
+	return out, nil
+}
+
+func renderLocalResults(w io.Writer, results []local.Result) {
+	var passed, degraded, failed int
+	for _, r := range results {
+		var status string
+		switch r.Status {
+		case local.StatusPass:
+			passed++
+			status = color.GreenString("pass")
+		case local.StatusDegraded:
+			degraded++
+			status = color.YellowString("degraded")
+		case local.StatusFail:
+			failed++
+			status = color.RedString("fail")
+		default:
+			status = color.New(color.Faint).Sprint(string(r.Status))
+		}
+
+		line := fmt.Sprintf("%-10s %s (%s)", status, r.Monitor, r.Kind)
+		if r.Status != local.StatusSkipped {
+			line += fmt.Sprintf("  %dms", r.Latency)
+		}
+		if r.StatusCode != 0 {
+			line += fmt.Sprintf("  %d", r.StatusCode)
+		}
+		if r.Attempts > 1 {
+			line += fmt.Sprintf("  after %d attempts", r.Attempts)
+		}
+		fmt.Fprintln(w, line)
+		for _, a := range r.Assertions {
+			if a.Passed {
+				continue
+			}
+			subject := string(a.Kind)
+			if a.Key != "" {
+				subject += " " + a.Key
+			}
+			fmt.Fprintf(w, "    %s %s %v, got %v\n", subject, a.Compare, a.Target, a.Actual)
+		}
+		if r.Error != "" && len(r.Assertions) == 0 {
+			fmt.Fprintf(w, "    %s\n", r.Error)
+		}
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%d passed, %d degraded, %d failed\n", passed, degraded, failed)
+}
+
+func GetMonitorTestCmd() *cli.Command {
+	monitorTestCmd := cli.Command{
+		Name:  "test",
+		Usage: "Run the monitors of openstatus.yaml from this machine",
+		UsageText: `openstatus monitors test --local
+  openstatus monitors test --local api-prod web-prod
+  openstatus monitors test --local --config monitors/ --env prod --var-file prod.env`,
+		Description: `Performs the request of each monitor defined in openstatus.yaml from this
+machine and evaluates its assertions, without calling the OpenStatus API.
+timeout, degradedAfter, retry and followRedirects are honoured, and each
+monitor is reported as pass, degraded or fail. HTTP and TCP monitors are
+supported; DNS monitors are skipped.
+
+Pass logical names to only run some monitors. The command exits with a
+non-zero status if any monitor fails, which makes it suitable for CI before
+running 'openstatus monitors apply'.`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "local",
+				Usage: "Run the checks from this machine",
+			},
+			&cli.StringFlag{
+				Name:        "config",
+				Usage:       "The configuration file, directory or glob containing monitor information",
+				Aliases:     []string{"c"},
+				DefaultText: "openstatus.yaml",
+				Value:       "openstatus.yaml",
+			},
+			&cli.StringFlag{
+				Name:  "env",
+				Usage: "Environment name: merges openstatus.<env>.yaml over the config",
+			},
+			&cli.StringSliceFlag{
+				Name:  "var-file",
+				Usage: "Dotenv file with values for ${VAR} and ${secret:NAME} references (repeatable)",
+			},
+			&cli.IntFlag{
+				Name:  "parallelism",
+				Usage: "Number of monitors to run concurrently",
+				Value: defaultApplyConcurrency,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if !cmd.Bool("local") {
+				return cli.Exit("Only local runs are supported: pass --local, or use 'openstatus monitors trigger <id>' to run a deployed monitor", 1)
+			}
+
+			path := cmd.String("config")
+			if !strings.ContainsAny(path, "*?[") {
+				if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+					return cli.Exit("Config does not exist", 1)
+				}
+			}
+			vars, err := config.ReadVarFiles(cmd.StringSlice("var-file")...)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			readOpts := config.ReadOptions{Vars: vars}
+			if env := cmd.String("env"); env != "" {
+				if overlay := config.OverlayPath(path, env); fileExists(overlay) {
+					readOpts.Overlays = append(readOpts.Overlays, overlay)
+				}
+			}
+
+			file, err := config.ReadOpenStatusFile(path, readOpts)
+			if err != nil {
+				return cli.Exit(fmt.Sprintf("Unable to read config file: %v", err), 1)
+			}
+			selected, err := selectMonitors(file.Monitors, cmd.Args().Slice())
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			resolved := make(config.Monitors, len(selected))
+			for name, m := range selected {
+				if resolved[name], err = config.ResolveSecrets(m, vars); err != nil {
+					return cli.Exit(err.Error(), 1)
+				}
+			}
+
+			s := output.StartSpinner(fmt.Sprintf("Running %d monitors locally...", len(resolved)))
+			results := local.RunAll(ctx, resolved, local.Options{Concurrency: int(cmd.Int("parallelism"))})
+			output.StopSpinner(s)
+
+			if output.IsJSONOutput() {
+				if err := output.PrintJSON(results); err != nil {
+					return err
+				}
+			} else {
+				renderLocalResults(os.Stdout, results)
+			}
+
+			for _, r := range results {
+				if r.Status == local.StatusFail {
+					return cli.Exit("Some monitors failed", 1)
+				}
+			}
+			return nil
+		},
+	}
+	return &monitorTestCmd
+}
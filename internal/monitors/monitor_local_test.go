@@ -0,0 +1,59 @@
+package monitors_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/openstatusHQ/cli/internal/config"
+	"github.com/openstatusHQ/cli/internal/local"
+	"github.com/openstatusHQ/cli/internal/monitors"
+)
+
+func Test_SelectMonitors(t *testing.T) {
+	all := config.Monitors{
+		"api": {Name: "API"},
+		"web": {Name: "Web"},
+	}
+
+	t.Run("No names selects every monitor", func(t *testing.T) {
+		got, err := monitors.SelectMonitors(all, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 {
+			t.Errorf("Expected 2 monitors, got %d", len(got))
+		}
+	})
+
+	t.Run("Selects the named monitors", func(t *testing.T) {
+		got, err := monitors.SelectMonitors(all, []string{"web"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := got["web"]; !ok || len(got) != 1 {
+			t.Errorf("Expected only web, got %v", got)
+		}
+	})
+
+	t.Run("Unknown name returns error", func(t *testing.T) {
+		if _, err := monitors.SelectMonitors(all, []string{"db"}); err == nil {
+			t.Error("Expected error for unknown monitor, got nil")
+		}
+	})
+}
+
+func Test_RenderLocalResults(t *testing.T) {
+	var buf bytes.Buffer
+	monitors.RenderLocalResults(&buf, []local.Result{
+		{Monitor: "api", Kind: config.HTTP, Status: local.StatusPass, Latency: 120, StatusCode: 200, Attempts: 1},
+		{Monitor: "web", Kind: config.HTTP, Status: local.StatusFail, Latency: 80, StatusCode: 500, Attempts: 2, Error: "assertions failed",
+			Assertions: []local.AssertionResult{{Kind: config.StatusCode, Compare: config.Eq, Target: 200, Actual: 500}}},
+	})
+	out := buf.String()
+	for _, want := range []string{"api (http)  120ms  200", "after 2 attempts", "statusCode eq 200, got 500", "1 passed, 0 degraded, 1 failed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
@@ -19,10 +19,10 @@ import (
 	output "github.com/openstatusHQ/cli/internal/cli"
 )
 
-type responseLogDetailOutput struct {
+type ResponseLogDetailOutput struct {
 	ID            string            `json:"id"`
 	MonitorID     string            `json:"monitor_id"`
-	URL           string            `json:"url"`
+	URL           string            `json:"url,omitempty"`
 	StatusCode    int32             `json:"status_code,omitempty"`
 	Latency       int32             `json:"latency_ms"`
 	Region        string            `json:"region"`
@@ -33,10 +33,10 @@ type responseLogDetailOutput struct {
 	Message       string            `json:"message,omitempty"`
 	Headers       map[string]string `json:"headers,omitempty"`
 	Assertions    string            `json:"assertions,omitempty"`
-	Timing        *timingOutput     `json:"timing,omitempty"`
+	Timing        *TimingOutput     `json:"timing,omitempty"`
 }
 
-type timingOutput struct {
+type TimingOutput struct {
 	DNS      int32 `json:"dns_ms"`
 	Connect  int32 `json:"connect_ms"`
 	TLS      int32 `json:"tls_ms"`
@@ -44,6 +44,53 @@ type timingOutput struct {
 	Transfer int32 `json:"transfer_ms"`
 }
 
+func toTimingOutput(l *monitorv1.HTTPResponseLog) *TimingOutput {
+	if !l.HasTiming() {
+		return nil
+	}
+	t := l.GetTiming()
+	return &TimingOutput{
+		DNS:      t.GetDns(),
+		Connect:  t.GetConnect(),
+		TLS:      t.GetTls(),
+		TTFB:     t.GetTtfb(),
+		Transfer: t.GetTransfer(),
+	}
+}
+
+func toResponseLogDetailOutput(detail *monitorv1.HTTPResponseLogDetail) ResponseLogDetailOutput {
+	logItem := detail.GetLog()
+	return ResponseLogDetailOutput{
+		ID:            logItem.GetId(),
+		MonitorID:     logItem.GetMonitorId(),
+		URL:           detail.GetUrl(),
+		StatusCode:    logItem.GetStatusCode(),
+		Latency:       logItem.GetLatency(),
+		Region:        regionToString(logItem.GetRegion()),
+		RequestStatus: requestStatusToString(logItem.GetRequestStatus()),
+		Trigger:       triggerToString(logItem.GetTrigger()),
+		Timestamp:     formatUnixMillis(logItem.GetTimestamp()),
+		Error:         detail.GetError(),
+		Message:       detail.GetMessage(),
+		Headers:       detail.GetHeaders(),
+		Assertions:    detail.GetAssertions(),
+		Timing:        toTimingOutput(logItem),
+	}
+}
+
+// GetResponseLogDetail fetches a single response log with its URL,
+// headers, error message and assertions.
+func GetResponseLogDetail(ctx context.Context, client monitorv1connect.MonitorServiceClient, monitorId string, logId string) (ResponseLogDetailOutput, error) {
+	resp, err := client.GetMonitorHTTPResponseLog(ctx, &monitorv1.GetMonitorHTTPResponseLogRequest{
+		Id:    monitorId,
+		LogId: logId,
+	})
+	if err != nil {
+		return ResponseLogDetailOutput{}, output.FormatError(err, "response log", logId)
+	}
+	return toResponseLogDetailOutput(resp.GetLog()), nil
+}
+
 func GetMonitorResponseLogInfo(
 	ctx context.Context,
 	client monitorv1connect.MonitorServiceClient,
@@ -66,46 +113,12 @@ func GetMonitorResponseLogInfo(
 		return fmt.Errorf("log ID is required")
 	}
 
-	resp, err := client.GetMonitorHTTPResponseLog(ctx, &monitorv1.GetMonitorHTTPResponseLogRequest{
-		Id:    monitorId,
-		LogId: logId,
-	})
+	detailOut, err := GetResponseLogDetail(ctx, client, monitorId, logId)
 	output.StopSpinner(s)
 	if err != nil {
-		return output.FormatError(err, "response log", logId)
-	}
-
-	detail := resp.GetLog()
-	logItem := detail.GetLog()
-
-	var timing *timingOutput
-	if logItem.HasTiming() {
-		t := logItem.GetTiming()
-		timing = &timingOutput{
-			DNS:      t.GetDns(),
-			Connect:  t.GetConnect(),
-			TLS:      t.GetTls(),
-			TTFB:     t.GetTtfb(),
-			Transfer: t.GetTransfer(),
-		}
-	}
-
-	detailOut := responseLogDetailOutput{
-		ID:            logItem.GetId(),
-		MonitorID:     logItem.GetMonitorId(),
-		URL:           detail.GetUrl(),
-		StatusCode:    logItem.GetStatusCode(),
-		Latency:       logItem.GetLatency(),
-		Region:        regionToString(logItem.GetRegion()),
-		RequestStatus: requestStatusToString(logItem.GetRequestStatus()),
-		Trigger:       triggerToString(logItem.GetTrigger()),
-		Timestamp:     formatUnixMillis(logItem.GetTimestamp()),
-		Error:         detail.GetError(),
-		Message:       detail.GetMessage(),
-		Headers:       detail.GetHeaders(),
-		Assertions:    detail.GetAssertions(),
-		Timing:        timing,
+		return err
 	}
+	timing := detailOut.Timing
 
 	if output.IsJSONOutput() {
 		return output.PrintJSON(detailOut)
@@ -170,7 +183,7 @@ func GetMonitorResponseLogInfo(
 	return nil
 }
 
-func renderTimingWaterfall(t *timingOutput) {
+func renderTimingWaterfall(t *TimingOutput) {
 	phases := []struct {
 		name string
 		ms   int32
@@ -242,7 +255,7 @@ func GetMonitorLogInfoCmd() *cli.Command {
 	return &cli.Command{
 		Name:  "log-info",
 		Usage: "Get detailed HTTP response log for a monitor",
-		UsageText: `openstatus monitors log-info <MonitorID> <LogID>
+		UsageText: `openstatus monitors log-info <MonitorID|name> <LogID>
   openstatus monitors log-info 12345 abc-def-ghi`,
 		Description: "Fetch a single HTTP response log with full details including timing phases, response headers, and assertion results.",
 		Flags: []cli.Flag{
@@ -252,18 +265,23 @@ func GetMonitorLogInfoCmd() *cli.Command {
 				Aliases: []string{"t"},
 				Sources: cli.EnvVars("OPENSTATUS_API_TOKEN"),
 			},
+			ResolveEnvFlag(),
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			apiKey, err := auth.ResolveAccessToken(cmd)
 			if err != nil {
 				return cli.Exit(err.Error(), 1)
 			}
-			monitorId := cmd.Args().Get(0)
+			client := NewMonitorClient(apiKey)
+			monitorId, err := ResolveMonitorID(ctx, client, cmd.Args().Get(0), cmd.String("env"))
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
 			logId := cmd.Args().Get(1)
 			s := output.StartSpinner("Fetching response log details...")
 			err = GetMonitorResponseLogInfo(
 				ctx,
-				NewMonitorClient(apiKey),
+				client,
 				monitorId,
 				logId,
 				s,
@@ -1,10 +1,15 @@
 package monitors
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"buf.build/gen/go/openstatus/api/connectrpc/gosimple/openstatus/monitor/v1/monitorv1connect"
@@ -17,7 +22,7 @@ import (
 	output "github.com/openstatusHQ/cli/internal/cli"
 )
 
-type responseLogEntry struct {
+type ResponseLogEntry struct {
 	ID            string `json:"id"`
 	MonitorID     string `json:"monitor_id"`
 	StatusCode    int32  `json:"status_code,omitempty"`
@@ -29,7 +34,7 @@ type responseLogEntry struct {
 }
 
 type responseLogListOutput struct {
-	Logs       []responseLogEntry `json:"logs"`
+	Logs       []ResponseLogEntry `json:"logs"`
 	Pagination *paginationOutput  `json:"pagination"`
 }
 
@@ -89,6 +94,47 @@ func parseRFC3339ToUnixMillis(s string) (int64, error) {
 	return t.UnixMilli(), nil
 }
 
+func toResponseLogEntry(l *monitorv1.HTTPResponseLog) ResponseLogEntry {
+	return ResponseLogEntry{
+		ID:            l.GetId(),
+		MonitorID:     l.GetMonitorId(),
+		StatusCode:    l.GetStatusCode(),
+		Latency:       l.GetLatency(),
+		Region:        regionToString(l.GetRegion()),
+		RequestStatus: requestStatusToString(l.GetRequestStatus()),
+		Trigger:       triggerToString(l.GetTrigger()),
+		Timestamp:     formatUnixMillis(l.GetTimestamp()),
+	}
+}
+
+// ResponseLogFilter selects response logs by region and request status.
+// Empty fields match everything. The filter is applied client-side.
+type ResponseLogFilter struct {
+	Regions  []string
+	Statuses []string
+}
+
+var responseLogStatuses = []string{"success", "error", "degraded"}
+
+func (f ResponseLogFilter) validate() error {
+	for _, s := range f.Statuses {
+		if !slices.Contains(responseLogStatuses, strings.ToLower(s)) {
+			return fmt.Errorf("invalid status %q, expected one of: %s", s, strings.Join(responseLogStatuses, ", "))
+		}
+	}
+	return nil
+}
+
+func (f ResponseLogFilter) match(e ResponseLogEntry) bool {
+	if len(f.Regions) > 0 && !slices.ContainsFunc(f.Regions, func(r string) bool { return strings.EqualFold(r, e.Region) }) {
+		return false
+	}
+	if len(f.Statuses) > 0 && !slices.ContainsFunc(f.Statuses, func(s string) bool { return strings.EqualFold(s, e.RequestStatus) }) {
+		return false
+	}
+	return true
+}
+
 func ListMonitorResponseLogs(
 	ctx context.Context,
 	client monitorv1connect.MonitorServiceClient,
@@ -97,6 +143,7 @@ func ListMonitorResponseLogs(
 	offset int32,
 	from string,
 	to string,
+	filter ResponseLogFilter,
 	s *output.Spinner,
 ) error {
 	if monitorId == "" {
@@ -149,18 +196,11 @@ func ListMonitorResponseLogs(
 	}
 
 	logs := resp.GetLogs()
-	entries := make([]responseLogEntry, 0, len(logs))
+	entries := make([]ResponseLogEntry, 0, len(logs))
 	for _, l := range logs {
-		entries = append(entries, responseLogEntry{
-			ID:            l.GetId(),
-			MonitorID:     l.GetMonitorId(),
-			StatusCode:    l.GetStatusCode(),
-			Latency:       l.GetLatency(),
-			Region:        regionToString(l.GetRegion()),
-			RequestStatus: requestStatusToString(l.GetRequestStatus()),
-			Trigger:       triggerToString(l.GetTrigger()),
-			Timestamp:     formatUnixMillis(l.GetTimestamp()),
-		})
+		if e := toResponseLogEntry(l); filter.match(e) {
+			entries = append(entries, e)
+		}
 	}
 
 	var pagination *paginationOutput
@@ -173,6 +213,11 @@ func ListMonitorResponseLogs(
 		}
 	}
 
+	return printResponseLogs(entries, pagination)
+}
+
+// printResponseLogs prints logs as a table, or as JSON with --json.
+func printResponseLogs(entries []ResponseLogEntry, pagination *paginationOutput) error {
 	if output.IsJSONOutput() {
 		return output.PrintJSON(responseLogListOutput{
 			Logs:       entries,
@@ -206,21 +251,207 @@ func ListMonitorResponseLogs(
 	return nil
 }
 
-func ListMonitorResponseLogsWithHTTPClient(ctx context.Context, httpClient *http.Client, apiKey string, monitorId string, limit int32, offset int32, from string, to string) error {
+func ListMonitorResponseLogsWithHTTPClient(ctx context.Context, httpClient *http.Client, apiKey string, monitorId string, limit int32, offset int32, from string, to string, filter ResponseLogFilter) error {
 	client := NewMonitorClientWithHTTPClient(httpClient, apiKey)
-	return ListMonitorResponseLogs(ctx, client, monitorId, limit, offset, from, to, nil)
+	return ListMonitorResponseLogs(ctx, client, monitorId, limit, offset, from, to, filter, nil)
+}
+
+const (
+	// followBacklog is how far back --follow starts without --from.
+	followBacklog = 10 * time.Minute
+	// followOverlap is how far before the newest log each poll starts
+	// again, so that logs ingested late are not missed. Logs seen twice
+	// are dropped by ID.
+	followOverlap = 2 * time.Minute
+	// logsPageSize is the page size used to fetch every log of a window.
+	logsPageSize = 100
+)
+
+// FollowOptions configures monitors logs --follow.
+type FollowOptions struct {
+	// From is the start of the tail; zero means followBacklog ago.
+	From     time.Time
+	Interval time.Duration
+	Filter   ResponseLogFilter
+	// JSON prints one JSON object per line instead of table rows.
+	JSON bool
+}
+
+// fetchResponseLogsSince returns every log from fromMs on, following
+// pagination.
+func fetchResponseLogsSince(ctx context.Context, client monitorv1connect.MonitorServiceClient, monitorId string, fromMs int64) ([]*monitorv1.HTTPResponseLog, error) {
+	var logs []*monitorv1.HTTPResponseLog
+	var offset int32
+	for {
+		req := &monitorv1.ListMonitorHTTPResponseLogsRequest{Id: monitorId}
+		req.SetFromTimestamp(fromMs)
+		req.SetLimit(logsPageSize)
+		if offset > 0 {
+			req.SetOffset(offset)
+		}
+		resp, err := client.ListMonitorHTTPResponseLogs(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, resp.GetLogs()...)
+		p := resp.GetPagination()
+		if p == nil || !p.GetHasMore() || p.GetNextOffset() <= offset {
+			return logs, nil
+		}
+		offset = p.GetNextOffset()
+	}
+}
+
+func printFollowHeader(w io.Writer) {
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	// cell pads a header to width, right-aligned when width is positive,
+	// to line up with the rows of printFollowRow.
+	cell := func(name string, width int) string {
+		if width < 0 {
+			return headerFmt(name) + strings.Repeat(" ", max(0, -width-len(name)))
+		}
+		return strings.Repeat(" ", max(0, width-len(name))) + headerFmt(name)
+	}
+	fmt.Fprintln(w, strings.Join([]string{
+		cell("ID", -28), cell("Status", -12), cell("Code", 4), cell("Latency (ms)", 12), cell("Region", -22), headerFmt("Timestamp"),
+	}, " "))
+}
+
+func printFollowRow(w io.Writer, e ResponseLogEntry) {
+	code := ""
+	if e.StatusCode != 0 {
+		code = fmt.Sprint(e.StatusCode)
+	}
+	// Pad before colorizing so escape codes do not break the alignment.
+	statusColor := color.New(color.Reset)
+	switch e.RequestStatus {
+	case "success":
+		statusColor = color.New(color.FgGreen)
+	case "error":
+		statusColor = color.New(color.FgRed)
+	case "degraded":
+		statusColor = color.New(color.FgYellow)
+	}
+	fmt.Fprintf(w, "%s %s %4s %12d %-22s %s\n",
+		color.New(color.FgYellow).Sprintf("%-28s", e.ID),
+		statusColor.Sprintf("%-12s", "● "+e.RequestStatus),
+		code, e.Latency, e.Region, e.Timestamp)
+}
+
+// FollowMonitorResponseLogs polls the response logs of a monitor and
+// prints new ones as they arrive until ctx is cancelled. The first poll
+// failing is an error; later failures are reported and retried.
+func FollowMonitorResponseLogs(ctx context.Context, client monitorv1connect.MonitorServiceClient, monitorId string, opts FollowOptions, w io.Writer) error {
+	if monitorId == "" {
+		return fmt.Errorf("monitor ID is required")
+	}
+	if err := opts.Filter.validate(); err != nil {
+		return err
+	}
+	if opts.Interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	from := opts.From
+	if from.IsZero() {
+		from = time.Now().Add(-followBacklog)
+	}
+
+	cursor := from.UnixMilli()
+	seen := map[string]int64{}
+	enc := json.NewEncoder(w)
+	header := false
+	for polls := 0; ; polls++ {
+		logs, err := fetchResponseLogsSince(ctx, client, monitorId, cursor)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			err = output.FormatError(err, "response logs", monitorId)
+			if polls == 0 {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Warning: %v; retrying in %s\n", err, opts.Interval)
+		}
+
+		slices.SortStableFunc(logs, func(a, b *monitorv1.HTTPResponseLog) int {
+			return cmp.Compare(a.GetTimestamp(), b.GetTimestamp())
+		})
+		newest := int64(0)
+		for _, l := range logs {
+			newest = max(newest, l.GetTimestamp())
+			if _, ok := seen[l.GetId()]; ok {
+				continue
+			}
+			seen[l.GetId()] = l.GetTimestamp()
+			e := toResponseLogEntry(l)
+			if !opts.Filter.match(e) {
+				continue
+			}
+			if opts.JSON {
+				if err := enc.Encode(e); err != nil {
+					return err
+				}
+				continue
+			}
+			if !header {
+				printFollowHeader(w)
+				header = true
+			}
+			printFollowRow(w, e)
+		}
+
+		cursor = max(cursor, newest-followOverlap.Milliseconds())
+		for id, ts := range seen {
+			if ts < cursor {
+				delete(seen, id)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
+func FollowMonitorResponseLogsWithHTTPClient(ctx context.Context, httpClient *http.Client, apiKey string, monitorId string, opts FollowOptions, w io.Writer) error {
+	client := NewMonitorClientWithHTTPClient(httpClient, apiKey)
+	return FollowMonitorResponseLogs(ctx, client, monitorId, opts, w)
 }
 
 func GetMonitorLogsCmd() *cli.Command {
 	return &cli.Command{
 		Name:  "logs",
 		Usage: "List HTTP response logs for a monitor",
-		UsageText: `openstatus monitors logs <MonitorID>
+		UsageText: `openstatus monitors logs <MonitorID|name>
   openstatus monitors logs 12345
   openstatus monitors logs 12345 --limit 10
+  openstatus monitors logs api-prod --limit 10
+  openstatus monitors logs --monitor stats --limit 10
   openstatus monitors logs 12345 --limit 5 --offset 5
-  openstatus monitors logs 12345 --from 2026-05-06T00:00:00Z --to 2026-05-07T00:00:00Z`,
-		Description: "List HTTP response logs for a monitor from the 14-day retention window. Supports pagination and time filtering.",
+  openstatus monitors logs 12345 --from 2026-05-06T00:00:00Z --to 2026-05-07T00:00:00Z
+  openstatus monitors logs api-prod --follow --status error --region fra --region iad
+  openstatus monitors logs api-prod --follow --json | jq .latency_ms
+  openstatus monitors logs api-prod --all --since 7d --output logs.csv
+  openstatus monitors logs api-prod --all --since 24h --detail --output logs.ndjson`,
+		Description: `List HTTP response logs for a monitor from the 14-day retention window. Supports pagination and time filtering.
+
+With --follow, new logs are printed as they arrive until interrupted with
+Ctrl+C. The tail starts at --from, or 10 minutes ago, and polls every
+--interval. With --json, each log is printed as one JSON object per line.
+--region and --status only keep the matching logs.
+
+With --all, every page of the window is fetched, several at a time, backing
+off while the API is rate limiting. --output writes the logs to a .csv or
+.ndjson file instead of printing them, and --detail adds the URL, headers,
+error message and assertions of each log, at the cost of one request per log.
+
+See 'openstatus monitors logs stats' to aggregate the logs instead. A monitor
+named "stats" is passed with --monitor stats.`,
+		Commands: []*cli.Command{
+			GetMonitorLogsStatsCmd(),
+		},
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "access-token",
@@ -228,6 +459,11 @@ func GetMonitorLogsCmd() *cli.Command {
 				Aliases: []string{"t"},
 				Sources: cli.EnvVars("OPENSTATUS_API_TOKEN"),
 			},
+			ResolveEnvFlag(),
+			&cli.StringFlag{
+				Name:  "monitor",
+				Usage: "Monitor ID or name, instead of the argument",
+			},
 			&cli.IntFlag{
 				Name:  "limit",
 				Usage: "Maximum number of logs to return (1-100)",
@@ -244,22 +480,146 @@ func GetMonitorLogsCmd() *cli.Command {
 				Name:  "to",
 				Usage: "End of time window (RFC 3339 format)",
 			},
+			&cli.BoolFlag{
+				Name:    "follow",
+				Usage:   "Print new logs as they arrive until interrupted",
+				Aliases: []string{"f"},
+			},
+			&cli.DurationFlag{
+				Name:  "interval",
+				Usage: "Polling interval with --follow",
+				Value: 10 * time.Second,
+			},
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "Fetch every page of the time window",
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Start the time window this long ago, e.g. 7d, 12h or 30m",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Usage:   "Write all logs to a .csv or .ndjson file (implies --all)",
+				Aliases: []string{"o"},
+			},
+			&cli.BoolFlag{
+				Name:  "detail",
+				Usage: "With --output, add the URL, headers, error message and assertions of each log",
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Usage: "Number of requests in flight with --all",
+				Value: defaultExportConcurrency,
+			},
+			&cli.StringSliceFlag{
+				Name:  "region",
+				Usage: "Only show logs from this region (repeatable)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "status",
+				Usage: "Only show logs with this request status: success, error or degraded (repeatable)",
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			apiKey, err := auth.ResolveAccessToken(cmd)
 			if err != nil {
 				return cli.Exit(err.Error(), 1)
 			}
-			monitorId := cmd.Args().Get(0)
+			filter := ResponseLogFilter{
+				Regions:  cmd.StringSlice("region"),
+				Statuses: cmd.StringSlice("status"),
+			}
+			if err := filter.validate(); err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			ref := cmd.Args().Get(0)
+			if cmd.IsSet("monitor") {
+				if ref != "" {
+					return cli.Exit("--monitor cannot be used with a monitor argument", 1)
+				}
+				ref = cmd.String("monitor")
+			}
+			client := NewMonitorClient(apiKey)
+			monitorId, err := ResolveMonitorID(ctx, client, ref, cmd.String("env"))
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+
+			from := cmd.String("from")
+			if since := cmd.String("since"); since != "" {
+				if from != "" {
+					return cli.Exit("--since and --from cannot be used together", 1)
+				}
+				d, err := parseSince(since)
+				if err != nil {
+					return cli.Exit(err.Error(), 1)
+				}
+				from = time.Now().Add(-d).UTC().Format(time.RFC3339)
+			}
+			var fromTime, toTime time.Time
+			if from != "" {
+				if fromTime, err = time.Parse(time.RFC3339, from); err != nil {
+					return cli.Exit(fmt.Sprintf("invalid RFC 3339 timestamp %q: %v", from, err), 1)
+				}
+			}
+			if to := cmd.String("to"); to != "" {
+				if toTime, err = time.Parse(time.RFC3339, to); err != nil {
+					return cli.Exit(fmt.Sprintf("invalid RFC 3339 timestamp %q: %v", to, err), 1)
+				}
+			}
+
+			if cmd.Bool("all") || cmd.IsSet("output") {
+				if cmd.Bool("follow") || cmd.IsSet("offset") || cmd.IsSet("limit") {
+					return cli.Exit("--follow, --limit and --offset cannot be used with --all", 1)
+				}
+				if cmd.Bool("detail") && !cmd.IsSet("output") {
+					return cli.Exit("--detail requires --output", 1)
+				}
+				s := output.StartSpinner("Fetching all response logs...")
+				err := ExportMonitorResponseLogs(ctx, client, monitorId, LogExportOptions{
+					From:        fromTime,
+					To:          toTime,
+					Filter:      filter,
+					Output:      cmd.String("output"),
+					Detail:      cmd.Bool("detail"),
+					Concurrency: int(cmd.Int("concurrency")),
+				}, s)
+				if err != nil {
+					return cli.Exit(err.Error(), 1)
+				}
+				return nil
+			}
+
+			if cmd.Bool("follow") {
+				if cmd.IsSet("to") || cmd.IsSet("offset") || cmd.IsSet("limit") {
+					return cli.Exit("--to, --limit and --offset cannot be used with --follow", 1)
+				}
+				opts := FollowOptions{
+					From:     fromTime,
+					Interval: cmd.Duration("interval"),
+					Filter:   filter,
+					JSON:     output.IsJSONOutput(),
+				}
+				if !opts.JSON && !output.IsQuiet() {
+					fmt.Fprintf(os.Stderr, "Following response logs of monitor %s, press Ctrl+C to stop\n", monitorId)
+				}
+				if err := FollowMonitorResponseLogs(ctx, client, monitorId, opts, os.Stdout); err != nil {
+					return cli.Exit(err.Error(), 1)
+				}
+				return nil
+			}
+
 			s := output.StartSpinner("Fetching response logs...")
 			err = ListMonitorResponseLogs(
 				ctx,
-				NewMonitorClient(apiKey),
+				client,
 				monitorId,
 				int32(cmd.Int("limit")),
 				int32(cmd.Int("offset")),
-				cmd.String("from"),
+				from,
 				cmd.String("to"),
+				filter,
 				s,
 			)
 			if err != nil {
@@ -0,0 +1,411 @@
+package monitors
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"buf.build/gen/go/openstatus/api/connectrpc/gosimple/openstatus/monitor/v1/monitorv1connect"
+	monitorv1 "buf.build/gen/go/openstatus/api/protocolbuffers/go/openstatus/monitor/v1"
+	"connectrpc.com/connect"
+
+	output "github.com/openstatusHQ/cli/internal/cli"
+)
+
+const defaultExportConcurrency = 4
+
+// exportRetryDelay is the wait after the first rate-limited or unavailable
+// request of a bulk fetch. It doubles on each attempt.
+var exportRetryDelay = time.Second
+
+const exportMaxAttempts = 6
+
+type exportFormat string
+
+const (
+	exportCSV    exportFormat = "csv"
+	exportNDJSON exportFormat = "ndjson"
+)
+
+// exportFormatForFile picks the export format from a file extension.
+func exportFormatForFile(path string) (exportFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return exportCSV, nil
+	case ".ndjson", ".jsonl":
+		return exportNDJSON, nil
+	}
+	return "", fmt.Errorf("cannot infer the export format of %s: use a .csv, .ndjson or .jsonl file", path)
+}
+
+// parseSince parses a duration like time.ParseDuration, with d for days.
+func parseSince(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q, e.g. 7d, 12h or 30m", s)
+	}
+	return d, nil
+}
+
+// withRetry calls fn until it succeeds, backing off while the API is rate
+// limiting or unavailable.
+func withRetry[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	delay := exportRetryDelay
+	for attempt := 1; ; attempt++ {
+		v, err := fn()
+		code := connect.CodeOf(err)
+		if err == nil || attempt == exportMaxAttempts || (code != connect.CodeResourceExhausted && code != connect.CodeUnavailable) {
+			return v, err
+		}
+		select {
+		case <-ctx.Done():
+			return v, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, 30*time.Second)
+	}
+}
+
+// fetchAllResponseLogs returns every log between fromMs and toMs. After
+// the first page, pages are fetched by up to concurrency workers. toMs
+// must be set so that new logs do not shift the offsets while walking.
+func fetchAllResponseLogs(ctx context.Context, client monitorv1connect.MonitorServiceClient, monitorId string, fromMs, toMs int64, concurrency int) ([]*monitorv1.HTTPResponseLog, error) {
+	fetch := func(offset int32) (*monitorv1.ListMonitorHTTPResponseLogsResponse, error) {
+		return withRetry(ctx, func() (*monitorv1.ListMonitorHTTPResponseLogsResponse, error) {
+			req := &monitorv1.ListMonitorHTTPResponseLogsRequest{Id: monitorId}
+			req.SetLimit(logsPageSize)
+			if offset > 0 {
+				req.SetOffset(offset)
+			}
+			if fromMs > 0 {
+				req.SetFromTimestamp(fromMs)
+			}
+			req.SetToTimestamp(toMs)
+			return client.ListMonitorHTTPResponseLogs(ctx, req)
+		})
+	}
+
+	first, err := fetch(0)
+	if err != nil {
+		return nil, err
+	}
+	logs := first.GetLogs()
+	if !first.GetPagination().GetHasMore() || len(logs) == 0 {
+		return logs, nil
+	}
+	step := first.GetPagination().GetLimit()
+	if step <= 0 {
+		step = int32(len(logs))
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		pages    = map[int32][]*monitorv1.HTTPResponseLog{}
+		next     = step
+		end      = int32(-1)
+		firstErr error
+	)
+	// take returns the next offset to fetch, or false once the last page
+	// is known or a request failed.
+	take := func() (int32, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr != nil || (end >= 0 && next >= end) {
+			return 0, false
+		}
+		offset := next
+		next += step
+		return offset, true
+	}
+	for range max(1, concurrency) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				offset, ok := take()
+				if !ok {
+					return
+				}
+				resp, err := fetch(offset)
+				mu.Lock()
+				switch {
+				case err != nil:
+					if firstErr == nil {
+						firstErr = err
+					}
+				default:
+					pages[offset] = resp.GetLogs()
+					if !resp.GetPagination().GetHasMore() || len(resp.GetLogs()) == 0 {
+						if end < 0 || offset+step < end {
+							end = offset + step
+						}
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	offsets := make([]int32, 0, len(pages))
+	for offset := range pages {
+		if offset < end {
+			offsets = append(offsets, offset)
+		}
+	}
+	slices.Sort(offsets)
+	for _, offset := range offsets {
+		logs = append(logs, pages[offset]...)
+	}
+
+	// Drop duplicates in case logs moved between pages while walking.
+	seen := make(map[string]bool, len(logs))
+	return slices.DeleteFunc(logs, func(l *monitorv1.HTTPResponseLog) bool {
+		if seen[l.GetId()] {
+			return true
+		}
+		seen[l.GetId()] = true
+		return false
+	}), nil
+}
+
+// enrichResponseLogs replaces each row by the detail of its log, with up
+// to concurrency requests in flight.
+func enrichResponseLogs(ctx context.Context, client monitorv1connect.MonitorServiceClient, monitorId string, rows []ResponseLogDetailOutput, concurrency int) error {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	sem := make(chan struct{}, max(1, concurrency))
+	for i := range rows {
+		sem <- struct{}{}
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			resp, err := withRetry(ctx, func() (*monitorv1.GetMonitorHTTPResponseLogResponse, error) {
+				return client.GetMonitorHTTPResponseLog(ctx, &monitorv1.GetMonitorHTTPResponseLogRequest{
+					Id:    monitorId,
+					LogId: rows[i].ID,
+				})
+			})
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = output.FormatError(err, "response log", rows[i].ID)
+				}
+				mu.Unlock()
+				return
+			}
+			rows[i] = toResponseLogDetailOutput(resp.GetLog())
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+var exportCSVHeader = []string{
+	"id", "monitor_id", "timestamp", "region", "request_status", "status_code", "latency_ms", "trigger",
+	"dns_ms", "connect_ms", "tls_ms", "ttfb_ms", "transfer_ms",
+}
+
+var exportCSVDetailHeader = []string{"url", "error", "message", "headers", "assertions"}
+
+func writeExportCSV(w io.Writer, rows []ResponseLogDetailOutput, detail bool) error {
+	cw := csv.NewWriter(w)
+	header := exportCSVHeader
+	if detail {
+		header = append(slices.Clip(header), exportCSVDetailHeader...)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		record := []string{
+			r.ID, r.MonitorID, r.Timestamp, r.Region, r.RequestStatus,
+			strconv.Itoa(int(r.StatusCode)), strconv.Itoa(int(r.Latency)), r.Trigger,
+		}
+		if t := r.Timing; t != nil {
+			for _, ms := range []int32{t.DNS, t.Connect, t.TLS, t.TTFB, t.Transfer} {
+				record = append(record, strconv.Itoa(int(ms)))
+			}
+		} else {
+			record = append(record, "", "", "", "", "")
+		}
+		if detail {
+			headers := ""
+			if len(r.Headers) > 0 {
+				b, err := json.Marshal(r.Headers)
+				if err != nil {
+					return err
+				}
+				headers = string(b)
+			}
+			record = append(record, r.URL, strconv.FormatBool(r.Error), r.Message, headers, r.Assertions)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeExportNDJSON(w io.Writer, rows []ResponseLogDetailOutput) error {
+	enc := json.NewEncoder(w)
+	for _, r := range rows {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LogExportOptions configures monitors logs --all.
+type LogExportOptions struct {
+	From time.Time
+	// To is the end of the window; zero means now.
+	To     time.Time
+	Filter ResponseLogFilter
+	// Output is the .csv, .ndjson or .jsonl file to write. Without one,
+	// the logs are printed like a single page.
+	Output string
+	// Detail fetches the detail of each log: URL, headers, error message
+	// and assertions.
+	Detail      bool
+	Concurrency int
+}
+
+// ExportMonitorResponseLogs fetches every response log of a window,
+// following pagination, and writes them to a file or prints them.
+func ExportMonitorResponseLogs(ctx context.Context, client monitorv1connect.MonitorServiceClient, monitorId string, opts LogExportOptions, s *output.Spinner) error {
+	if monitorId == "" {
+		output.StopSpinner(s)
+		return fmt.Errorf("monitor ID is required")
+	}
+	if err := opts.Filter.validate(); err != nil {
+		output.StopSpinner(s)
+		return err
+	}
+	var format exportFormat
+	if opts.Output != "" {
+		var err error
+		if format, err = exportFormatForFile(opts.Output); err != nil {
+			output.StopSpinner(s)
+			return err
+		}
+	}
+	to := opts.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if !opts.From.IsZero() && !opts.From.Before(to) {
+		output.StopSpinner(s)
+		return fmt.Errorf("--from must be before --to")
+	}
+	var fromMs int64
+	if !opts.From.IsZero() {
+		fromMs = opts.From.UnixMilli()
+	}
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = defaultExportConcurrency
+	}
+
+	logs, err := fetchAllResponseLogs(ctx, client, monitorId, fromMs, to.UnixMilli(), concurrency)
+	if err != nil {
+		output.StopSpinner(s)
+		return output.FormatError(err, "response logs", monitorId)
+	}
+
+	var rows []ResponseLogDetailOutput
+	var entries []ResponseLogEntry
+	for _, l := range logs {
+		e := toResponseLogEntry(l)
+		if !opts.Filter.match(e) {
+			continue
+		}
+		entries = append(entries, e)
+		rows = append(rows, ResponseLogDetailOutput{
+			ID:            e.ID,
+			MonitorID:     e.MonitorID,
+			StatusCode:    e.StatusCode,
+			Latency:       e.Latency,
+			Region:        e.Region,
+			RequestStatus: e.RequestStatus,
+			Trigger:       e.Trigger,
+			Timestamp:     e.Timestamp,
+			Timing:        toTimingOutput(l),
+		})
+	}
+
+	if opts.Output == "" {
+		output.StopSpinner(s)
+		return printResponseLogs(entries, nil)
+	}
+
+	if opts.Detail {
+		if err := enrichResponseLogs(ctx, client, monitorId, rows, concurrency); err != nil {
+			output.StopSpinner(s)
+			return err
+		}
+	}
+	output.StopSpinner(s)
+
+	f, err := os.Create(opts.Output)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case exportCSV:
+		err = writeExportCSV(f, rows, opts.Detail)
+	default:
+		err = writeExportNDJSON(f, rows)
+	}
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if !output.IsQuiet() {
+		fmt.Fprintf(os.Stderr, "Exported %d response logs to %s\n", len(rows), opts.Output)
+	}
+	return nil
+}
+
+func ExportMonitorResponseLogsWithHTTPClient(ctx context.Context, httpClient *http.Client, apiKey string, monitorId string, opts LogExportOptions) error {
+	client := NewMonitorClientWithHTTPClient(httpClient, apiKey)
+	return ExportMonitorResponseLogs(ctx, client, monitorId, opts, nil)
+}
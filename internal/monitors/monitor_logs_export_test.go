@@ -0,0 +1,129 @@
+package monitors_test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openstatusHQ/cli/internal/monitors"
+)
+
+// pagedLogsInterceptor serves total response logs in pages of 100,
+// rate limiting the first request.
+func pagedLogsInterceptor(total int, calls *atomic.Int32) *interceptorHTTPClient {
+	return &interceptorHTTPClient{
+		f: func(req *http.Request) (*http.Response, error) {
+			if calls.Add(1) == 1 {
+				return jsonResponse(http.StatusTooManyRequests, `{"code":"resource_exhausted","message":"rate limited"}`), nil
+			}
+			var body struct {
+				Offset int `json:"offset"`
+			}
+			b, _ := io.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &body)
+
+			var logs []string
+			for i := body.Offset; i < min(body.Offset+100, total); i++ {
+				logs = append(logs, fmt.Sprintf(`{"id":"log-%d","latency":%d,"statusCode":200,"monitorId":"1","requestStatus":"HTTP_RESPONSE_LOG_REQUEST_STATUS_SUCCESS","region":"REGION_FLY_IAD","trigger":"HTTP_RESPONSE_LOG_TRIGGER_CRON","timestamp":"%d"}`, i, i, 1715000000000+int64(i)))
+			}
+			hasMore := body.Offset+100 < total
+			return jsonResponse(http.StatusOK, fmt.Sprintf(`{"logs":[%s],"pagination":{"limit":100,"offset":%d,"hasMore":%t,"nextOffset":%d}}`,
+				strings.Join(logs, ","), body.Offset, hasMore, body.Offset+100)), nil
+		},
+	}
+}
+
+func Test_exportMonitorResponseLogs(t *testing.T) {
+	delay := *monitors.ExportRetryDelay
+	*monitors.ExportRetryDelay = time.Millisecond
+	t.Cleanup(func() { *monitors.ExportRetryDelay = delay })
+
+	t.Run("Writes every page to CSV", func(t *testing.T) {
+		var calls atomic.Int32
+		path := filepath.Join(t.TempDir(), "logs.csv")
+		err := monitors.ExportMonitorResponseLogsWithHTTPClient(context.Background(), pagedLogsInterceptor(250, &calls).GetHTTPClient(), "test-token", "1", monitors.LogExportOptions{
+			Output:      path,
+			Concurrency: 2,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		records, err := csv.NewReader(f).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != 251 {
+			t.Fatalf("Expected a header and 250 rows, got %d records", len(records))
+		}
+		if records[0][0] != "id" || records[1][0] != "log-0" || records[250][0] != "log-249" {
+			t.Errorf("Unexpected rows: %v, %v, %v", records[0], records[1], records[250])
+		}
+	})
+
+	t.Run("Writes NDJSON", func(t *testing.T) {
+		var calls atomic.Int32
+		path := filepath.Join(t.TempDir(), "logs.ndjson")
+		err := monitors.ExportMonitorResponseLogsWithHTTPClient(context.Background(), pagedLogsInterceptor(3, &calls).GetHTTPClient(), "test-token", "1", monitors.LogExportOptions{
+			Output: path,
+			Filter: monitors.ResponseLogFilter{Regions: []string{"iad"}},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("Expected 3 lines, got %d:\n%s", len(lines), b)
+		}
+		var row struct {
+			ID     string `json:"id"`
+			Region string `json:"region"`
+		}
+		if err := json.Unmarshal([]byte(lines[2]), &row); err != nil || row.ID != "log-2" || row.Region != "iad" {
+			t.Errorf("Unexpected last line %s (%v)", lines[2], err)
+		}
+	})
+
+	t.Run("Unknown output extension returns error", func(t *testing.T) {
+		var calls atomic.Int32
+		err := monitors.ExportMonitorResponseLogsWithHTTPClient(context.Background(), pagedLogsInterceptor(3, &calls).GetHTTPClient(), "test-token", "1", monitors.LogExportOptions{
+			Output: filepath.Join(t.TempDir(), "logs.txt"),
+		})
+		if err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+}
+
+func Test_parseSince(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]time.Duration{"7d": 7 * 24 * time.Hour, "12h": 12 * time.Hour, "30m": 30 * time.Minute} {
+		if got, err := monitors.ParseSince(in); err != nil || got != want {
+			t.Errorf("ParseSince(%q) = %s, %v, want %s", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "d", "-1d", "7w", "0h"} {
+		if _, err := monitors.ParseSince(in); err == nil {
+			t.Errorf("ParseSince(%q) = nil error, want error", in)
+		}
+	}
+}
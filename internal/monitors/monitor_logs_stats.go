@@ -0,0 +1,354 @@
+package monitors
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"buf.build/gen/go/openstatus/api/connectrpc/gosimple/openstatus/monitor/v1/monitorv1connect"
+	monitorv1 "buf.build/gen/go/openstatus/api/protocolbuffers/go/openstatus/monitor/v1"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/urfave/cli/v3"
+
+	"github.com/openstatusHQ/cli/internal/auth"
+	output "github.com/openstatusHQ/cli/internal/cli"
+)
+
+// logStatsDimensions are the values of --by, with their column header.
+var logStatsDimensions = map[string]string{
+	"region":      "Region",
+	"status-code": "Status Code",
+	"status":      "Status",
+	"trigger":     "Trigger",
+	"hour":        "Hour",
+	"day":         "Day",
+}
+
+// TimingStatsOutput holds the median of every timing phase, in ms.
+type TimingStatsOutput struct {
+	DNS      int64 `json:"dns_ms"`
+	Connect  int64 `json:"connect_ms"`
+	TLS      int64 `json:"tls_ms"`
+	TTFB     int64 `json:"ttfb_ms"`
+	Transfer int64 `json:"transfer_ms"`
+}
+
+type LogStatsGroup struct {
+	Key              string             `json:"key"`
+	Count            int                `json:"count"`
+	Errors           int                `json:"errors"`
+	Degraded         int                `json:"degraded"`
+	ErrorRatePercent float64            `json:"error_rate_percent"`
+	P50              int64              `json:"p50_ms"`
+	P90              int64              `json:"p90_ms"`
+	P99              int64              `json:"p99_ms"`
+	Timing           *TimingStatsOutput `json:"timing,omitempty"`
+}
+
+type LogStatsOutput struct {
+	MonitorID string          `json:"monitor_id"`
+	By        string          `json:"by"`
+	From      string          `json:"from"`
+	To        string          `json:"to"`
+	Total     LogStatsGroup   `json:"total"`
+	Groups    []LogStatsGroup `json:"groups"`
+}
+
+// LogStatsOptions configures monitors logs stats.
+type LogStatsOptions struct {
+	By          string
+	From, To    time.Time
+	Filter      ResponseLogFilter
+	Concurrency int
+}
+
+func (o LogStatsOptions) validate() error {
+	if _, ok := logStatsDimensions[o.By]; !ok {
+		return fmt.Errorf("invalid --by %q, expected one of: region, status-code, status, trigger, hour, day", o.By)
+	}
+	if !o.To.IsZero() && o.From.After(o.To) {
+		return fmt.Errorf("the start of the time window is after its end")
+	}
+	return o.Filter.validate()
+}
+
+// logStatsAccumulator collects the latencies and timings of a group.
+type logStatsAccumulator struct {
+	group     LogStatsGroup
+	latencies []int64
+	timings   [5][]int64
+}
+
+func (a *logStatsAccumulator) add(l *monitorv1.HTTPResponseLog, status string) {
+	a.group.Count++
+	switch status {
+	case "error":
+		a.group.Errors++
+	case "degraded":
+		a.group.Degraded++
+	}
+	a.latencies = append(a.latencies, int64(l.GetLatency()))
+	if t := toTimingOutput(l); t != nil {
+		for i, ms := range []int32{t.DNS, t.Connect, t.TLS, t.TTFB, t.Transfer} {
+			a.timings[i] = append(a.timings[i], int64(ms))
+		}
+	}
+}
+
+func (a *logStatsAccumulator) finish() LogStatsGroup {
+	g := a.group
+	if g.Count == 0 {
+		return g
+	}
+	g.ErrorRatePercent = 100 * float64(g.Errors) / float64(g.Count)
+	slices.Sort(a.latencies)
+	g.P50 = percentile(a.latencies, 50)
+	g.P90 = percentile(a.latencies, 90)
+	g.P99 = percentile(a.latencies, 99)
+	if len(a.timings[0]) > 0 {
+		var medians [5]int64
+		for i, phase := range a.timings {
+			slices.Sort(phase)
+			medians[i] = percentile(phase, 50)
+		}
+		g.Timing = &TimingStatsOutput{DNS: medians[0], Connect: medians[1], TLS: medians[2], TTFB: medians[3], Transfer: medians[4]}
+	}
+	return g
+}
+
+func logStatsKey(by string, l *monitorv1.HTTPResponseLog, e ResponseLogEntry) string {
+	switch by {
+	case "status-code":
+		if e.StatusCode == 0 {
+			return "none"
+		}
+		return strconv.Itoa(int(e.StatusCode))
+	case "status":
+		return e.RequestStatus
+	case "trigger":
+		return e.Trigger
+	case "hour":
+		return time.UnixMilli(l.GetTimestamp()).UTC().Truncate(time.Hour).Format(time.RFC3339)
+	case "day":
+		return time.UnixMilli(l.GetTimestamp()).UTC().Truncate(24 * time.Hour).Format(time.RFC3339)
+	default:
+		return e.Region
+	}
+}
+
+// computeLogStats groups the logs matching filter. Time buckets are ordered
+// chronologically, other groups by decreasing count.
+func computeLogStats(logs []*monitorv1.HTTPResponseLog, by string, filter ResponseLogFilter) ([]LogStatsGroup, LogStatsGroup) {
+	var total logStatsAccumulator
+	total.group.Key = "all"
+	groups := map[string]*logStatsAccumulator{}
+	for _, l := range logs {
+		e := toResponseLogEntry(l)
+		if !filter.match(e) {
+			continue
+		}
+		key := logStatsKey(by, l, e)
+		a, ok := groups[key]
+		if !ok {
+			a = &logStatsAccumulator{group: LogStatsGroup{Key: key}}
+			groups[key] = a
+		}
+		a.add(l, e.RequestStatus)
+		total.add(l, e.RequestStatus)
+	}
+
+	out := make([]LogStatsGroup, 0, len(groups))
+	for _, a := range groups {
+		out = append(out, a.finish())
+	}
+	slices.SortFunc(out, func(a, b LogStatsGroup) int {
+		if by == "hour" || by == "day" {
+			return cmp.Compare(a.Key, b.Key)
+		}
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Key, b.Key))
+	})
+	return out, total.finish()
+}
+
+// MonitorResponseLogStats fetches every response log of the window and
+// aggregates them.
+func MonitorResponseLogStats(ctx context.Context, client monitorv1connect.MonitorServiceClient, monitorId string, opts LogStatsOptions) (LogStatsOutput, error) {
+	if err := opts.validate(); err != nil {
+		return LogStatsOutput{}, err
+	}
+	to := opts.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	logs, err := fetchAllResponseLogs(ctx, client, monitorId, opts.From.UnixMilli(), to.UnixMilli(), opts.Concurrency)
+	if err != nil {
+		return LogStatsOutput{}, output.FormatError(err, "response logs", monitorId)
+	}
+	groups, total := computeLogStats(logs, opts.By, opts.Filter)
+	return LogStatsOutput{
+		MonitorID: monitorId,
+		By:        opts.By,
+		From:      opts.From.UTC().Format(time.RFC3339),
+		To:        to.UTC().Format(time.RFC3339),
+		Total:     total,
+		Groups:    groups,
+	}, nil
+}
+
+func MonitorResponseLogStatsWithHTTPClient(ctx context.Context, httpClient *http.Client, apiKey string, monitorId string, opts LogStatsOptions) (LogStatsOutput, error) {
+	client := NewMonitorClientWithHTTPClient(httpClient, apiKey)
+	return MonitorResponseLogStats(ctx, client, monitorId, opts)
+}
+
+func writeLogStats(w io.Writer, stats LogStatsOutput) {
+	if stats.Total.Count == 0 {
+		fmt.Fprintln(w, "No response logs found")
+		return
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	columnFmt := color.New(color.FgYellow).SprintfFunc()
+
+	tbl := table.New(logStatsDimensions[stats.By], "Checks", "Errors", "Error Rate", "P50 (ms)", "P90 (ms)", "P99 (ms)", "DNS", "Connect", "TLS", "TTFB")
+	tbl.WithHeaderFormatter(headerFmt).WithFirstColumnFormatter(columnFmt).WithWriter(w)
+	for _, g := range append(slices.Clip(stats.Groups), stats.Total) {
+		key := g.Key
+		if stats.By == "hour" || stats.By == "day" {
+			key = output.FormatTimestamp(key)
+		}
+		row := []any{key, g.Count, g.Errors, fmt.Sprintf("%.2f%%", g.ErrorRatePercent), g.P50, g.P90, g.P99}
+		if t := g.Timing; t != nil {
+			row = append(row, t.DNS, t.Connect, t.TLS, t.TTFB)
+		} else {
+			row = append(row, "-", "-", "-", "-")
+		}
+		tbl.AddRow(row...)
+	}
+	tbl.Print()
+	fmt.Fprintf(w, "\n%d checks from %s to %s. Timing phases are medians in ms.\n",
+		stats.Total.Count, output.FormatTimestamp(stats.From), output.FormatTimestamp(stats.To))
+}
+
+func GetMonitorLogsStatsCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "stats",
+		Usage: "Aggregate response logs by region, status code, status, trigger or time",
+		UsageText: `openstatus monitors logs stats <MonitorID|name>
+  openstatus monitors logs stats api-prod --since 24h
+  openstatus monitors logs stats api-prod --by hour --region syd
+  openstatus monitors logs stats api-prod --by status-code --since 7d --json`,
+		Description: `Fetches every response log of the time window and reports, per group, the
+number of checks, the error rate, p50/p90/p99 latency and the median of
+every timing phase (DNS, connect, TLS, TTFB).
+
+--by groups by region (default), status-code, status, trigger, hour or day.
+The window defaults to the last 24 hours.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "access-token",
+				Usage:   "OpenStatus API Access Token",
+				Aliases: []string{"t"},
+				Sources: cli.EnvVars("OPENSTATUS_API_TOKEN"),
+			},
+			ResolveEnvFlag(),
+			&cli.StringFlag{
+				Name:  "by",
+				Usage: "Group by region, status-code, status, trigger, hour or day",
+				Value: "region",
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Start the time window this long ago, e.g. 7d, 12h or 30m",
+				Value: "24h",
+			},
+			&cli.StringFlag{
+				Name:  "from",
+				Usage: "Start of time window (RFC 3339 format), instead of --since",
+			},
+			&cli.StringFlag{
+				Name:  "to",
+				Usage: "End of time window (RFC 3339 format)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "region",
+				Usage: "Only count logs from this region (repeatable)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "status",
+				Usage: "Only count logs with this request status: success, error or degraded (repeatable)",
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Usage: "Number of requests in flight",
+				Value: defaultExportConcurrency,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			opts := LogStatsOptions{
+				By: cmd.String("by"),
+				Filter: ResponseLogFilter{
+					Regions:  cmd.StringSlice("region"),
+					Statuses: cmd.StringSlice("status"),
+				},
+				Concurrency: int(cmd.Int("concurrency")),
+			}
+			var err error
+			if from := cmd.String("from"); from != "" {
+				if cmd.IsSet("since") {
+					return cli.Exit("--since and --from cannot be used together", 1)
+				}
+				if opts.From, err = time.Parse(time.RFC3339, from); err != nil {
+					return cli.Exit(fmt.Sprintf("invalid RFC 3339 timestamp %q: %v", from, err), 1)
+				}
+			} else {
+				d, err := parseSince(cmd.String("since"))
+				if err != nil {
+					return cli.Exit(err.Error(), 1)
+				}
+				opts.From = time.Now().Add(-d)
+			}
+			if to := cmd.String("to"); to != "" {
+				if opts.To, err = time.Parse(time.RFC3339, to); err != nil {
+					return cli.Exit(fmt.Sprintf("invalid RFC 3339 timestamp %q: %v", to, err), 1)
+				}
+			}
+			if err := opts.validate(); err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+
+			apiKey, err := auth.ResolveAccessToken(cmd)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			client := NewMonitorClient(apiKey)
+			monitorId, err := ResolveMonitorID(ctx, client, cmd.Args().Get(0), cmd.String("env"))
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			if monitorId == "" {
+				return cli.Exit("Usage: openstatus monitors logs stats <MonitorID|name>", 1)
+			}
+
+			s := output.StartSpinner("Fetching response logs...")
+			stats, err := MonitorResponseLogStats(ctx, client, monitorId, opts)
+			output.StopSpinner(s)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			if output.IsJSONOutput() {
+				return output.PrintJSON(stats)
+			}
+			if stats.Total.Count == 0 && output.IsQuiet() {
+				return nil
+			}
+			writeLogStats(os.Stdout, stats)
+			return nil
+		},
+	}
+}
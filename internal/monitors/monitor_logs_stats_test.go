@@ -0,0 +1,106 @@
+package monitors_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	monitorv1 "buf.build/gen/go/openstatus/api/protocolbuffers/go/openstatus/monitor/v1"
+
+	"github.com/openstatusHQ/cli/internal/monitors"
+)
+
+func statsLog(at time.Time, region monitorv1.Region, code, latency, ttfb int32, failed bool) *monitorv1.HTTPResponseLog {
+	l := &monitorv1.HTTPResponseLog{}
+	l.SetTimestamp(at.UnixMilli())
+	l.SetRegion(region)
+	l.SetStatusCode(code)
+	l.SetLatency(latency)
+	l.SetTrigger(monitorv1.HTTPResponseLogTrigger_HTTP_RESPONSE_LOG_TRIGGER_CRON)
+	l.SetRequestStatus(monitorv1.HTTPResponseLogRequestStatus_HTTP_RESPONSE_LOG_REQUEST_STATUS_SUCCESS)
+	if failed {
+		l.SetRequestStatus(monitorv1.HTTPResponseLogRequestStatus_HTTP_RESPONSE_LOG_REQUEST_STATUS_ERROR)
+	}
+	if ttfb > 0 {
+		t := &monitorv1.HTTPResponseTiming{}
+		t.SetDns(5)
+		t.SetConnect(10)
+		t.SetTls(20)
+		t.SetTtfb(ttfb)
+		l.SetTiming(t)
+	}
+	return l
+}
+
+func statsFixture() []*monitorv1.HTTPResponseLog {
+	day := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	syd, iad := monitorv1.Region_REGION_FLY_SYD, monitorv1.Region_REGION_FLY_IAD
+	return []*monitorv1.HTTPResponseLog{
+		statsLog(day.Add(9*time.Hour), syd, 200, 900, 800, false),
+		statsLog(day.Add(9*time.Hour+time.Minute), syd, 500, 1200, 1100, true),
+		statsLog(day.Add(10*time.Hour), syd, 200, 1000, 0, false),
+		statsLog(day.Add(9*time.Hour), iad, 200, 100, 60, false),
+		statsLog(day.Add(10*time.Hour), iad, 0, 30000, 0, true),
+	}
+}
+
+func Test_computeLogStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("by region", func(t *testing.T) {
+		groups, total := monitors.ComputeLogStats(statsFixture(), "region", monitors.ResponseLogFilter{})
+		if len(groups) != 2 || groups[0].Key != "syd" || groups[1].Key != "iad" {
+			t.Fatalf("expected syd then iad by count, got %+v", groups)
+		}
+		syd := groups[0]
+		if syd.Count != 3 || syd.Errors != 1 || syd.P50 != 1000 || syd.P99 != 1200 {
+			t.Errorf("unexpected syd stats %+v", syd)
+		}
+		if syd.ErrorRatePercent < 33.3 || syd.ErrorRatePercent > 33.4 {
+			t.Errorf("syd error rate = %v", syd.ErrorRatePercent)
+		}
+		if syd.Timing == nil || syd.Timing.TTFB != 800 || syd.Timing.DNS != 5 {
+			t.Errorf("unexpected syd timing %+v", syd.Timing)
+		}
+		if total.Key != "all" || total.Count != 5 || total.Errors != 2 {
+			t.Errorf("unexpected total %+v", total)
+		}
+	})
+
+	t.Run("by hour is chronological", func(t *testing.T) {
+		groups, _ := monitors.ComputeLogStats(statsFixture(), "hour", monitors.ResponseLogFilter{Regions: []string{"syd"}})
+		if len(groups) != 2 || groups[0].Key != "2026-05-01T09:00:00Z" || groups[0].Count != 2 || groups[1].Count != 1 {
+			t.Errorf("unexpected hourly stats %+v", groups)
+		}
+		if groups[1].Timing != nil {
+			t.Errorf("expected no timing without timed logs, got %+v", groups[1].Timing)
+		}
+	})
+
+	t.Run("by status code", func(t *testing.T) {
+		groups, _ := monitors.ComputeLogStats(statsFixture(), "status-code", monitors.ResponseLogFilter{})
+		keys := make([]string, len(groups))
+		for i, g := range groups {
+			keys[i] = g.Key
+		}
+		if got := strings.Join(keys, ","); got != "200,500,none" {
+			t.Errorf("keys = %s, want 200,500,none", got)
+		}
+	})
+}
+
+func Test_writeLogStats(t *testing.T) {
+	t.Parallel()
+	groups, total := monitors.ComputeLogStats(statsFixture(), "day", monitors.ResponseLogFilter{})
+	var buf bytes.Buffer
+	monitors.WriteLogStats(&buf, monitors.LogStatsOutput{
+		By: "day", From: "2026-04-30T12:00:00Z", To: "2026-05-01T12:00:00Z", Groups: groups, Total: total,
+	})
+	out := buf.String()
+	for _, want := range []string{"Day", "2026-05-01 00:00 UTC", "40.00%", "all", "5 checks from 2026-04-30 12:00 UTC to 2026-05-01 12:00 UTC"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -3,11 +3,16 @@ package monitors_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/openstatusHQ/cli/internal/monitors"
 )
@@ -36,7 +41,7 @@ func Test_listMonitorResponseLogs(t *testing.T) {
 		t.Cleanup(func() {
 			log.SetOutput(os.Stdout)
 		})
-		err := monitors.ListMonitorResponseLogsWithHTTPClient(context.Background(), interceptor.GetHTTPClient(), "test-token", "1", 0, 0, "", "")
+		err := monitors.ListMonitorResponseLogsWithHTTPClient(context.Background(), interceptor.GetHTTPClient(), "test-token", "1", 0, 0, "", "", monitors.ResponseLogFilter{})
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -63,7 +68,7 @@ func Test_listMonitorResponseLogs(t *testing.T) {
 		t.Cleanup(func() {
 			log.SetOutput(os.Stdout)
 		})
-		err := monitors.ListMonitorResponseLogsWithHTTPClient(context.Background(), interceptor.GetHTTPClient(), "test-token", "1", 0, 0, "", "")
+		err := monitors.ListMonitorResponseLogsWithHTTPClient(context.Background(), interceptor.GetHTTPClient(), "test-token", "1", 0, 0, "", "", monitors.ResponseLogFilter{})
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -90,7 +95,7 @@ func Test_listMonitorResponseLogs(t *testing.T) {
 		t.Cleanup(func() {
 			log.SetOutput(os.Stdout)
 		})
-		err := monitors.ListMonitorResponseLogsWithHTTPClient(context.Background(), interceptor.GetHTTPClient(), "test-token", "1", 0, 0, "", "")
+		err := monitors.ListMonitorResponseLogsWithHTTPClient(context.Background(), interceptor.GetHTTPClient(), "test-token", "1", 0, 0, "", "", monitors.ResponseLogFilter{})
 		if err == nil {
 			t.Error("Expected error, got nil")
 		}
@@ -103,9 +108,96 @@ func Test_listMonitorResponseLogs(t *testing.T) {
 			},
 		}
 
-		err := monitors.ListMonitorResponseLogsWithHTTPClient(context.Background(), interceptor.GetHTTPClient(), "test-token", "", 0, 0, "", "")
+		err := monitors.ListMonitorResponseLogsWithHTTPClient(context.Background(), interceptor.GetHTTPClient(), "test-token", "", 0, 0, "", "", monitors.ResponseLogFilter{})
 		if err == nil {
 			t.Error("Expected error for empty monitor ID, got nil")
 		}
 	})
 }
+
+func Test_followMonitorResponseLogs(t *testing.T) {
+	t.Parallel()
+
+	logJSON := func(id, status, region string, ts int64) string {
+		return fmt.Sprintf(`{"id":%q,"latency":100,"statusCode":200,"monitorId":"1","requestStatus":"HTTP_RESPONSE_LOG_REQUEST_STATUS_%s","region":"REGION_FLY_%s","trigger":"HTTP_RESPONSE_LOG_TRIGGER_CRON","timestamp":"%d"}`, id, status, region, ts)
+	}
+	polls := []string{
+		`{"logs":[` + logJSON("log-2", "ERROR", "FRA", 2000) + `,` + logJSON("log-1", "SUCCESS", "IAD", 1000) + `],"pagination":{"hasMore":false}}`,
+		`{"logs":[` + logJSON("log-2", "ERROR", "FRA", 2000) + `,` + logJSON("log-3", "ERROR", "IAD", 3000) + `],"pagination":{"hasMore":false}}`,
+		`{"logs":[` + logJSON("log-3", "ERROR", "IAD", 3000) + `,` + logJSON("log-4", "DEGRADED", "FRA", 4000) + `],"pagination":{"hasMore":false}}`,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var calls int
+	interceptor := &interceptorHTTPClient{
+		f: func(req *http.Request) (*http.Response, error) {
+			if calls == len(polls)-1 {
+				cancel()
+			}
+			body := polls[min(calls, len(polls)-1)]
+			calls++
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+			}, nil
+		},
+	}
+
+	var out bytes.Buffer
+	err := monitors.FollowMonitorResponseLogsWithHTTPClient(ctx, interceptor.GetHTTPClient(), "test-token", "1", monitors.FollowOptions{
+		From:     time.UnixMilli(0),
+		Interval: time.Millisecond,
+		Filter:   monitors.ResponseLogFilter{Statuses: []string{"error", "degraded"}},
+		JSON:     true,
+	}, &out)
+	if err != nil {
+		t.Fatalf("Expected no error on cancellation, got %v", err)
+	}
+
+	var ids []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var entry struct {
+			ID     string `json:"id"`
+			Region string `json:"region"`
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("line %q is not JSON: %v", line, err)
+		}
+		ids = append(ids, entry.ID)
+	}
+	if want := []string{"log-2", "log-3"}; !slices.Equal(ids, want) {
+		// The third poll may or may not be printed before cancellation.
+		if want = append(want, "log-4"); !slices.Equal(ids, want) {
+			t.Errorf("Expected logs %v, got %v", want, ids)
+		}
+	}
+}
+
+func Test_followMonitorResponseLogs_firstPollError(t *testing.T) {
+	t.Parallel()
+
+	interceptor := &interceptorHTTPClient{
+		f: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(strings.NewReader(`{"code":"not_found","message":"monitor not found"}`)),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+			}, nil
+		},
+	}
+
+	err := monitors.FollowMonitorResponseLogsWithHTTPClient(context.Background(), interceptor.GetHTTPClient(), "test-token", "1", monitors.FollowOptions{Interval: time.Millisecond}, io.Discard)
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	err = monitors.FollowMonitorResponseLogsWithHTTPClient(context.Background(), interceptor.GetHTTPClient(), "test-token", "1", monitors.FollowOptions{
+		Interval: time.Millisecond,
+		Filter:   monitors.ResponseLogFilter{Statuses: []string{"failed"}},
+	}, io.Discard)
+	if err == nil {
+		t.Error("Expected error for invalid status filter, got nil")
+	}
+}
@@ -0,0 +1,108 @@
+package monitors
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	output "github.com/openstatusHQ/cli/internal/cli"
+	"github.com/openstatusHQ/cli/internal/config"
+)
+
+// applyMoves returns a copy of the lock with the moved declarations applied
+// in order, along with the moves that actually renamed an entry. Moves that
+// were already applied are skipped, so declarations can stay in the file.
+func applyMoves(lock config.MonitorsLock, moves []config.Move, configData config.Monitors) (config.MonitorsLock, []config.Move, error) {
+	if len(moves) == 0 {
+		return lock, nil, nil
+	}
+
+	moved := make(config.MonitorsLock, len(lock))
+	for k, v := range lock {
+		moved[k] = v
+	}
+
+	seen := make(map[string]bool, len(moves))
+	var applied []config.Move
+	for _, m := range moves {
+		if m.From == "" || m.To == "" {
+			return nil, nil, fmt.Errorf("moved entries require both from and to")
+		}
+		if seen[m.From] {
+			return nil, nil, fmt.Errorf("monitor %q is moved more than once", m.From)
+		}
+		seen[m.From] = true
+		if _, ok := configData[m.To]; !ok {
+			return nil, nil, fmt.Errorf("cannot move %q to %q: %q is not defined in the configuration", m.From, m.To, m.To)
+		}
+		ok, err := moved.Move(m.From, m.To)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			applied = append(applied, m)
+		}
+	}
+	return moved, applied, nil
+}
+
+func GetMonitorMoveCmd() *cli.Command {
+	monitorMoveCmd := cli.Command{
+		Name:  "mv",
+		Usage: "Rename a monitor in the lock file",
+		UsageText: `openstatus monitors mv <old-name> <new-name>
+  openstatus monitors mv api-prod api-production`,
+		Description: `Renames the logical name of a monitor in openstatus.lock while keeping its
+monitor ID, so that renaming its key in openstatus.yaml updates the existing
+monitor instead of deleting it and creating a new one with an empty history.
+
+Alternatively, declare the rename in openstatus.yaml and let apply do it:
+
+  moved:
+    - from: api-prod
+      to: api-production`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "env",
+				Usage: "Environment name: renames the monitor in openstatus.<env>.lock",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			from := cmd.Args().Get(0)
+			to := cmd.Args().Get(1)
+			if from == "" || to == "" {
+				fmt.Fprintln(os.Stderr, "Usage: openstatus monitors mv <old-name> <new-name>")
+				return cli.Exit("old and new names are required", 1)
+			}
+
+			lockPath := config.LockFilePath(cmd.String("env"))
+			lock, err := config.ReadLockFile(lockPath)
+			if err != nil {
+				return cli.Exit("Unable to read lock file", 1)
+			}
+			entry, ok := lock[from]
+			if !ok {
+				return cli.Exit(fmt.Sprintf("Monitor %q not found in %s", from, lockPath), 1)
+			}
+			if _, err := lock.Move(from, to); err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			if err := config.WriteLockFile(lockPath, lock); err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+
+			if output.IsJSONOutput() {
+				return output.PrintJSON(struct {
+					config.Move
+					ID int `json:"id"`
+				}{config.Move{From: from, To: to}, entry.ID})
+			}
+			fmt.Printf("Moved %s to %s (id %d) in %s\n", from, to, entry.ID, lockPath)
+			fmt.Printf("Make sure openstatus.yaml defines the monitor as %q before running 'openstatus monitors apply'\n", to)
+			return nil
+		},
+	}
+	return &monitorMoveCmd
+}
@@ -0,0 +1,277 @@
+package monitors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/openstatusHQ/cli/internal/config"
+)
+
+const (
+	planActionCreate  = "create"
+	planActionUpdate  = "update"
+	planActionDelete  = "delete"
+	planActionReplace = "replace"
+)
+
+// localOnlyFields are config fields that are never sent to the API, so a
+// change to them only needs the lock file to be updated.
+var localOnlyFields = map[string]bool{
+	"preventDestroy": true,
+	"team":           true,
+}
+
+// Plan describes the changes apply would make, keyed by logical monitor name.
+type Plan struct {
+	Moved   []config.Move `json:"moved,omitempty"`
+	Changes []PlanEntry   `json:"changes"`
+	Summary PlanSummary   `json:"summary"`
+}
+
+type PlanEntry struct {
+	Name   string        `json:"name"`
+	Action string        `json:"action"`
+	ID     int           `json:"id,omitempty"`
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+type PlanSummary struct {
+	Create  int `json:"create"`
+	Update  int `json:"update"`
+	Replace int `json:"replace"`
+	Delete  int `json:"delete"`
+}
+
+// HasChanges reports whether applying the plan would touch any monitor.
+// Moves only rename lock entries and are not counted.
+func (p Plan) HasChanges() bool {
+	return len(p.Changes) > 0
+}
+
+// buildPlan computes the changes between the lock file and the config data without making API calls.
+// A monitor whose kind changed cannot be updated in place and is planned as a replacement.
+func buildPlan(lock config.MonitorsLock, configData config.Monitors) Plan {
+	var plan Plan
+	for name, configValue := range configData {
+		value, exist := lock[name]
+		if !exist {
+			plan.Changes = append(plan.Changes, PlanEntry{
+				Name:   name,
+				Action: planActionCreate,
+			})
+			continue
+		}
+		if fields := diffMonitor(value.Monitor, configValue); len(fields) > 0 {
+			action := planActionUpdate
+			if value.Monitor.Kind != configValue.Kind {
+				action = planActionReplace
+			}
+			plan.Changes = append(plan.Changes, PlanEntry{
+				Name:   name,
+				Action: action,
+				ID:     value.ID,
+				Fields: fields,
+			})
+		}
+	}
+	for name, value := range lock {
+		if _, exist := configData[name]; !exist {
+			plan.Changes = append(plan.Changes, PlanEntry{
+				Name:   name,
+				Action: planActionDelete,
+				ID:     value.ID,
+			})
+		}
+	}
+	sort.Slice(plan.Changes, func(i, j int) bool {
+		return plan.Changes[i].Name < plan.Changes[j].Name
+	})
+	plan.Summary = summarizePlan(plan.Changes)
+	return plan
+}
+
+// filter returns a copy of the plan keeping only the entries for which keep returns true.
+func (p Plan) filter(keep func(PlanEntry) bool) Plan {
+	var filtered Plan
+	for _, entry := range p.Changes {
+		if keep(entry) {
+			filtered.Changes = append(filtered.Changes, entry)
+		}
+	}
+	filtered.Summary = summarizePlan(filtered.Changes)
+	return filtered
+}
+
+func summarizePlan(changes []PlanEntry) PlanSummary {
+	var summary PlanSummary
+	for _, entry := range changes {
+		switch entry.Action {
+		case planActionCreate:
+			summary.Create++
+		case planActionUpdate:
+			summary.Update++
+		case planActionReplace:
+			summary.Replace++
+		case planActionDelete:
+			summary.Delete++
+		}
+	}
+	return summary
+}
+
+// localOnly reports whether every changed field is a CLI-only setting.
+func localOnly(fields []FieldChange) bool {
+	for _, f := range fields {
+		if !localOnlyFields[f.Field] {
+			return false
+		}
+	}
+	return len(fields) > 0
+}
+
+// monitorsEqual reports whether two monitor definitions are equivalent.
+// Nil and empty maps or slices are treated as equal, since YAML drops both.
+func monitorsEqual(a, b config.Monitor) bool {
+	return cmp.Equal(a, b, cmpopts.EquateEmpty())
+}
+
+// diffMonitor returns the field-level differences between two monitor definitions.
+func diffMonitor(old, updated config.Monitor) []FieldChange {
+	r := &fieldDiffReporter{seen: map[string]bool{}}
+	cmp.Equal(old, updated, cmpopts.EquateEmpty(), cmp.Reporter(r))
+	return r.changes
+}
+
+// fieldDiffReporter is a cmp.Reporter that collects differences as
+// YAML-style field paths. Slices are reported as a whole so that a
+// reordered region list or assertion set reads as one change.
+type fieldDiffReporter struct {
+	path    cmp.Path
+	seen    map[string]bool
+	changes []FieldChange
+}
+
+func (r *fieldDiffReporter) PushStep(ps cmp.PathStep) {
+	r.path = append(r.path, ps)
+}
+
+func (r *fieldDiffReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+func (r *fieldDiffReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+	name, step := fieldPath(r.path)
+	if r.seen[name] {
+		return
+	}
+	r.seen[name] = true
+	vx, vy := step.Values()
+	r.changes = append(r.changes, FieldChange{
+		Field: name,
+		Old:   planValue(vx),
+		New:   planValue(vy),
+	})
+}
+
+// fieldPath converts a cmp path into a dotted field name using the JSON tag
+// names, and returns the step whose values should be reported.
+func fieldPath(path cmp.Path) (string, cmp.PathStep) {
+	var parts []string
+	for i, step := range path {
+		switch s := step.(type) {
+		case cmp.StructField:
+			parent := path[i-1].Type()
+			if parent.Kind() == reflect.Pointer {
+				parent = parent.Elem()
+			}
+			parts = append(parts, jsonFieldName(parent.Field(s.Index())))
+		case cmp.MapIndex:
+			parts = append(parts, fmt.Sprint(s.Key()))
+		case cmp.SliceIndex:
+			return strings.Join(parts, "."), path[i-1]
+		}
+	}
+	return strings.Join(parts, "."), path.Last()
+}
+
+func jsonFieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return strings.ToLower(f.Name[:1]) + f.Name[1:]
+	}
+	return name
+}
+
+func planValue(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+	}
+	if !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func formatPlanValue(v any) string {
+	if v == nil {
+		return "(none)"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// renderPlan prints a Terraform-style plan to w.
+func renderPlan(w io.Writer, plan Plan) {
+	for _, m := range plan.Moved {
+		fmt.Fprintf(w, "  %s %s has moved to %s\n", color.CyanString("→"), m.From, m.To)
+	}
+	for _, entry := range plan.Changes {
+		switch entry.Action {
+		case planActionCreate:
+			fmt.Fprintf(w, "  %s %s will be created\n", color.GreenString("+"), entry.Name)
+		case planActionUpdate:
+			fmt.Fprintf(w, "  %s %s (id %d) will be updated\n", color.YellowString("~"), entry.Name, entry.ID)
+			for _, f := range entry.Fields {
+				fmt.Fprintf(w, "      %s: %s → %s\n", f.Field, formatPlanValue(f.Old), formatPlanValue(f.New))
+			}
+		case planActionReplace:
+			fmt.Fprintf(w, "  %s %s (id %d) will be replaced\n", color.MagentaString("-/+"), entry.Name, entry.ID)
+			for _, f := range entry.Fields {
+				fmt.Fprintf(w, "      %s: %s → %s\n", f.Field, formatPlanValue(f.Old), formatPlanValue(f.New))
+			}
+		case planActionDelete:
+			fmt.Fprintf(w, "  %s %s (id %d) will be deleted\n", color.RedString("-"), entry.Name, entry.ID)
+		}
+	}
+	if plan.Summary.Replace > 0 {
+		fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d to replace, %d to delete.\n", plan.Summary.Create, plan.Summary.Update, plan.Summary.Replace, plan.Summary.Delete)
+		return
+	}
+	fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d to delete.\n", plan.Summary.Create, plan.Summary.Update, plan.Summary.Delete)
+}
@@ -0,0 +1,205 @@
+package monitors_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/openstatusHQ/cli/internal/config"
+	"github.com/openstatusHQ/cli/internal/monitors"
+)
+
+func planTestMonitor() config.Monitor {
+	return config.Monitor{
+		Name:      "API",
+		Active:    true,
+		Frequency: config.The10M,
+		Kind:      config.HTTP,
+		Regions:   []config.Region{config.Iad, config.Ams},
+		Request: config.Request{
+			URL:     "https://example.com",
+			Method:  config.Get,
+			Headers: map[string]string{"User-Agent": "OpenStatus"},
+		},
+		Assertions: []config.Assertion{
+			{Kind: config.StatusCode, Compare: config.Eq, Target: 200},
+		},
+	}
+}
+
+func Test_BuildPlan(t *testing.T) {
+	t.Run("No changes", func(t *testing.T) {
+		m := planTestMonitor()
+		plan := monitors.BuildPlan(config.MonitorsLock{"api": {ID: 1, Monitor: m}}, config.Monitors{"api": m})
+		if plan.HasChanges() {
+			t.Errorf("Expected no changes, got %+v", plan.Changes)
+		}
+	})
+
+	t.Run("Create, update and delete are reported per name", func(t *testing.T) {
+		old := planTestMonitor()
+		updated := planTestMonitor()
+		updated.Frequency = config.The5M
+		updated.Regions = []config.Region{config.Iad, config.Ams, config.Fra}
+		updated.Request.Headers = map[string]string{"User-Agent": "OpenStatus", "Authorization": "Bearer x"}
+
+		lock := config.MonitorsLock{
+			"api":     {ID: 1, Monitor: old},
+			"removed": {ID: 2, Monitor: old},
+		}
+		configData := config.Monitors{
+			"api": updated,
+			"new": planTestMonitor(),
+		}
+
+		plan := monitors.BuildPlan(lock, configData)
+		if plan.Summary.Create != 1 || plan.Summary.Update != 1 || plan.Summary.Delete != 1 {
+			t.Fatalf("Unexpected summary %+v", plan.Summary)
+		}
+		if len(plan.Changes) != 3 {
+			t.Fatalf("Expected 3 changes, got %d", len(plan.Changes))
+		}
+
+		update := plan.Changes[0]
+		if update.Name != "api" || update.Action != "update" || update.ID != 1 {
+			t.Fatalf("Unexpected first entry %+v", update)
+		}
+		fields := map[string]monitors.FieldChange{}
+		for _, f := range update.Fields {
+			fields[f.Field] = f
+		}
+		if len(fields) != 3 {
+			t.Errorf("Expected 3 changed fields, got %+v", update.Fields)
+		}
+		if f, ok := fields["frequency"]; !ok || f.Old != config.The10M || f.New != config.The5M {
+			t.Errorf("Unexpected frequency change %+v", f)
+		}
+		if _, ok := fields["regions"]; !ok {
+			t.Error("Expected regions change")
+		}
+		if f, ok := fields["request.headers.Authorization"]; !ok || f.Old != nil || f.New != "Bearer x" {
+			t.Errorf("Unexpected header change %+v", f)
+		}
+
+		if plan.Changes[1].Name != "new" || plan.Changes[1].Action != "create" {
+			t.Errorf("Unexpected second entry %+v", plan.Changes[1])
+		}
+		if plan.Changes[2].Name != "removed" || plan.Changes[2].Action != "delete" || plan.Changes[2].ID != 2 {
+			t.Errorf("Unexpected third entry %+v", plan.Changes[2])
+		}
+	})
+
+	t.Run("Assertion changes are reported as a whole", func(t *testing.T) {
+		old := planTestMonitor()
+		updated := planTestMonitor()
+		updated.Assertions = []config.Assertion{
+			{Kind: config.StatusCode, Compare: config.Eq, Target: 201},
+			{Kind: config.TextBody, Compare: config.Contains, Target: "ok"},
+		}
+
+		plan := monitors.BuildPlan(config.MonitorsLock{"api": {ID: 1, Monitor: old}}, config.Monitors{"api": updated})
+		if len(plan.Changes) != 1 || len(plan.Changes[0].Fields) != 1 {
+			t.Fatalf("Expected a single field change, got %+v", plan.Changes)
+		}
+		if plan.Changes[0].Fields[0].Field != "assertions" {
+			t.Errorf("Expected 'assertions' field, got %q", plan.Changes[0].Fields[0].Field)
+		}
+	})
+}
+
+func Test_BuildPlan_Replace(t *testing.T) {
+	old := planTestMonitor()
+	updated := planTestMonitor()
+	updated.Kind = config.TCP
+	updated.Request = config.Request{Host: "example.com", Port: 443}
+
+	plan := monitors.BuildPlan(config.MonitorsLock{"api": {ID: 1, Monitor: old}}, config.Monitors{"api": updated})
+	if plan.Summary.Replace != 1 || plan.Summary.Update != 0 {
+		t.Fatalf("Unexpected summary %+v", plan.Summary)
+	}
+	if plan.Changes[0].Action != "replace" || plan.Changes[0].ID != 1 {
+		t.Errorf("Unexpected entry %+v", plan.Changes[0])
+	}
+
+	var buf bytes.Buffer
+	monitors.RenderPlan(&buf, plan)
+	if !strings.Contains(buf.String(), "api (id 1) will be replaced") {
+		t.Errorf("Expected replacement in output, got %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "Plan: 0 to create, 0 to update, 1 to replace, 0 to delete.") {
+		t.Errorf("Expected summary with replacement, got %s", buf.String())
+	}
+}
+
+func Test_RenderPlan(t *testing.T) {
+	old := planTestMonitor()
+	updated := planTestMonitor()
+	updated.Frequency = config.The1M
+
+	plan := monitors.BuildPlan(config.MonitorsLock{"api": {ID: 7, Monitor: old}}, config.Monitors{"api": updated})
+
+	var buf bytes.Buffer
+	monitors.RenderPlan(&buf, plan)
+	out := buf.String()
+
+	for _, want := range []string{"api (id 7) will be updated", `frequency: "10m" → "1m"`, "Plan: 0 to create, 1 to update, 0 to delete."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func Test_ApplyMoves(t *testing.T) {
+	m := planTestMonitor()
+
+	t.Run("Renamed monitor is updated in place", func(t *testing.T) {
+		lock := config.MonitorsLock{"api-prod": {ID: 7, Monitor: m}}
+		configData := config.Monitors{"api-production": m}
+		moves := []config.Move{{From: "api-prod", To: "api-production"}}
+
+		moved, applied, err := monitors.ApplyMoves(lock, moves, configData)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(applied) != 1 {
+			t.Errorf("Expected 1 applied move, got %v", applied)
+		}
+		if _, ok := lock["api-prod"]; !ok {
+			t.Error("Expected caller's lock to be left untouched")
+		}
+
+		plan := monitors.BuildPlan(moved, configData)
+		if plan.HasChanges() {
+			t.Errorf("Expected no changes after move, got %+v", plan.Changes)
+		}
+		if moved["api-production"].ID != 7 {
+			t.Errorf("Expected ID 7 to be kept, got %d", moved["api-production"].ID)
+		}
+
+		plan.Moved = applied
+		var buf bytes.Buffer
+		monitors.RenderPlan(&buf, plan)
+		if !strings.Contains(buf.String(), "api-prod has moved to api-production") {
+			t.Errorf("Expected move in output, got %s", buf.String())
+		}
+	})
+
+	t.Run("Applied moves are skipped", func(t *testing.T) {
+		lock := config.MonitorsLock{"api-production": {ID: 7, Monitor: m}}
+		_, applied, err := monitors.ApplyMoves(lock, []config.Move{{From: "api-prod", To: "api-production"}}, config.Monitors{"api-production": m})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(applied) != 0 {
+			t.Errorf("Expected no applied moves, got %v", applied)
+		}
+	})
+
+	t.Run("Destination must be defined", func(t *testing.T) {
+		lock := config.MonitorsLock{"api-prod": {ID: 7, Monitor: m}}
+		_, _, err := monitors.ApplyMoves(lock, []config.Move{{From: "api-prod", To: "api-typo"}}, config.Monitors{"api-production": m})
+		if err == nil {
+			t.Error("Expected error for undefined destination, got nil")
+		}
+	})
+}
@@ -0,0 +1,174 @@
+package monitors
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"buf.build/gen/go/openstatus/api/connectrpc/gosimple/openstatus/monitor/v1/monitorv1connect"
+	monitorv1 "buf.build/gen/go/openstatus/api/protocolbuffers/go/openstatus/monitor/v1"
+	"github.com/agext/levenshtein"
+	"github.com/urfave/cli/v3"
+
+	output "github.com/openstatusHQ/cli/internal/cli"
+	"github.com/openstatusHQ/cli/internal/config"
+)
+
+// monitorRef is a name a monitor can be referred to by: its logical name
+// in openstatus.lock or its display name in the workspace.
+type monitorRef struct {
+	ID   string
+	Name string
+}
+
+func (r monitorRef) String() string {
+	return fmt.Sprintf("%s (id %s)", r.Name, r.ID)
+}
+
+// normalizeName lowercases a name and drops everything but letters and
+// digits, so that "API Prod", "api-prod" and "api_prod" are equal.
+func normalizeName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+// matchMonitor returns the monitors ref designates, trying in turn exact
+// names, normalized names, prefixes and substrings, then names within a
+// small edit distance. Without fuzzy, only exact and normalized names are
+// tried. The first step with a match wins. References to the same ID are
+// merged.
+func matchMonitor(ref string, candidates []monitorRef, fuzzy bool) []monitorRef {
+	norm := normalizeName(ref)
+	steps := []func(c monitorRef) bool{
+		func(c monitorRef) bool { return c.Name == ref },
+		func(c monitorRef) bool { return normalizeName(c.Name) == norm },
+		func(c monitorRef) bool { return norm != "" && strings.HasPrefix(normalizeName(c.Name), norm) },
+		func(c monitorRef) bool { return norm != "" && strings.Contains(normalizeName(c.Name), norm) },
+		func(c monitorRef) bool {
+			return levenshtein.Distance(normalizeName(c.Name), norm, nil) <= max(1, len(norm)/4)
+		},
+	}
+	if !fuzzy {
+		steps = steps[:2]
+	}
+	for _, match := range steps {
+		var out []monitorRef
+		for _, c := range candidates {
+			if match(c) && !slices.ContainsFunc(out, func(o monitorRef) bool { return o.ID == c.ID }) {
+				out = append(out, c)
+			}
+		}
+		if len(out) > 0 {
+			return out
+		}
+	}
+	return nil
+}
+
+// lockRefs returns the logical names of the lock file of env, if there is
+// one.
+func lockRefs(env string) []monitorRef {
+	lock, err := config.ReadLockFile(config.LockFilePath(env))
+	if err != nil {
+		return nil
+	}
+	refs := make([]monitorRef, 0, len(lock))
+	for name, entry := range lock {
+		refs = append(refs, monitorRef{ID: strconv.Itoa(entry.ID), Name: name})
+	}
+	slices.SortFunc(refs, func(a, b monitorRef) int { return strings.Compare(a.Name, b.Name) })
+	return refs
+}
+
+func workspaceRefs(ctx context.Context, client monitorv1connect.MonitorServiceClient) ([]monitorRef, error) {
+	resp, err := client.ListMonitors(ctx, &monitorv1.ListMonitorsRequest{})
+	if err != nil {
+		return nil, output.FormatError(err, "monitors", "")
+	}
+	var refs []monitorRef
+	for _, m := range resp.GetHttpMonitors() {
+		refs = append(refs, monitorRef{ID: m.GetId(), Name: m.GetName()})
+	}
+	for _, m := range resp.GetTcpMonitors() {
+		refs = append(refs, monitorRef{ID: m.GetId(), Name: m.GetName()})
+	}
+	for _, m := range resp.GetDnsMonitors() {
+		refs = append(refs, monitorRef{ID: m.GetId(), Name: m.GetName()})
+	}
+	return refs, nil
+}
+
+// ResolveOptions controls how ResolveMonitor looks up a name.
+type ResolveOptions struct {
+	// Env selects the lock file of an environment, openstatus.<env>.lock.
+	Env string
+	// Exact only accepts exact and normalized names, for commands that
+	// must not act on a monitor the user did not name.
+	Exact bool
+}
+
+// ResolveMonitorID turns a monitor reference into its ID, with fuzzy
+// matching. See ResolveMonitor.
+func ResolveMonitorID(ctx context.Context, client monitorv1connect.MonitorServiceClient, ref string, env string) (string, error) {
+	m, err := ResolveMonitor(ctx, client, ref, ResolveOptions{Env: env})
+	return m.ID, err
+}
+
+// ResolveMonitor turns a monitor reference into the monitor it designates.
+// Numeric IDs are returned as-is, with the logical name of the lock file
+// when there is one. Other references are looked up among the logical names
+// of the lock file, then among the display names of the workspace. A
+// reference matching several monitors is an error.
+func ResolveMonitor(ctx context.Context, client monitorv1connect.MonitorServiceClient, ref string, opts ResolveOptions) (monitorRef, error) {
+	if ref == "" {
+		return monitorRef{}, nil
+	}
+	lock := lockRefs(opts.Env)
+	if _, err := strconv.Atoi(ref); err == nil {
+		for _, r := range lock {
+			if r.ID == ref {
+				return r, nil
+			}
+		}
+		return monitorRef{ID: ref}, nil
+	}
+
+	for _, r := range lock {
+		if r.Name == ref {
+			return r, nil
+		}
+	}
+
+	workspace, err := workspaceRefs(ctx, client)
+	if err != nil {
+		return monitorRef{}, err
+	}
+	matches := matchMonitor(ref, append(lock, workspace...), !opts.Exact)
+	switch len(matches) {
+	case 0:
+		return monitorRef{}, fmt.Errorf("no monitor matches %q; run 'openstatus monitors list' to see your monitors", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		names := make([]string, len(matches))
+		for i, m := range matches {
+			names[i] = "  " + m.String()
+		}
+		return monitorRef{}, fmt.Errorf("%q matches several monitors, use a more specific name or the ID:\n%s", ref, strings.Join(names, "\n"))
+	}
+}
+
+// ResolveEnvFlag selects the lock file used to resolve monitor names.
+func ResolveEnvFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:  "env",
+		Usage: "Environment name: resolves monitor names with openstatus.<env>.lock",
+	}
+}
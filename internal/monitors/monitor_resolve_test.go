@@ -0,0 +1,96 @@
+package monitors_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openstatusHQ/cli/internal/monitors"
+)
+
+func Test_MatchMonitor(t *testing.T) {
+	t.Parallel()
+
+	candidates := []monitors.MonitorRef{
+		{ID: "1", Name: "api-prod"},
+		{ID: "1", Name: "API Production"},
+		{ID: "2", Name: "api-staging"},
+		{ID: "3", Name: "Marketing Website"},
+		{ID: "4", Name: "Website"},
+	}
+
+	// exact is the result without fuzzy matching, as used by delete.
+	tests := []struct {
+		ref   string
+		want  []string
+		exact []string
+	}{
+		{"api-prod", []string{"1"}, []string{"1"}},
+		{"API Production", []string{"1"}, []string{"1"}},
+		{"api_prod", []string{"1"}, []string{"1"}},
+		{"api", []string{"1", "2"}, nil},
+		{"marketing", []string{"3"}, nil},
+		{"website", []string{"4"}, []string{"4"}},
+		{"api-stagng", []string{"2"}, nil},
+		{"billing", nil, nil},
+	}
+	ids := func(refs []monitors.MonitorRef) string {
+		var out []string
+		for _, m := range refs {
+			out = append(out, m.ID)
+		}
+		return strings.Join(out, ",")
+	}
+	for _, tt := range tests {
+		if got := ids(monitors.MatchMonitor(tt.ref, candidates, true)); got != strings.Join(tt.want, ",") {
+			t.Errorf("MatchMonitor(%q) = %v, want %v", tt.ref, got, tt.want)
+		}
+		if got := ids(monitors.MatchMonitor(tt.ref, candidates, false)); got != strings.Join(tt.exact, ",") {
+			t.Errorf("MatchMonitor(%q) without fuzzy = %v, want %v", tt.ref, got, tt.exact)
+		}
+	}
+}
+
+func Test_ResolveMonitorID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Numeric IDs are returned without calling the API", func(t *testing.T) {
+		id, err := monitors.ResolveMonitorID(context.Background(), nil, "12345", "")
+		if err != nil || id != "12345" {
+			t.Errorf("Expected 12345, got %q, %v", id, err)
+		}
+	})
+
+	t.Run("Empty reference is returned as-is", func(t *testing.T) {
+		id, err := monitors.ResolveMonitorID(context.Background(), nil, "", "")
+		if err != nil || id != "" {
+			t.Errorf("Expected empty ID, got %q, %v", id, err)
+		}
+	})
+}
+
+func Test_ResolveMonitor(t *testing.T) {
+	t.Run("Numeric IDs are named from the lock file of the environment", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Chdir(dir)
+		lock := `api-prod:
+  id: 12345
+  monitor:
+    name: API
+    kind: http
+`
+		if err := os.WriteFile(filepath.Join(dir, "openstatus.staging.lock"), []byte(lock), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		m, err := monitors.ResolveMonitor(context.Background(), nil, "12345", monitors.ResolveOptions{Env: "staging"})
+		if err != nil || m.ID != "12345" || m.Name != "api-prod" {
+			t.Errorf("Expected api-prod (id 12345), got %+v, %v", m, err)
+		}
+		m, err = monitors.ResolveMonitor(context.Background(), nil, "12345", monitors.ResolveOptions{})
+		if err != nil || m.Name != "" {
+			t.Errorf("Expected no name without the staging lock, got %+v, %v", m, err)
+		}
+	})
+}
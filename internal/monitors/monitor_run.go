@@ -0,0 +1,138 @@
+package monitors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openstatusHQ/cli/internal/api"
+	"github.com/openstatusHQ/cli/internal/report"
+)
+
+type RegionRunResult struct {
+	Region  string `json:"region"`
+	Latency int64  `json:"latency"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Failed reports whether the region did not pass. An unknown result is a
+// failure, as in the JUnit and JSON reports.
+func (r RegionRunResult) Failed() bool {
+	return r.Status != "pass"
+}
+
+type MonitorRunResult struct {
+	MonitorID string            `json:"monitor_id"`
+	Results   []RegionRunResult `json:"results"`
+	// Violations lists the latency budget checks that failed across regions.
+	Violations []string `json:"violations,omitempty"`
+}
+
+// Suite converts the result to a report suite, one case per region.
+func (r MonitorRunResult) Suite() report.Suite {
+	s := report.Suite{Name: fmt.Sprintf("Monitor %s", r.MonitorID)}
+	for _, res := range r.Results {
+		s.Cases = append(s.Cases, report.Case{
+			Name:    res.Region,
+			Latency: res.Latency,
+			Status:  res.Status,
+			Error:   res.Error,
+		})
+	}
+	for _, v := range r.Violations {
+		s.Cases = append(s.Cases, report.Case{Name: "budget", Status: report.StatusFail, Error: v})
+	}
+	return s
+}
+
+// RunMonitor runs a monitor from all of its regions and waits for the results.
+func RunMonitor(ctx context.Context, httpClient *http.Client, apiKey string, monitorId string) (MonitorRunResult, error) {
+	if monitorId == "" {
+		return MonitorRunResult{}, fmt.Errorf("monitor ID is required")
+	}
+
+	url := fmt.Sprintf("%s/monitor/%s/run", api.APIBaseURL, monitorId)
+
+	client := &http.Client{
+		Timeout:   2 * time.Minute,
+		Transport: httpClient.Transport,
+	}
+
+	payload := strings.NewReader("{}")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, payload)
+	if err != nil {
+		return MonitorRunResult{}, err
+	}
+	req.Header.Add("x-openstatus-key", apiKey)
+	res, err := client.Do(req)
+	if err != nil {
+		return MonitorRunResult{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return MonitorRunResult{}, fmt.Errorf("failed to trigger monitor test")
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return MonitorRunResult{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var result []json.RawMessage
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return MonitorRunResult{}, err
+	}
+
+	var regionResults []RegionRunResult
+	for _, r := range result {
+		rr := RunResult{}
+
+		if err := json.Unmarshal(r, &rr); err != nil {
+			return MonitorRunResult{}, fmt.Errorf("unable to unmarshal: %w", err)
+		}
+
+		entry := RegionRunResult{
+			Region:  rr.Region,
+			Latency: rr.Latency,
+			Status:  "pass",
+		}
+
+		switch rr.JobType {
+		case "tcp":
+			var tcp TCPRunResult
+			if err := json.Unmarshal(r, &tcp); err != nil {
+				return MonitorRunResult{}, fmt.Errorf("unable to unmarshal: %w", err)
+			}
+			if tcp.ErrorMessage != "" {
+				entry.Status = "fail"
+				entry.Error = tcp.ErrorMessage
+			}
+		case "http":
+			var httpResult HTTPRunResult
+			if err := json.Unmarshal(r, &httpResult); err != nil {
+				return MonitorRunResult{}, fmt.Errorf("unable to unmarshal: %w", err)
+			}
+			if httpResult.Error != "" {
+				entry.Status = "fail"
+				entry.Error = httpResult.Error
+			}
+		default:
+			entry.Status = "unknown"
+			entry.Error = fmt.Sprintf("unknown job type: %s", rr.JobType)
+		}
+
+		regionResults = append(regionResults, entry)
+	}
+
+	return MonitorRunResult{
+		MonitorID: monitorId,
+		Results:   regionResults,
+	}, nil
+}
@@ -0,0 +1,39 @@
+package monitors_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/openstatusHQ/cli/internal/monitors"
+	"github.com/openstatusHQ/cli/internal/report"
+)
+
+func Test_RunMonitor(t *testing.T) {
+	t.Parallel()
+
+	interceptor := &interceptorHTTPClient{
+		f: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusOK, `[
+  {"jobType": "http", "region": "iad", "latency": 318, "status": 200},
+  {"jobType": "tcp", "region": "fra", "latency": 12, "errorMessage": "connection refused"}
+]`), nil
+		},
+	}
+
+	res, err := monitors.RunMonitor(context.Background(), interceptor.GetHTTPClient(), "", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Results) != 2 || res.Results[1].Status != "fail" {
+		t.Fatalf("Unexpected results %+v", res.Results)
+	}
+
+	suite := res.Suite()
+	if suite.Name != "Monitor 1" || len(suite.Cases) != 2 {
+		t.Fatalf("Unexpected suite %+v", suite)
+	}
+	if c := suite.Cases[1]; c.Name != "fra" || c.Status != report.StatusFail || c.Error != "connection refused" {
+		t.Errorf("Unexpected case %+v", c)
+	}
+}
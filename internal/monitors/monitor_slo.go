@@ -0,0 +1,282 @@
+package monitors
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"buf.build/gen/go/openstatus/api/connectrpc/gosimple/openstatus/monitor/v1/monitorv1connect"
+	monitorv1 "buf.build/gen/go/openstatus/api/protocolbuffers/go/openstatus/monitor/v1"
+	"github.com/urfave/cli/v3"
+
+	"github.com/openstatusHQ/cli/internal/auth"
+	output "github.com/openstatusHQ/cli/internal/cli"
+	"github.com/openstatusHQ/cli/internal/slo"
+)
+
+// logRetention is how long response logs are kept.
+const logRetention = 14 * 24 * time.Hour
+
+// SLOOptions configures the SLO commands.
+type SLOOptions struct {
+	Target      float64
+	Window      time.Duration
+	Concurrency int
+	// Now is the end of the window; zero means the current time.
+	Now time.Time
+}
+
+func (o SLOOptions) validate() error {
+	if o.Target <= 0 || o.Target >= 100 {
+		return fmt.Errorf("--target must be between 0 and 100, e.g. 99.9")
+	}
+	if o.Window <= 0 {
+		return fmt.Errorf("--window must be positive")
+	}
+	return nil
+}
+
+// monitorSLO computes the SLO of an HTTP monitor from its response logs.
+// Windows longer than the retention are computed from the logs available.
+func monitorSLO(ctx context.Context, client monitorv1connect.MonitorServiceClient, id, name string, opts SLOOptions) slo.Result {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	observed := min(opts.Window, logRetention)
+	sloOpts := slo.Options{Target: opts.Target, Window: opts.Window, Observed: observed}
+
+	logs, err := fetchAllResponseLogs(ctx, client, id, now.Add(-observed).UnixMilli(), now.UnixMilli(), opts.Concurrency)
+	if err != nil {
+		res := slo.Compute(id, name, nil, sloOpts)
+		res.NoData = false
+		res.Error = output.FormatError(err, "monitor", id).Error()
+		return res
+	}
+	samples := make([]slo.Sample, len(logs))
+	for i, l := range logs {
+		samples[i] = slo.Sample{
+			Region: regionToString(l.GetRegion()),
+			Status: requestStatusToString(l.GetRequestStatus()),
+		}
+	}
+	return slo.Compute(id, name, samples, sloOpts)
+}
+
+// WorkspaceSLO computes the SLO of every HTTP monitor of the workspace,
+// ordered by name. Response logs only exist for HTTP monitors.
+func WorkspaceSLO(ctx context.Context, client monitorv1connect.MonitorServiceClient, opts SLOOptions) ([]slo.Result, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	resp, err := client.ListMonitors(ctx, &monitorv1.ListMonitorsRequest{})
+	if err != nil {
+		return nil, output.FormatError(err, "monitors", "")
+	}
+	httpMonitors := resp.GetHttpMonitors()
+	results := make([]slo.Result, len(httpMonitors))
+
+	concurrency := max(1, opts.Concurrency)
+	// Each monitor fetches its pages one at a time; monitors run in parallel.
+	perMonitor := opts
+	perMonitor.Concurrency = 1
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, m := range httpMonitors {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = monitorSLO(ctx, client, m.GetId(), m.GetName(), perMonitor)
+		}()
+	}
+	wg.Wait()
+
+	slices.SortFunc(results, func(a, b slo.Result) int { return strings.Compare(a.Name, b.Name) })
+	return results, nil
+}
+
+func WorkspaceSLOWithHTTPClient(ctx context.Context, httpClient *http.Client, apiKey string, opts SLOOptions) ([]slo.Result, error) {
+	client := NewMonitorClientWithHTTPClient(httpClient, apiKey)
+	return WorkspaceSLO(ctx, client, opts)
+}
+
+// MonitorSLO computes the SLO of one monitor.
+func MonitorSLO(ctx context.Context, client monitorv1connect.MonitorServiceClient, monitorId string, opts SLOOptions) (slo.Result, error) {
+	if err := opts.validate(); err != nil {
+		return slo.Result{}, err
+	}
+	resp, err := client.GetMonitor(ctx, &monitorv1.GetMonitorRequest{Id: monitorId})
+	if err != nil {
+		return slo.Result{}, output.FormatError(err, "monitor", monitorId)
+	}
+	if !resp.GetMonitor().HasHttp() {
+		return slo.Result{}, fmt.Errorf("monitor %s is not an HTTP monitor: SLOs are computed from HTTP response logs", monitorId)
+	}
+	res := monitorSLO(ctx, client, monitorId, resp.GetMonitor().GetHttp().GetName(), opts)
+	if res.Error != "" {
+		return slo.Result{}, fmt.Errorf("%s", res.Error)
+	}
+	return res, nil
+}
+
+func MonitorSLOWithHTTPClient(ctx context.Context, httpClient *http.Client, apiKey string, monitorId string, opts SLOOptions) (slo.Result, error) {
+	client := NewMonitorClientWithHTTPClient(httpClient, apiKey)
+	return MonitorSLO(ctx, client, monitorId, opts)
+}
+
+func sloFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "access-token",
+			Usage:   "OpenStatus API Access Token",
+			Aliases: []string{"t"},
+			Sources: cli.EnvVars("OPENSTATUS_API_TOKEN"),
+		},
+		ResolveEnvFlag(),
+		&cli.FloatFlag{
+			Name:  "target",
+			Usage: "Availability objective in percent",
+			Value: 99.9,
+		},
+		&cli.StringFlag{
+			Name:  "window",
+			Usage: "Period of the objective, e.g. 30d or 7d",
+			Value: "30d",
+		},
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "Output format: table or markdown",
+			DefaultText: "table",
+		},
+		&cli.IntFlag{
+			Name:  "concurrency",
+			Usage: "Number of requests in flight",
+			Value: defaultExportConcurrency,
+		},
+	}
+}
+
+func sloOptionsFromCmd(cmd *cli.Command) (SLOOptions, error) {
+	window, err := parseSince(cmd.String("window"))
+	if err != nil {
+		return SLOOptions{}, err
+	}
+	switch cmd.String("format") {
+	case "", "table", "markdown":
+	default:
+		return SLOOptions{}, fmt.Errorf("invalid format %q, expected one of: table, markdown", cmd.String("format"))
+	}
+	opts := SLOOptions{
+		Target:      cmd.Float("target"),
+		Window:      window,
+		Concurrency: int(cmd.Int("concurrency")),
+	}
+	return opts, opts.validate()
+}
+
+func printSLO(cmd *cli.Command, results []slo.Result) error {
+	switch {
+	case output.IsJSONOutput():
+		return output.PrintJSON(results)
+	case cmd.String("format") == "markdown":
+		slo.WriteMarkdown(os.Stdout, results)
+	case len(results) == 0:
+		if !output.IsQuiet() {
+			fmt.Println("No HTTP monitors found")
+		}
+	default:
+		slo.WriteTable(os.Stdout, results)
+	}
+	return nil
+}
+
+func GetMonitorSLOCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "slo",
+		Usage: "Compute the availability and error budget of a monitor",
+		UsageText: `openstatus monitors slo <MonitorID|name>
+  openstatus monitors slo api-prod --target 99.9 --window 30d
+  openstatus monitors slo 12345 --window 7d --json`,
+		Description: `Computes availability, degraded ratio, remaining error budget and burn rate
+of an HTTP monitor from its response logs, overall and per region. Degraded
+checks count as available.
+
+Response logs are kept for 14 days: longer windows are computed from the
+last 14 days. The remaining budget assumes checks fail at the observed pace
+over the whole window, so it is 100% minus the burn rate: a burn rate of 1.5
+leaves -50%.`,
+		Flags: sloFlags(),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			opts, err := sloOptionsFromCmd(cmd)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			apiKey, err := auth.ResolveAccessToken(cmd)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			client := NewMonitorClient(apiKey)
+			monitorId, err := ResolveMonitorID(ctx, client, cmd.Args().Get(0), cmd.String("env"))
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			if monitorId == "" {
+				return cli.Exit("Usage: openstatus monitors slo <MonitorID|name>", 1)
+			}
+
+			s := output.StartSpinner("Computing SLO from response logs...")
+			res, err := MonitorSLO(ctx, client, monitorId, opts)
+			output.StopSpinner(s)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			return printSLO(cmd, []slo.Result{res})
+		},
+	}
+}
+
+// SLOCmd is the top-level slo command, for workspace-wide reports.
+func SLOCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "slo",
+		Usage: "Report availability and error budgets across monitors",
+		Commands: []*cli.Command{
+			{
+				Name:  "report",
+				Usage: "Compute the SLO of every HTTP monitor of the workspace",
+				UsageText: `openstatus slo report
+  openstatus slo report --target 99.95 --window 30d
+  openstatus slo report --format markdown > reliability-review.md
+  openstatus slo report --json`,
+				Description: `Computes availability, degraded ratio, remaining error budget and burn rate
+of every HTTP monitor of the workspace from its response logs, with the same
+target for all of them. See 'openstatus monitors slo' for a single monitor.`,
+				Flags: sloFlags(),
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					opts, err := sloOptionsFromCmd(cmd)
+					if err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+					apiKey, err := auth.ResolveAccessToken(cmd)
+					if err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+					s := output.StartSpinner("Computing SLOs from response logs...")
+					results, err := WorkspaceSLO(ctx, NewMonitorClient(apiKey), opts)
+					output.StopSpinner(s)
+					if err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+					return printSLO(cmd, results)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,67 @@
+package monitors_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openstatusHQ/cli/internal/monitors"
+)
+
+func Test_WorkspaceSLO(t *testing.T) {
+	t.Parallel()
+
+	logs := func(statuses ...string) string {
+		var out []string
+		for i, s := range statuses {
+			out = append(out, fmt.Sprintf(`{"id":"log-%d","latency":100,"statusCode":200,"monitorId":"1","requestStatus":"HTTP_RESPONSE_LOG_REQUEST_STATUS_%s","region":"REGION_FLY_IAD","trigger":"HTTP_RESPONSE_LOG_TRIGGER_CRON","timestamp":"1715000000000"}`, i, s))
+		}
+		return `{"logs":[` + strings.Join(out, ",") + `],"pagination":{"limit":100,"offset":0,"hasMore":false}}`
+	}
+
+	interceptor := &interceptorHTTPClient{
+		f: func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			switch {
+			case strings.HasSuffix(req.URL.Path, "/ListMonitors"):
+				return jsonResponse(http.StatusOK, `{"httpMonitors":[{"id":"2","name":"Web"},{"id":"1","name":"API"}],"tcpMonitors":[{"id":"3","name":"DB"}]}`), nil
+			case strings.HasSuffix(req.URL.Path, "/ListMonitorHTTPResponseLogs") && strings.Contains(string(body), `"id":"1"`):
+				return jsonResponse(http.StatusOK, logs("SUCCESS", "SUCCESS", "DEGRADED", "ERROR")), nil
+			case strings.HasSuffix(req.URL.Path, "/ListMonitorHTTPResponseLogs"):
+				return jsonResponse(http.StatusNotFound, `{"code":"not_found","message":"monitor not found"}`), nil
+			}
+			t.Errorf("Unexpected request to %s", req.URL.Path)
+			return jsonResponse(http.StatusNotFound, `{}`), nil
+		},
+	}
+
+	results, err := monitors.WorkspaceSLOWithHTTPClient(context.Background(), interceptor.GetHTTPClient(), "test-token", monitors.SLOOptions{
+		Target: 99,
+		Window: 7 * 24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected the 2 HTTP monitors, got %d", len(results))
+	}
+	api, web := results[0], results[1]
+	if api.Name != "API" || api.Total != 4 || api.AvailabilityPercent != 75 || api.Met {
+		t.Errorf("Unexpected result for API: %+v", api)
+	}
+	if web.Name != "Web" || web.Error == "" {
+		t.Errorf("Expected an error for Web, got %+v", web)
+	}
+
+	_, err = monitors.WorkspaceSLOWithHTTPClient(context.Background(), interceptor.GetHTTPClient(), "test-token", monitors.SLOOptions{
+		Target: 100,
+		Window: time.Hour,
+	})
+	if err == nil {
+		t.Error("Expected error for a 100% target, got nil")
+	}
+}
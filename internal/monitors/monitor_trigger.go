@@ -10,8 +10,10 @@ import (
 	monitorv1 "buf.build/gen/go/openstatus/api/protocolbuffers/go/openstatus/monitor/v1"
 	"github.com/urfave/cli/v3"
 
+	"github.com/openstatusHQ/cli/internal/api"
 	"github.com/openstatusHQ/cli/internal/auth"
 	output "github.com/openstatusHQ/cli/internal/cli"
+	"github.com/openstatusHQ/cli/internal/report"
 )
 
 // TriggerMonitor triggers a monitor using the SDK
@@ -45,29 +47,91 @@ func TriggerMonitorWithHTTPClient(ctx context.Context, httpClient *http.Client,
 	return TriggerMonitor(ctx, client, monitorId, nil)
 }
 
+// runAndReport runs the monitor synchronously so that its per-region
+// results can be reported, and fails when any region failed.
+func runAndReport(ctx context.Context, httpClient *http.Client, apiKey, monitorId string, opts report.Options) error {
+	if monitorId == "" {
+		return fmt.Errorf("monitor ID is required")
+	}
+	s := output.StartSpinner("Running monitor...")
+	res, err := RunMonitor(ctx, httpClient, apiKey, monitorId)
+	output.StopSpinner(s)
+	if err != nil {
+		return err
+	}
+
+	if err := opts.Emit(os.Stdout, []report.Suite{res.Suite()}); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	switch {
+	case opts.ReplacesOutput():
+	case output.IsJSONOutput():
+		if err := output.PrintJSON(res); err != nil {
+			return err
+		}
+	default:
+		for _, r := range res.Results {
+			line := fmt.Sprintf("%-8s %6dms  %s", r.Region, r.Latency, r.Status)
+			if r.Error != "" {
+				line += "  " + r.Error
+			}
+			fmt.Println(line)
+		}
+	}
+
+	for _, r := range res.Results {
+		if r.Failed() {
+			return fmt.Errorf("some regions failed")
+		}
+	}
+	return nil
+}
+
 func GetMonitorsTriggerCmd() *cli.Command {
 	monitorsCmd := cli.Command{
 		Name:  "trigger",
 		Usage: "Trigger a monitor execution",
-		UsageText: `openstatus monitors trigger <MonitorID>
-  openstatus monitors trigger 12345`,
-		Description: "Trigger a monitor execution on demand. This command allows you to launch your tests on demand.",
-		Flags: []cli.Flag{
+		UsageText: `openstatus monitors trigger <MonitorID|name>
+  openstatus monitors trigger 12345
+  openstatus monitors trigger api-prod
+  openstatus monitors trigger 12345 --format junit --report-file results.xml`,
+		Description: `Trigger a monitor execution on demand. This command allows you to launch your tests on demand.
+
+With --format or --report-file, the command waits for the results of every
+region and reports them as junit, tap, github or markdown, exiting with a
+non-zero status if a region failed.`,
+		Flags: append([]cli.Flag{
 			&cli.StringFlag{
 				Name:    "access-token",
 				Usage:   "OpenStatus API Access Token",
 				Aliases: []string{"t"},
 				Sources: cli.EnvVars("OPENSTATUS_API_TOKEN"),
 			},
-		},
+			ResolveEnvFlag(),
+		}, report.Flags()...),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			apiKey, err := auth.ResolveAccessToken(cmd)
 			if err != nil {
 				return cli.Exit(err.Error(), 1)
 			}
-			monitorId := cmd.Args().Get(0)
-			s := output.StartSpinner("Triggering monitor...")
 			client := NewMonitorClient(apiKey)
+			monitorId, err := ResolveMonitorID(ctx, client, cmd.Args().Get(0), cmd.String("env"))
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+
+			reportOpts, err := report.OptionsFromCmd(cmd)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			if reportOpts.Format != report.FormatTable || reportOpts.File != "" {
+				if err := runAndReport(ctx, api.DefaultHTTPClient, apiKey, monitorId, reportOpts); err != nil {
+					return cli.Exit(err.Error(), 1)
+				}
+				return nil
+			}
+
+			s := output.StartSpinner("Triggering monitor...")
 			err = TriggerMonitor(ctx, client, monitorId, s)
 			if err != nil {
 				return cli.Exit(err.Error(), 1)
@@ -21,6 +21,8 @@ func UpdateMonitor(ctx context.Context, httpClient *http.Client, apiKey string,
 		return UpdateHTTPMonitor(ctx, client, id, monitor)
 	case config.TCP:
 		return UpdateTCPMonitor(ctx, client, id, monitor)
+	case config.DNS:
+		return UpdateDNSMonitor(ctx, client, id, monitor)
 	default:
 		return Monitor{}, fmt.Errorf("unsupported monitor kind: %s", monitor.Kind)
 	}
@@ -64,3 +66,24 @@ func UpdateTCPMonitor(ctx context.Context, client monitorv1connect.MonitorServic
 
 	return tcpMonitorToLocal(resp.GetMonitor())
 }
+
+// UpdateDNSMonitor updates a DNS monitor using the SDK
+func UpdateDNSMonitor(ctx context.Context, client monitorv1connect.MonitorServiceClient, id int, monitor config.Monitor) (Monitor, error) {
+	dnsMonitor, err := configToDNSMonitor(monitor)
+	if err != nil {
+		return Monitor{}, err
+	}
+	dnsMonitor.Id = strconv.Itoa(id)
+
+	req := &monitorv1.UpdateDNSMonitorRequest{
+		Id:      strconv.Itoa(id),
+		Monitor: dnsMonitor,
+	}
+
+	resp, err := client.UpdateDNSMonitor(ctx, req)
+	if err != nil {
+		return Monitor{}, fmt.Errorf("failed to update DNS monitor: %w", err)
+	}
+
+	return dnsMonitorToLocal(resp.GetMonitor())
+}
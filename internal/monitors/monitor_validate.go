@@ -0,0 +1,128 @@
+package monitors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	output "github.com/openstatusHQ/cli/internal/cli"
+	"github.com/openstatusHQ/cli/internal/config"
+)
+
+// ValidationResult is the machine-readable output of monitors validate.
+type ValidationResult struct {
+	Valid  bool                     `json:"valid"`
+	Errors []config.ValidationError `json:"errors"`
+}
+
+func renderValidationErrors(w io.Writer, errs []config.ValidationError) {
+	for _, e := range errs {
+		fmt.Fprintln(w, e.Error())
+	}
+	if len(errs) == 1 {
+		fmt.Fprintln(w, "1 error found")
+	} else {
+		fmt.Fprintf(w, "%d errors found\n", len(errs))
+	}
+}
+
+func GetMonitorValidateCmd() *cli.Command {
+	monitorValidateCmd := cli.Command{
+		Name:  "validate",
+		Usage: "Validate the monitors configuration without calling the API",
+		UsageText: `openstatus monitors validate
+  openstatus monitors validate --config monitors/
+  openstatus monitors validate --env prod --var-file prod.env
+  openstatus monitors validate --schema > openstatus.schema.json`,
+		Description: `Checks openstatus.yaml, and every file it includes, offline. Unknown fields,
+such as a misspelled frequncy, values that are not a valid frequency, region,
+method, comparison operator or assertion kind, and fields missing from the
+expanded monitors are reported as file:line:column.
+
+apply runs the same checks before planning.
+
+--schema prints a JSON Schema of the configuration instead. Point your editor
+at it for completion, e.g. with the YAML language server:
+
+  # yaml-language-server: $schema=./openstatus.schema.json`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "config",
+				Usage:       "The configuration file, directory or glob containing monitor information",
+				Aliases:     []string{"c"},
+				DefaultText: "openstatus.yaml",
+				Value:       "openstatus.yaml",
+			},
+			&cli.StringFlag{
+				Name:  "env",
+				Usage: "Environment name: also validates openstatus.<env>.yaml",
+			},
+			&cli.StringSliceFlag{
+				Name:  "var-file",
+				Usage: "Dotenv file with values for ${VAR} references (repeatable)",
+			},
+			&cli.BoolFlag{
+				Name:  "schema",
+				Usage: "Print the JSON Schema of the configuration file and exit",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Bool("schema") {
+				b, err := config.JSONSchema()
+				if err != nil {
+					return cli.Exit(err.Error(), 1)
+				}
+				fmt.Println(string(b))
+				return nil
+			}
+
+			path := cmd.String("config")
+			if !strings.ContainsAny(path, "*?[") {
+				if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+					return cli.Exit("Config does not exist", 1)
+				}
+			}
+
+			env := cmd.String("env")
+			vars, err := config.ReadVarFiles(cmd.StringSlice("var-file")...)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			readOpts := config.ReadOptions{Vars: vars}
+			if env != "" {
+				if overlay := config.OverlayPath(path, env); fileExists(overlay) {
+					readOpts.Overlays = append(readOpts.Overlays, overlay)
+				}
+			}
+
+			errs, err := config.Validate(path, readOpts)
+			if err != nil {
+				return cli.Exit(fmt.Sprintf("Unable to read config file: %v", err), 1)
+			}
+
+			if output.IsJSONOutput() {
+				result := ValidationResult{Valid: len(errs) == 0, Errors: errs}
+				if result.Errors == nil {
+					result.Errors = []config.ValidationError{}
+				}
+				if err := output.PrintJSON(result); err != nil {
+					return err
+				}
+			} else if len(errs) == 0 {
+				fmt.Printf("%s is valid\n", path)
+			} else {
+				renderValidationErrors(os.Stderr, errs)
+			}
+			if len(errs) > 0 {
+				return cli.Exit("", 1)
+			}
+			return nil
+		},
+	}
+	return &monitorValidateCmd
+}
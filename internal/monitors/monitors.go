@@ -345,6 +345,22 @@ func configCompareToStringComparator(c config.Compare) monitorv1.StringComparato
 	}
 }
 
+// configCompareToRecordComparator converts config.Compare to RecordComparator
+func configCompareToRecordComparator(c config.Compare) monitorv1.RecordComparator {
+	switch c {
+	case config.Eq:
+		return monitorv1.RecordComparator_RECORD_COMPARATOR_EQUAL
+	case config.NotEq:
+		return monitorv1.RecordComparator_RECORD_COMPARATOR_NOT_EQUAL
+	case config.Contains:
+		return monitorv1.RecordComparator_RECORD_COMPARATOR_CONTAINS
+	case config.NotContains:
+		return monitorv1.RecordComparator_RECORD_COMPARATOR_NOT_CONTAINS
+	default:
+		return monitorv1.RecordComparator_RECORD_COMPARATOR_EQUAL
+	}
+}
+
 // Builder functions (config.Monitor → SDK monitor types)
 
 // configToHTTPMonitor converts config.Monitor to SDK HTTPMonitor
@@ -449,6 +465,49 @@ func configToTCPMonitor(m config.Monitor) (*monitorv1.TCPMonitor, error) {
 	return monitor, nil
 }
 
+// configToDNSMonitor converts config.Monitor to SDK DNSMonitor. DNSMonitor
+// has no resolver field, so the checker's default resolver is always used.
+func configToDNSMonitor(m config.Monitor) (*monitorv1.DNSMonitor, error) {
+	if m.Request.Host == "" {
+		return nil, fmt.Errorf("DNS monitor %q: host is required", m.Name)
+	}
+
+	var recordAssertions []*monitorv1.RecordAssertion
+	for _, a := range m.Assertions {
+		if a.Kind != config.DNSRecord {
+			return nil, fmt.Errorf("DNS monitor %q: unsupported assertion kind %q", m.Name, a.Kind)
+		}
+		if a.Record == "" {
+			return nil, fmt.Errorf("DNS monitor %q: record is required for dnsRecord assertions", m.Name)
+		}
+		target, _ := a.Target.(string)
+		recordAssertions = append(recordAssertions, &monitorv1.RecordAssertion{
+			Record:     string(a.Record),
+			Target:     target,
+			Comparator: configCompareToRecordComparator(a.Compare),
+		})
+	}
+
+	monitor := &monitorv1.DNSMonitor{
+		Name:             m.Name,
+		Description:      m.Description,
+		Uri:              m.Request.Host,
+		Periodicity:      stringToPeriodicity(m.Frequency),
+		Active:           m.Active,
+		Public:           m.Public,
+		Regions:          stringsToRegions(m.Regions),
+		Timeout:          m.Timeout,
+		Retry:            m.Retry,
+		RecordAssertions: recordAssertions,
+	}
+
+	if m.DegradedAfter > 0 {
+		monitor.DegradedAt = &m.DegradedAfter
+	}
+
+	return monitor, nil
+}
+
 type Monitor struct {
 	ID            int         `json:"id"`
 	Name          string      `json:"name"`
@@ -537,16 +596,24 @@ func MonitorsCmd() *cli.Command {
 		Name:    "monitors",
 		Usage:   "Manage your monitors",
 		Aliases: []string{"m"},
+		Description: `Commands that take a monitor ID also accept the logical name of the monitor
+in openstatus.lock or its display name. Names are matched loosely ("api prod"
+finds "API Production"); a name matching several monitors is an error.`,
 		Commands: []*cli.Command{
 			GetMonitorsApplyCmd(),
 			GetMonitorCreateCmd(),
 			GetMonitorDeleteCmd(),
+			GetMonitorDriftCmd(),
 			GetMonitorImportCmd(),
 			GetMonitorInfoCmd(),
 			GetMonitorsListCmd(),
 			GetMonitorLogsCmd(),
 			GetMonitorLogInfoCmd(),
+			GetMonitorMoveCmd(),
+			GetMonitorSLOCmd(),
+			GetMonitorTestCmd(),
 			GetMonitorsTriggerCmd(),
+			GetMonitorValidateCmd(),
 		},
 	}
 	return &monitorsCmd
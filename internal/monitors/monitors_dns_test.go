@@ -0,0 +1,83 @@
+package monitors_test
+
+import (
+	"testing"
+
+	monitorv1 "buf.build/gen/go/openstatus/api/protocolbuffers/go/openstatus/monitor/v1"
+
+	"github.com/openstatusHQ/cli/internal/config"
+	"github.com/openstatusHQ/cli/internal/monitors"
+)
+
+func Test_ConfigToDNSMonitor_EmptyHost(t *testing.T) {
+	m := config.Monitor{
+		Name:    "test",
+		Kind:    config.DNS,
+		Request: config.Request{Host: ""},
+	}
+	_, err := monitors.ConfigToDNSMonitor(m)
+	if err == nil {
+		t.Error("expected error for empty host")
+	}
+}
+
+func Test_ConfigToDNSMonitor_UnsupportedAssertion(t *testing.T) {
+	m := config.Monitor{
+		Name:    "test",
+		Kind:    config.DNS,
+		Request: config.Request{Host: "example.com"},
+		Assertions: []config.Assertion{
+			{Kind: config.StatusCode, Compare: config.Eq, Target: 200},
+		},
+	}
+	_, err := monitors.ConfigToDNSMonitor(m)
+	if err == nil {
+		t.Error("expected error for statusCode assertion on DNS monitor")
+	}
+}
+
+func Test_ConfigToDNSMonitor_MissingRecord(t *testing.T) {
+	m := config.Monitor{
+		Name:    "test",
+		Kind:    config.DNS,
+		Request: config.Request{Host: "example.com"},
+		Assertions: []config.Assertion{
+			{Kind: config.DNSRecord, Compare: config.Eq, Target: "93.184.216.34"},
+		},
+	}
+	_, err := monitors.ConfigToDNSMonitor(m)
+	if err == nil {
+		t.Error("expected error for dnsRecord assertion without record")
+	}
+}
+
+func Test_ConfigToDNSMonitor_ValidInputs(t *testing.T) {
+	m := config.Monitor{
+		Name:      "test",
+		Kind:      config.DNS,
+		Frequency: config.The10M,
+		Regions:   []config.Region{config.Iad},
+		Request:   config.Request{Host: "example.com"},
+		Assertions: []config.Assertion{
+			{Kind: config.DNSRecord, Record: config.RecordA, Compare: config.Eq, Target: "93.184.216.34"},
+			{Kind: config.DNSRecord, Record: config.RecordTXT, Compare: config.Contains, Target: "v=spf1"},
+		},
+	}
+	result, err := monitors.ConfigToDNSMonitor(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.GetUri() != "example.com" {
+		t.Errorf("expected URI 'example.com', got %q", result.GetUri())
+	}
+	assertions := result.GetRecordAssertions()
+	if len(assertions) != 2 {
+		t.Fatalf("expected 2 record assertions, got %d", len(assertions))
+	}
+	if assertions[0].GetRecord() != "A" || assertions[0].GetTarget() != "93.184.216.34" {
+		t.Errorf("unexpected first assertion: record=%q target=%q", assertions[0].GetRecord(), assertions[0].GetTarget())
+	}
+	if assertions[1].GetComparator() != monitorv1.RecordComparator_RECORD_COMPARATOR_CONTAINS {
+		t.Errorf("expected CONTAINS comparator, got %v", assertions[1].GetComparator())
+	}
+}
@@ -43,20 +43,25 @@ func Test_MonitorsCmd(t *testing.T) {
 	t.Run("Has expected subcommands", func(t *testing.T) {
 		cmd := monitors.MonitorsCmd()
 
-		if len(cmd.Commands) != 9 {
-			t.Errorf("Expected 9 subcommands, got %d", len(cmd.Commands))
+		if len(cmd.Commands) != 14 {
+			t.Errorf("Expected 14 subcommands, got %d", len(cmd.Commands))
 		}
 
 		expectedSubcommands := map[string]bool{
 			"apply":    false,
 			"create":   false,
 			"delete":   false,
+			"drift":    false,
 			"import":   false,
 			"info":     false,
 			"list":     false,
 			"logs":     false,
 			"log-info": false,
+			"mv":       false,
+			"slo":      false,
+			"test":     false,
 			"trigger":  false,
+			"validate": false,
 		}
 
 		for _, subcmd := range cmd.Commands {
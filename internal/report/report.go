@@ -0,0 +1,367 @@
+// Package report renders check results in formats understood by CI systems.
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+type Format string
+
+const (
+	FormatTable    Format = "table"
+	FormatJUnit    Format = "junit"
+	FormatTAP      Format = "tap"
+	FormatGitHub   Format = "github"
+	FormatMarkdown Format = "markdown"
+)
+
+// Formats lists the accepted values of --format.
+var Formats = []Format{FormatTable, FormatJUnit, FormatTAP, FormatGitHub, FormatMarkdown}
+
+const (
+	StatusPass = "pass"
+	StatusFail = "fail"
+)
+
+// Case is a single check, e.g. a monitor run from one region.
+type Case struct {
+	Name    string
+	Latency int64
+	Status  string
+	Error   string
+}
+
+func (c Case) Failed() bool {
+	return c.Status != StatusPass
+}
+
+// Suite groups the cases of one monitor.
+type Suite struct {
+	Name  string
+	Cases []Case
+	// Error is set when the suite could not run at all.
+	Error string
+}
+
+func (s Suite) failures() int {
+	n := 0
+	for _, c := range s.Cases {
+		if c.Failed() {
+			n++
+		}
+	}
+	if s.Error != "" {
+		n++
+	}
+	return n
+}
+
+func (s Suite) tests() int {
+	if s.Error != "" {
+		return len(s.Cases) + 1
+	}
+	return len(s.Cases)
+}
+
+func ParseFormat(s string) (Format, error) {
+	for _, f := range Formats {
+		if strings.EqualFold(s, string(f)) {
+			return f, nil
+		}
+	}
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = string(f)
+	}
+	return "", fmt.Errorf("invalid format %q, expected one of: %s", s, strings.Join(names, ", "))
+}
+
+// FormatForFile guesses the report format from a file extension.
+func FormatForFile(path string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xml":
+		return FormatJUnit, true
+	case ".tap":
+		return FormatTAP, true
+	case ".md", ".markdown":
+		return FormatMarkdown, true
+	}
+	return "", false
+}
+
+// Write renders the suites in the given format. The table format is
+// rendered by each command and is not supported here.
+func Write(w io.Writer, format Format, suites []Suite) error {
+	switch format {
+	case FormatJUnit:
+		return writeJUnit(w, suites)
+	case FormatTAP:
+		return writeTAP(w, suites)
+	case FormatGitHub:
+		return writeGitHub(w, suites)
+	case FormatMarkdown:
+		return writeMarkdown(w, suites)
+	default:
+		return fmt.Errorf("format %q cannot be written as a report", format)
+	}
+}
+
+// WriteFile renders the suites to path.
+func WriteFile(path string, format Format, suites []Suite) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, format, suites); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func seconds(ms int64) string {
+	return fmt.Sprintf("%.3f", float64(ms)/1000)
+}
+
+func writeJUnit(w io.Writer, suites []Suite) error {
+	out := junitTestSuites{Name: "openstatus"}
+	var total int64
+	for _, s := range suites {
+		js := junitTestSuite{Name: s.Name, Tests: s.tests(), Failures: s.failures()}
+		var suiteTime int64
+		for _, c := range s.Cases {
+			suiteTime += c.Latency
+			tc := junitTestCase{Name: c.Name, ClassName: s.Name, Time: seconds(c.Latency)}
+			if c.Failed() {
+				msg := c.Error
+				if msg == "" {
+					msg = "status " + c.Status
+				}
+				tc.Failure = &junitFailure{Message: msg, Type: c.Status, Text: msg}
+			}
+			js.Cases = append(js.Cases, tc)
+		}
+		if s.Error != "" {
+			js.Cases = append(js.Cases, junitTestCase{
+				Name:      "run",
+				ClassName: s.Name,
+				Time:      seconds(0),
+				Failure:   &junitFailure{Message: s.Error, Type: "error", Text: s.Error},
+			})
+		}
+		js.Time = seconds(suiteTime)
+		total += suiteTime
+		out.Tests += js.Tests
+		out.Failures += js.Failures
+		out.Suites = append(out.Suites, js)
+	}
+	out.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func writeTAP(w io.Writer, suites []Suite) error {
+	total := 0
+	for _, s := range suites {
+		total += s.tests()
+	}
+	fmt.Fprintln(w, "TAP version 13")
+	fmt.Fprintf(w, "1..%d\n", total)
+	n := 0
+	for _, s := range suites {
+		for _, c := range s.Cases {
+			n++
+			status := "ok"
+			if c.Failed() {
+				status = "not ok"
+			}
+			fmt.Fprintf(w, "%s %d - %s %s (%dms)\n", status, n, s.Name, c.Name, c.Latency)
+			if c.Failed() && c.Error != "" {
+				fmt.Fprintln(w, "  ---")
+				fmt.Fprintf(w, "  message: %q\n", c.Error)
+				fmt.Fprintln(w, "  ...")
+			}
+		}
+		if s.Error != "" {
+			n++
+			fmt.Fprintf(w, "not ok %d - %s\n", n, s.Name)
+			fmt.Fprintln(w, "  ---")
+			fmt.Fprintf(w, "  message: %q\n", s.Error)
+			fmt.Fprintln(w, "  ...")
+		}
+	}
+	return nil
+}
+
+// escapeGitHub escapes a workflow command value.
+func escapeGitHub(s string, property bool) string {
+	r := strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	s = r.Replace(s)
+	if property {
+		s = strings.NewReplacer(":", "%3A", ",", "%2C").Replace(s)
+	}
+	return s
+}
+
+func writeGitHub(w io.Writer, suites []Suite) error {
+	var passed, failed int
+	for _, s := range suites {
+		for _, c := range s.Cases {
+			if !c.Failed() {
+				passed++
+				continue
+			}
+			failed++
+			msg := c.Error
+			if msg == "" {
+				msg = "status " + c.Status
+			}
+			title := fmt.Sprintf("%s (%s)", s.Name, c.Name)
+			fmt.Fprintf(w, "::error title=%s::%s\n", escapeGitHub(title, true), escapeGitHub(msg, false))
+		}
+		if s.Error != "" {
+			failed++
+			fmt.Fprintf(w, "::error title=%s::%s\n", escapeGitHub(s.Name, true), escapeGitHub(s.Error, false))
+		}
+	}
+	fmt.Fprintf(w, "::notice title=openstatus::%d passed, %d failed\n", passed, failed)
+	return nil
+}
+
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+func writeMarkdown(w io.Writer, suites []Suite) error {
+	for i, s := range suites {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		icon := "✅"
+		if s.failures() > 0 {
+			icon = "❌"
+		}
+		fmt.Fprintf(w, "### %s %s\n\n", icon, escapeMarkdown(s.Name))
+		if s.Error != "" {
+			fmt.Fprintf(w, "%s\n", escapeMarkdown(s.Error))
+			if len(s.Cases) == 0 {
+				continue
+			}
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, "| Region | Latency (ms) | Status | Error |")
+		fmt.Fprintln(w, "|--------|-------------:|--------|-------|")
+		for _, c := range s.Cases {
+			fmt.Fprintf(w, "| %s | %d | %s | %s |\n", escapeMarkdown(c.Name), c.Latency, c.Status, escapeMarkdown(c.Error))
+		}
+	}
+	return nil
+}
+
+// Options holds the --format and --report-file flags of commands that
+// print check results.
+type Options struct {
+	Format Format
+	File   string
+}
+
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "Output format: table, junit, tap, github or markdown",
+			DefaultText: "table",
+		},
+		&cli.StringFlag{
+			Name:  "report-file",
+			Usage: "Write the results to this file; the format is taken from --format or the extension (.xml, .tap, .md)",
+		},
+	}
+}
+
+// OptionsFromCmd reads the report flags. Without --format, a report file
+// gets the format matching its extension.
+func OptionsFromCmd(cmd *cli.Command) (Options, error) {
+	opts := Options{Format: FormatTable, File: cmd.String("report-file")}
+	if f := cmd.String("format"); f != "" {
+		format, err := ParseFormat(f)
+		if err != nil {
+			return Options{}, err
+		}
+		opts.Format = format
+	} else if opts.File != "" {
+		format, ok := FormatForFile(opts.File)
+		if !ok {
+			return Options{}, fmt.Errorf("cannot infer the report format of %s, pass --format", opts.File)
+		}
+		opts.Format = format
+	}
+	if opts.File != "" && opts.Format == FormatTable {
+		return Options{}, fmt.Errorf("the table format cannot be written to a report file")
+	}
+	return opts, nil
+}
+
+// ReplacesOutput reports whether the report is printed instead of the
+// regular output of the command.
+func (o Options) ReplacesOutput() bool {
+	return o.File == "" && o.Format != FormatTable
+}
+
+// Emit writes the report to the report file, or to w when it replaces the
+// regular output. It does nothing for the table format.
+func (o Options) Emit(w io.Writer, suites []Suite) error {
+	if o.File != "" {
+		return WriteFile(o.File, o.Format, suites)
+	}
+	if o.ReplacesOutput() {
+		return Write(w, o.Format, suites)
+	}
+	return nil
+}
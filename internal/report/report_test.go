@@ -0,0 +1,138 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testSuites = []Suite{
+	{Name: "Monitor 1", Cases: []Case{
+		{Name: "iad", Latency: 318, Status: StatusPass},
+		{Name: "fra", Latency: 1200, Status: StatusFail, Error: "connection refused"},
+	}},
+	{Name: "Monitor 2", Error: "failed to trigger monitor test"},
+}
+
+func TestWriteJUnit(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJUnit, testSuites); err != nil {
+		t.Fatal(err)
+	}
+
+	var got junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buf.String())
+	}
+	if got.Tests != 3 || got.Failures != 2 || len(got.Suites) != 2 {
+		t.Fatalf("tests=%d failures=%d suites=%d, want 3/2/2", got.Tests, got.Failures, len(got.Suites))
+	}
+	fra := got.Suites[0].Cases[1]
+	if fra.Name != "fra" || fra.Time != "1.200" || fra.Failure == nil || fra.Failure.Message != "connection refused" {
+		t.Errorf("unexpected fra test case %+v", fra)
+	}
+	if got.Suites[0].Cases[0].Failure != nil {
+		t.Error("passing test case has a failure")
+	}
+}
+
+func TestWriteTAP(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatTAP, testSuites); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"TAP version 13\n1..3\n",
+		"ok 1 - Monitor 1 iad (318ms)\n",
+		"not ok 2 - Monitor 1 fra (1200ms)\n  ---\n  message: \"connection refused\"\n  ...\n",
+		"not ok 3 - Monitor 2\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteGitHub(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	suites := []Suite{{Name: "Monitor 1", Cases: []Case{
+		{Name: "fra", Status: StatusFail, Error: "line 1\nline 2: 100%"},
+	}}}
+	if err := Write(&buf, FormatGitHub, suites); err != nil {
+		t.Fatal(err)
+	}
+	want := "::error title=Monitor 1 (fra)::line 1%0Aline 2: 100%25\n::notice title=openstatus::0 passed, 1 failed\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatMarkdown, testSuites); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"### ❌ Monitor 1\n",
+		"| fra | 1200 | fail | connection refused |\n",
+		"### ❌ Monitor 2\n\nfailed to trigger monitor test\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+	if f, err := ParseFormat("JUnit"); err != nil || f != FormatJUnit {
+		t.Errorf("ParseFormat(JUnit) = %q, %v", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("ParseFormat(xml) = nil error, want error")
+	}
+	for path, want := range map[string]Format{"out.xml": FormatJUnit, "out.tap": FormatTAP, "summary.md": FormatMarkdown} {
+		if got, ok := FormatForFile(path); !ok || got != want {
+			t.Errorf("FormatForFile(%q) = %q, want %q", path, got, want)
+		}
+	}
+	if _, ok := FormatForFile("out.txt"); ok {
+		t.Error("FormatForFile(out.txt) guessed a format")
+	}
+}
+
+func TestEmit(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "results.xml")
+	opts := Options{Format: FormatJUnit, File: path}
+	if opts.ReplacesOutput() {
+		t.Error("a report file should not replace the regular output")
+	}
+	var stdout bytes.Buffer
+	if err := opts.Emit(&stdout, testSuites); err != nil {
+		t.Fatal(err)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("expected nothing on stdout, got %q", stdout.String())
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(b, []byte(xml.Header)) {
+		t.Errorf("report file is not JUnit XML:\n%s", b)
+	}
+
+	if err := (Options{Format: FormatTable}).Emit(&stdout, testSuites); err != nil || stdout.Len() != 0 {
+		t.Errorf("table format should emit nothing, got %q, %v", stdout.String(), err)
+	}
+}
@@ -0,0 +1,78 @@
+package run
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/openstatusHQ/cli/internal/config"
+	"github.com/openstatusHQ/cli/internal/monitors"
+)
+
+// applyBudget checks a run against its latency budget. A region slower than
+// its MaxLatency is marked as failed; the checks across regions are recorded as
+// violations.
+func applyBudget(res monitors.MonitorRunResult, b config.LatencyBudget) monitors.MonitorRunResult {
+	if b.IsZero() {
+		return res
+	}
+	res.Results = slices.Clone(res.Results)
+
+	for i, r := range res.Results {
+		if limit := b.MaxLatency.For(r.Region); limit > 0 && r.Status == "pass" && r.Latency > limit {
+			res.Results[i].Status = "fail"
+			res.Results[i].Error = fmt.Sprintf("latency %dms exceeds budget of %dms", r.Latency, limit)
+		}
+	}
+
+	if b.P95Latency > 0 && len(res.Results) > 0 {
+		if p95 := p95Latency(res.Results); p95 > b.P95Latency {
+			res.Violations = append(res.Violations, fmt.Sprintf("p95 latency %dms exceeds budget of %dms", p95, b.P95Latency))
+		}
+	}
+
+	passing := 0
+	status := make(map[string]string, len(res.Results))
+	for _, r := range res.Results {
+		status[r.Region] = r.Status
+		if r.Status == "pass" {
+			passing++
+		}
+	}
+	if b.MinPassingRegions > 0 && passing < b.MinPassingRegions {
+		res.Violations = append(res.Violations, fmt.Sprintf("%d regions passed, at least %d required", passing, b.MinPassingRegions))
+	}
+	for _, region := range b.RequiredRegions {
+		switch s, ok := status[region]; {
+		case !ok:
+			res.Violations = append(res.Violations, fmt.Sprintf("required region %s did not run", region))
+		case s != "pass":
+			res.Violations = append(res.Violations, fmt.Sprintf("required region %s did not pass", region))
+		}
+	}
+	return res
+}
+
+// p95Latency returns the nearest-rank 95th percentile of the region latencies.
+func p95Latency(results []monitors.RegionRunResult) int64 {
+	latencies := make([]int64, len(results))
+	for i, r := range results {
+		latencies[i] = r.Latency
+	}
+	slices.Sort(latencies)
+	rank := int(math.Ceil(0.95 * float64(len(latencies))))
+	return latencies[max(rank-1, 0)]
+}
+
+// failed reports whether a region failed or the budget was blown.
+func failed(res monitors.MonitorRunResult) bool {
+	if len(res.Violations) > 0 {
+		return true
+	}
+	for _, r := range res.Results {
+		if r.Failed() {
+			return true
+		}
+	}
+	return false
+}
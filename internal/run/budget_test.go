@@ -0,0 +1,94 @@
+package run_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openstatusHQ/cli/internal/config"
+	"github.com/openstatusHQ/cli/internal/monitors"
+	"github.com/openstatusHQ/cli/internal/run"
+)
+
+func Test_ApplyBudget(t *testing.T) {
+	t.Parallel()
+
+	res := monitors.MonitorRunResult{
+		MonitorID: "1",
+		Results: []monitors.RegionRunResult{
+			{Region: "iad", Latency: 120, Status: "pass"},
+			{Region: "ams", Latency: 300, Status: "pass"},
+			{Region: "syd", Latency: 9000, Status: "pass"},
+			{Region: "gru", Latency: 50, Status: "fail", Error: "timeout"},
+		},
+	}
+
+	t.Run("No budget keeps the result", func(t *testing.T) {
+		got := run.ApplyBudget(res, config.LatencyBudget{})
+		if len(got.Violations) != 0 || got.Results[2].Status != "pass" {
+			t.Errorf("Unexpected result %+v", got)
+		}
+	})
+
+	t.Run("Slow regions fail", func(t *testing.T) {
+		got := run.ApplyBudget(res, config.LatencyBudget{MaxLatency: config.RegionLatency{"default": 2000}})
+		if got.Results[2].Status != "fail" || !strings.Contains(got.Results[2].Error, "9000ms exceeds budget of 2000ms") {
+			t.Errorf("Expected syd to fail, got %+v", got.Results[2])
+		}
+		if res.Results[2].Status != "pass" {
+			t.Error("Expected the original result to be unchanged")
+		}
+	})
+
+	t.Run("Regions have their own thresholds", func(t *testing.T) {
+		got := run.ApplyBudget(res, config.LatencyBudget{MaxLatency: config.RegionLatency{"syd": 10000, "default": 200}})
+		if got.Results[1].Status != "fail" || !strings.Contains(got.Results[1].Error, "300ms exceeds budget of 200ms") {
+			t.Errorf("Expected ams to fail on the default, got %+v", got.Results[1])
+		}
+		if got.Results[0].Status != "pass" || got.Results[2].Status != "pass" {
+			t.Errorf("Expected iad and syd to pass, got %+v", got.Results)
+		}
+	})
+
+	t.Run("Checks across regions", func(t *testing.T) {
+		got := run.ApplyBudget(res, config.LatencyBudget{
+			P95Latency:        1000,
+			MinPassingRegions: 4,
+			RequiredRegions:   []string{"iad", "gru", "fra"},
+		})
+		want := []string{
+			"p95 latency 9000ms exceeds budget of 1000ms",
+			"3 regions passed, at least 4 required",
+			"required region gru did not pass",
+			"required region fra did not run",
+		}
+		if strings.Join(got.Violations, "\n") != strings.Join(want, "\n") {
+			t.Errorf("Expected violations %q, got %q", want, got.Violations)
+		}
+		if suite := got.Suite(); len(suite.Cases) != len(res.Results)+len(want) {
+			t.Errorf("Expected one report case per violation, got %+v", suite.Cases)
+		}
+	})
+
+	t.Run("Budget met", func(t *testing.T) {
+		got := run.ApplyBudget(res, config.LatencyBudget{P95Latency: 10000, MinPassingRegions: 3, RequiredRegions: []string{"iad"}})
+		if len(got.Violations) != 0 {
+			t.Errorf("Expected no violations, got %q", got.Violations)
+		}
+	})
+}
+
+func Test_Failed(t *testing.T) {
+	t.Parallel()
+
+	pass := monitors.MonitorRunResult{Results: []monitors.RegionRunResult{{Region: "iad", Status: "pass"}}}
+	if run.Failed(pass) {
+		t.Error("Expected a passing run not to fail")
+	}
+	unknown := monitors.MonitorRunResult{Results: []monitors.RegionRunResult{
+		{Region: "iad", Status: "pass"},
+		{Region: "ams", Status: "unknown", Error: "unknown job type: ping"},
+	}}
+	if !run.Failed(unknown) {
+		t.Error("Expected an unknown region to fail the run, as in the reports")
+	}
+}
@@ -0,0 +1,5 @@
+package run
+
+var ApplyBudget = applyBudget
+
+var Failed = failed
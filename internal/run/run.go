@@ -2,163 +2,84 @@ package run
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
-	"strings"
+	"strconv"
 	"sync"
-	"time"
 
 	"github.com/fatih/color"
 	"github.com/logrusorgru/aurora/v4"
 	"github.com/rodaine/table"
 	"github.com/urfave/cli/v3"
 
-	"github.com/openstatusHQ/cli/internal/api"
 	"github.com/openstatusHQ/cli/internal/auth"
 	output "github.com/openstatusHQ/cli/internal/cli"
 	"github.com/openstatusHQ/cli/internal/config"
 	"github.com/openstatusHQ/cli/internal/monitors"
+	"github.com/openstatusHQ/cli/internal/report"
 )
 
-type runRegionResult struct {
-	Region  string `json:"region"`
-	Latency int64  `json:"latency"`
-	Status  string `json:"status"`
-	Error   string `json:"error,omitempty"`
-}
-
-type runMonitorResult struct {
-	MonitorID string            `json:"monitor_id"`
-	Results   []runRegionResult `json:"results"`
-}
-
 // MonitorTrigger triggers a monitor run and returns the results without printing.
-func MonitorTrigger(ctx context.Context, httpClient *http.Client, apiKey string, monitorId string) (runMonitorResult, error) {
-	if monitorId == "" {
-		return runMonitorResult{}, fmt.Errorf("monitor ID is required")
-	}
-
-	url := fmt.Sprintf("%s/monitor/%s/run", api.APIBaseURL, monitorId)
-
-	client := &http.Client{
-		Timeout:   2 * time.Minute,
-		Transport: httpClient.Transport,
-	}
-
-	payload := strings.NewReader("{}")
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, payload)
-	if err != nil {
-		return runMonitorResult{}, err
-	}
-	req.Header.Add("x-openstatus-key", apiKey)
-	res, err := client.Do(req)
-	if err != nil {
-		return runMonitorResult{}, err
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		return runMonitorResult{}, fmt.Errorf("failed to trigger monitor test")
-	}
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return runMonitorResult{}, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	var result []json.RawMessage
-	err = json.Unmarshal(body, &result)
-	if err != nil {
-		return runMonitorResult{}, err
-	}
-
-	var regionResults []runRegionResult
-	for _, r := range result {
-		rr := monitors.RunResult{}
-
-		if err := json.Unmarshal(r, &rr); err != nil {
-			return runMonitorResult{}, fmt.Errorf("unable to unmarshal: %w", err)
-		}
-
-		entry := runRegionResult{
-			Region:  rr.Region,
-			Latency: rr.Latency,
-			Status:  "pass",
-		}
-
-		switch rr.JobType {
-		case "tcp":
-			var tcp monitors.TCPRunResult
-			if err := json.Unmarshal(r, &tcp); err != nil {
-				return runMonitorResult{}, fmt.Errorf("unable to unmarshal: %w", err)
-			}
-			if tcp.ErrorMessage != "" {
-				entry.Status = "fail"
-				entry.Error = tcp.ErrorMessage
-			}
-		case "http":
-			var httpResult monitors.HTTPRunResult
-			if err := json.Unmarshal(r, &httpResult); err != nil {
-				return runMonitorResult{}, fmt.Errorf("unable to unmarshal: %w", err)
-			}
-			if httpResult.Error != "" {
-				entry.Status = "fail"
-				entry.Error = httpResult.Error
-			}
-		default:
-			entry.Status = "unknown"
-			entry.Error = fmt.Sprintf("unknown job type: %s", rr.JobType)
-		}
-
-		regionResults = append(regionResults, entry)
-	}
-
-	return runMonitorResult{
-		MonitorID: monitorId,
-		Results:   regionResults,
-	}, nil
+func MonitorTrigger(ctx context.Context, httpClient *http.Client, apiKey string, monitorId string) (monitors.MonitorRunResult, error) {
+	return monitors.RunMonitor(ctx, httpClient, apiKey, monitorId)
 }
 
 // printMonitorResult prints a single monitor's results to stdout.
-func printMonitorResult(res runMonitorResult) bool {
+func printMonitorResult(res monitors.MonitorRunResult) bool {
 	var inError bool
 	fmt.Println(aurora.Bold(fmt.Sprintf("Monitor: %s", res.MonitorID)))
 	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
 	columnFmt := color.New(color.FgYellow).SprintfFunc()
 
-	tbl := table.New("Region", "Latency (ms)", "Status")
+	tbl := table.New("Region", "Latency (ms)", "Status", "Error")
 	tbl.WithHeaderFormatter(headerFmt).WithFirstColumnFormatter(columnFmt)
 
 	for _, entry := range res.Results {
-		if entry.Status == "fail" {
-			tbl.AddRow(entry.Region, entry.Latency, color.RedString("fail"))
+		if entry.Failed() {
+			tbl.AddRow(entry.Region, entry.Latency, color.RedString("%s", entry.Status), entry.Error)
 			inError = true
 		} else {
-			tbl.AddRow(entry.Region, entry.Latency, color.GreenString("pass"))
+			tbl.AddRow(entry.Region, entry.Latency, color.GreenString("pass"), entry.Error)
 		}
 	}
 	tbl.Print()
 
-	if inError {
+	for _, v := range res.Violations {
+		fmt.Println(color.RedString("Budget exceeded: %s", v))
+	}
+	switch {
+	case inError:
 		fmt.Println(color.RedString("Some regions failed"))
-	} else {
+	case len(res.Violations) > 0:
+		fmt.Println(color.RedString("Latency budget exceeded"))
+		inError = true
+	default:
 		fmt.Println(color.GreenString("All regions passed"))
 	}
 	fmt.Println()
 	return inError
 }
 
+func entryLabel(e config.TestEntry) string {
+	if e.Name != "" {
+		return e.Name
+	}
+	return strconv.Itoa(e.ID)
+}
+
 func RunCmd() *cli.Command {
 	runCmd := cli.Command{
 		Name:    "run",
 		Aliases: []string{"r"},
 		Usage:   "Run your uptime tests",
 		UsageText: `openstatus run
-  openstatus run --config custom-config.yaml`,
+  openstatus run --config custom-config.yaml
+  openstatus run --format junit > results.xml
+  openstatus run --report-file results.xml
+  openstatus run --format github
+  openstatus run --env prod`,
 		Description: `Run the uptime tests defined in the config.openstatus.yaml.
 The config file should be in the following format:
 
@@ -166,7 +87,27 @@ tests:
   ids:
      - monitor-id-1
      - monitor-id-2
-
+  names:
+     - api-prod            # logical name in openstatus.lock (openstatus.<env>.lock
+                           # with --env), or display name
+  monitors:
+     - id: monitor-id-3      # or name: api-prod
+       maxLatency: 2000        # ms, for each region
+       p95Latency: 1500        # ms, across regions
+       minPassingRegions: 3
+       requiredRegions: [iad, fra]
+     - name: api-prod
+       maxLatency:             # ms, by region
+         syd: 1200
+         default: 800          # the regions not listed
+
+A region slower than its maxLatency fails, and the run exits with a non-zero
+status when any budget is exceeded.
+
+Use --format junit, tap, github or markdown to print a report for your CI
+system instead of the table, with one test suite per monitor and one test case
+per region. --report-file writes the report to a file and keeps the table on
+the terminal, e.g. to archive results.xml as a build artifact.
      `,
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			apiKey, err := auth.ResolveAccessToken(cmd)
@@ -181,19 +122,25 @@ tests:
 				}
 			}
 
+			reportOpts, err := report.OptionsFromCmd(cmd)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+
 			conf, err := config.ReadConfig(path)
 			if err != nil {
 				return err
 			}
-			size := len(conf.Tests.Ids)
+			entries := conf.Tests.Entries()
+			size := len(entries)
 
-			if !output.IsQuiet() && !output.IsJSONOutput() {
+			if !output.IsQuiet() && !output.IsJSONOutput() && !reportOpts.ReplacesOutput() {
 				fmt.Print("Tests are running\n\n")
 			}
 
 			type indexedResult struct {
 				index  int
-				result runMonitorResult
+				result monitors.MonitorRunResult
 				err    error
 			}
 
@@ -201,26 +148,56 @@ tests:
 			var wg sync.WaitGroup
 			var mu sync.Mutex
 
-			for i, id := range conf.Tests.Ids {
+			client := monitors.NewMonitorClient(apiKey)
+			env := cmd.String("env")
+			for i, entry := range entries {
 				wg.Add(1)
-				go func(idx, id int) {
+				go func(idx int, entry config.TestEntry) {
 					defer wg.Done()
-					res, err := MonitorTrigger(ctx, http.DefaultClient, apiKey, fmt.Sprintf("%d", id))
+					id := strconv.Itoa(entry.ID)
+					var res monitors.MonitorRunResult
+					var err error
+					if entry.Name != "" {
+						id, err = monitors.ResolveMonitorID(ctx, client, entry.Name, env)
+					}
+					if err == nil {
+						res, err = MonitorTrigger(ctx, http.DefaultClient, apiKey, id)
+					}
+					if err == nil {
+						res = applyBudget(res, entry.LatencyBudget)
+					}
 					mu.Lock()
 					results[idx] = indexedResult{index: idx, result: res, err: err}
 					mu.Unlock()
-				}(i, id)
+				}(i, entry)
 			}
 			wg.Wait()
 
 			// Print results sequentially to avoid interleaved output
 			var hasErrors bool
+			suites := make([]report.Suite, len(results))
+			for i, r := range results {
+				if r.err != nil {
+					hasErrors = true
+					suites[i] = report.Suite{Name: "Monitor " + entryLabel(entries[r.index]), Error: r.err.Error()}
+					continue
+				}
+				suites[i] = r.result.Suite()
+				if failed(r.result) {
+					hasErrors = true
+				}
+			}
+
+			if err := reportOpts.Emit(os.Stdout, suites); err != nil {
+				return cli.Exit(fmt.Sprintf("Failed to write report: %v", err), 1)
+			}
 
-			if output.IsJSONOutput() {
-				var allResults []runMonitorResult
+			switch {
+			case reportOpts.ReplacesOutput():
+			case output.IsJSONOutput():
+				var allResults []monitors.MonitorRunResult
 				for _, r := range results {
 					if r.err != nil {
-						hasErrors = true
 						continue
 					}
 					allResults = append(allResults, r.result)
@@ -228,16 +205,13 @@ tests:
 				if err := output.PrintJSON(allResults); err != nil {
 					return err
 				}
-			} else {
+			default:
 				for _, r := range results {
 					if r.err != nil {
-						hasErrors = true
-						fmt.Printf("Monitor %d: %v\n\n", conf.Tests.Ids[r.index], r.err)
+						fmt.Printf("Monitor %s: %v\n\n", entryLabel(entries[r.index]), r.err)
 						continue
 					}
-					if printMonitorResult(r.result) {
-						hasErrors = true
-					}
+					printMonitorResult(r.result)
 				}
 			}
 
@@ -246,7 +220,7 @@ tests:
 			}
 			return nil
 		},
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.StringFlag{
 				Name:        "config",
 				Usage:       "The configuration file",
@@ -259,7 +233,8 @@ tests:
 				Aliases: []string{"t"},
 				Sources: cli.EnvVars("OPENSTATUS_API_TOKEN"),
 			},
-		},
+			monitors.ResolveEnvFlag(),
+		}, report.Flags()...),
 	}
 	return &runCmd
 }
@@ -0,0 +1,262 @@
+// Package slo computes availability and error budgets from check results.
+package slo
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+)
+
+// Request statuses of a sample, as reported by the response logs.
+const (
+	StatusSuccess  = "success"
+	StatusDegraded = "degraded"
+	StatusError    = "error"
+)
+
+// Sample is a single check of a monitor from one region.
+type Sample struct {
+	Region string
+	Status string
+}
+
+type Options struct {
+	// Target is the availability objective in percent, e.g. 99.9.
+	Target float64
+	// Window is the period the objective applies to.
+	Window time.Duration
+	// Observed is the part of the window covered by the samples. It is
+	// shorter than Window when the window exceeds the log retention, and
+	// only reported: the budget assumes the whole window fails at the
+	// observed pace.
+	Observed time.Duration
+}
+
+// Counts are the checks of a monitor or of one of its regions. Degraded
+// checks count as available.
+type Counts struct {
+	Total               int     `json:"total"`
+	Successful          int     `json:"successful"`
+	Degraded            int     `json:"degraded"`
+	Failed              int     `json:"failed"`
+	AvailabilityPercent float64 `json:"availability_percent"`
+	DegradedPercent     float64 `json:"degraded_percent"`
+}
+
+func (c *Counts) add(status string) {
+	c.Total++
+	switch status {
+	case StatusSuccess:
+		c.Successful++
+	case StatusDegraded:
+		c.Degraded++
+	default:
+		c.Failed++
+	}
+}
+
+func (c *Counts) finish() {
+	if c.Total == 0 {
+		return
+	}
+	c.AvailabilityPercent = 100 * float64(c.Successful+c.Degraded) / float64(c.Total)
+	c.DegradedPercent = 100 * float64(c.Degraded) / float64(c.Total)
+}
+
+type Region struct {
+	Region string `json:"region"`
+	Counts
+}
+
+type Result struct {
+	MonitorID     string  `json:"monitor_id"`
+	Name          string  `json:"name"`
+	TargetPercent float64 `json:"target_percent"`
+	Window        string  `json:"window"`
+	Observed      string  `json:"observed"`
+	Counts
+	// NoData is set when there was no check in the observed window; the
+	// availability and budget figures are then meaningless.
+	NoData bool `json:"no_data,omitempty"`
+	// ErrorBudgetRemainingPercent is the share of the window's error budget
+	// left, assuming checks fail at the observed pace over the whole window:
+	// 100 * (1 - BurnRate). It is negative once the budget is exhausted.
+	ErrorBudgetRemainingPercent float64 `json:"error_budget_remaining_percent"`
+	// BurnRate is the observed failure rate divided by the allowed one: 1
+	// spends the budget exactly over the window, above 1 exhausts it early.
+	BurnRate float64  `json:"burn_rate"`
+	Met      bool     `json:"met"`
+	Regions  []Region `json:"regions,omitempty"`
+	// Error is set when the logs of the monitor could not be fetched.
+	Error string `json:"error,omitempty"`
+}
+
+// FormatWindow prints a duration in days when it is a whole number of days.
+func FormatWindow(d time.Duration) string {
+	if d > 0 && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return d.String()
+}
+
+// Compute returns the SLO figures of a monitor from its samples.
+func Compute(id, name string, samples []Sample, opts Options) Result {
+	res := Result{
+		MonitorID:     id,
+		Name:          name,
+		TargetPercent: opts.Target,
+		Window:        FormatWindow(opts.Window),
+		Observed:      FormatWindow(opts.Observed.Round(time.Hour)),
+	}
+
+	regions := map[string]*Region{}
+	for _, s := range samples {
+		res.add(s.Status)
+		r, ok := regions[s.Region]
+		if !ok {
+			r = &Region{Region: s.Region}
+			regions[s.Region] = r
+		}
+		r.add(s.Status)
+	}
+	res.finish()
+	for _, r := range regions {
+		r.finish()
+		res.Regions = append(res.Regions, *r)
+	}
+	slices.SortFunc(res.Regions, func(a, b Region) int { return strings.Compare(a.Region, b.Region) })
+
+	if res.Total == 0 {
+		res.NoData = true
+		return res
+	}
+
+	budget := 1 - opts.Target/100
+	failureRate := float64(res.Failed) / float64(res.Total)
+	if budget > 0 {
+		res.BurnRate = failureRate / budget
+	}
+	// The observed failure rate is extrapolated to the whole window, even
+	// when the logs only cover part of it.
+	res.ErrorBudgetRemainingPercent = 100 * (1 - res.BurnRate)
+	res.Met = res.AvailabilityPercent >= opts.Target
+	return res
+}
+
+func percent(v float64) string {
+	return fmt.Sprintf("%.3f%%", v)
+}
+
+func status(r Result) string {
+	switch {
+	case r.Error != "":
+		return "error"
+	case r.NoData:
+		return "no data"
+	case r.Met:
+		return "met"
+	default:
+		return "breached"
+	}
+}
+
+func colorizeStatus(s string) string {
+	switch s {
+	case "met":
+		return color.GreenString("● met")
+	case "breached", "error":
+		return color.RedString("● " + s)
+	default:
+		return "● " + s
+	}
+}
+
+// WriteTable prints one row per monitor, followed by the regions of each
+// monitor when there is a single one.
+func WriteTable(w io.Writer, results []Result) {
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	columnFmt := color.New(color.FgYellow).SprintfFunc()
+
+	tbl := table.New("Monitor", "Status", "Availability", "Target", "Degraded", "Budget Left", "Burn Rate", "Checks")
+	tbl.WithHeaderFormatter(headerFmt).WithFirstColumnFormatter(columnFmt).WithWriter(w)
+	for _, r := range results {
+		if r.Error != "" || r.NoData {
+			tbl.AddRow(r.Name, colorizeStatus(status(r)), "-", percent(r.TargetPercent), "-", "-", "-", r.Total)
+			continue
+		}
+		tbl.AddRow(r.Name, colorizeStatus(status(r)), percent(r.AvailabilityPercent), percent(r.TargetPercent),
+			percent(r.DegradedPercent), fmt.Sprintf("%.1f%%", r.ErrorBudgetRemainingPercent), fmt.Sprintf("%.2fx", r.BurnRate), r.Total)
+	}
+	tbl.Print()
+
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Fprintf(w, "\n%s: %s\n", r.Name, r.Error)
+		}
+	}
+
+	if len(results) == 1 && len(results[0].Regions) > 0 {
+		fmt.Fprintln(w)
+		regions := table.New("Region", "Availability", "Degraded", "Failed", "Checks")
+		regions.WithHeaderFormatter(headerFmt).WithFirstColumnFormatter(columnFmt).WithWriter(w)
+		for _, r := range results[0].Regions {
+			regions.AddRow(r.Region, percent(r.AvailabilityPercent), percent(r.DegradedPercent), r.Failed, r.Total)
+		}
+		regions.Print()
+	}
+
+	if len(results) > 0 && results[0].Observed != results[0].Window {
+		fmt.Fprintf(w, "\nOnly the last %s of the %s window are covered by the response logs retention.\n", results[0].Observed, results[0].Window)
+	}
+}
+
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+// WriteMarkdown renders a summary table and the regions of each monitor,
+// for reliability reviews.
+func WriteMarkdown(w io.Writer, results []Result) {
+	window, observed := "", ""
+	if len(results) > 0 {
+		window, observed = results[0].Window, results[0].Observed
+	}
+	fmt.Fprintf(w, "## SLO report (%s window)\n\n", window)
+	if observed != window {
+		fmt.Fprintf(w, "Only the last %s are covered by the response logs retention.\n\n", observed)
+	}
+
+	fmt.Fprintln(w, "| Monitor | Status | Availability | Target | Degraded | Budget left | Burn rate | Checks |")
+	fmt.Fprintln(w, "|---------|--------|-------------:|-------:|---------:|------------:|----------:|-------:|")
+	for _, r := range results {
+		icon := map[string]string{"met": "✅", "breached": "❌", "error": "⚠️", "no data": "➖"}[status(r)]
+		if r.Error != "" || r.NoData {
+			fmt.Fprintf(w, "| %s | %s %s | - | %s | - | - | - | %d |\n", escapeMarkdown(r.Name), icon, status(r), percent(r.TargetPercent), r.Total)
+			continue
+		}
+		fmt.Fprintf(w, "| %s | %s %s | %s | %s | %s | %.1f%% | %.2fx | %d |\n", escapeMarkdown(r.Name), icon, status(r),
+			percent(r.AvailabilityPercent), percent(r.TargetPercent), percent(r.DegradedPercent),
+			r.ErrorBudgetRemainingPercent, r.BurnRate, r.Total)
+	}
+
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Fprintf(w, "\n### %s\n\n%s\n", escapeMarkdown(r.Name), escapeMarkdown(r.Error))
+			continue
+		}
+		if len(r.Regions) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n### %s\n\n", escapeMarkdown(r.Name))
+		fmt.Fprintln(w, "| Region | Availability | Degraded | Failed | Checks |")
+		fmt.Fprintln(w, "|--------|-------------:|---------:|-------:|-------:|")
+		for _, reg := range r.Regions {
+			fmt.Fprintf(w, "| %s | %s | %s | %d | %d |\n", escapeMarkdown(reg.Region), percent(reg.AvailabilityPercent), percent(reg.DegradedPercent), reg.Failed, reg.Total)
+		}
+	}
+}
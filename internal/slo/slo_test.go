@@ -0,0 +1,88 @@
+package slo
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+func samples(region string, success, degraded, failed int) []Sample {
+	var out []Sample
+	for range success {
+		out = append(out, Sample{Region: region, Status: StatusSuccess})
+	}
+	for range degraded {
+		out = append(out, Sample{Region: region, Status: StatusDegraded})
+	}
+	for range failed {
+		out = append(out, Sample{Region: region, Status: StatusError})
+	}
+	return out
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestCompute(t *testing.T) {
+	t.Parallel()
+	s := append(samples("iad", 995, 4, 1), samples("fra", 998, 0, 2)...)
+	res := Compute("1", "API", s, Options{Target: 99.9, Window: 30 * day, Observed: 15 * day})
+
+	if res.Total != 2000 || res.Failed != 3 || res.Degraded != 4 {
+		t.Fatalf("unexpected counts %+v", res.Counts)
+	}
+	if !near(res.AvailabilityPercent, 99.85) || !near(res.DegradedPercent, 0.2) {
+		t.Errorf("availability = %v, degraded = %v, want 99.85/0.2", res.AvailabilityPercent, res.DegradedPercent)
+	}
+	// 0.15% failures against a 0.1% budget, extrapolated from half of the
+	// window to all of it.
+	if !near(res.BurnRate, 1.5) || !near(res.ErrorBudgetRemainingPercent, -50) {
+		t.Errorf("burn rate = %v, budget left = %v, want 1.5/-50", res.BurnRate, res.ErrorBudgetRemainingPercent)
+	}
+	if res.Met {
+		t.Error("99.85% should not meet a 99.9% target")
+	}
+	if res.Window != "30d" || res.Observed != "15d" {
+		t.Errorf("window = %s, observed = %s", res.Window, res.Observed)
+	}
+	if len(res.Regions) != 2 || res.Regions[0].Region != "fra" || !near(res.Regions[1].AvailabilityPercent, 99.9) {
+		t.Errorf("unexpected regions %+v", res.Regions)
+	}
+}
+
+func TestComputeNoData(t *testing.T) {
+	t.Parallel()
+	res := Compute("1", "API", nil, Options{Target: 99.9, Window: 7 * day, Observed: 7 * day})
+	if !res.NoData || res.Met {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	t.Parallel()
+	opts := Options{Target: 99, Window: 30 * day, Observed: 14 * day}
+	results := []Result{
+		Compute("1", "API", samples("iad", 100, 0, 0), opts),
+		{MonitorID: "2", Name: "Web", TargetPercent: 99, Window: "30d", Observed: "14d", Error: "not found"},
+	}
+	var buf bytes.Buffer
+	WriteMarkdown(&buf, results)
+	out := buf.String()
+	for _, want := range []string{
+		"## SLO report (30d window)\n",
+		"Only the last 14d are covered",
+		"| API | ✅ met | 100.000% | 99.000% | 0.000% | 100.0% | 0.00x | 100 |\n",
+		"| Web | ⚠️ error | - | 99.000% |",
+		"### API\n\n| Region |",
+		"### Web\n\nnot found\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
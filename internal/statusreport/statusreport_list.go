@@ -15,7 +15,7 @@ import (
 	output "github.com/openstatusHQ/cli/internal/cli"
 )
 
-type statusReportListEntry struct {
+type StatusReportListEntry struct {
 	ID        string `json:"id"`
 	Title     string `json:"title"`
 	Status    string `json:"status"`
@@ -23,6 +23,35 @@ type statusReportListEntry struct {
 	UpdatedAt string `json:"updated_at"`
 }
 
+func toStatusReportListEntries(reports []*status_reportv1.StatusReportSummary) []StatusReportListEntry {
+	entries := make([]StatusReportListEntry, 0, len(reports))
+	for _, r := range reports {
+		entries = append(entries, StatusReportListEntry{
+			ID:        r.GetId(),
+			Title:     r.GetTitle(),
+			Status:    statusToString(r.GetStatus()),
+			CreatedAt: r.GetCreatedAt(),
+			UpdatedAt: r.GetUpdatedAt(),
+		})
+	}
+	return entries
+}
+
+// OpenStatusReports returns the status reports that are not resolved yet.
+func OpenStatusReports(ctx context.Context, client status_reportv1connect.StatusReportServiceClient) ([]StatusReportListEntry, error) {
+	req := &status_reportv1.ListStatusReportsRequest{}
+	req.SetStatuses([]status_reportv1.StatusReportStatus{
+		status_reportv1.StatusReportStatus_STATUS_REPORT_STATUS_INVESTIGATING,
+		status_reportv1.StatusReportStatus_STATUS_REPORT_STATUS_IDENTIFIED,
+		status_reportv1.StatusReportStatus_STATUS_REPORT_STATUS_MONITORING,
+	})
+	resp, err := client.ListStatusReports(ctx, req)
+	if err != nil {
+		return nil, output.FormatError(err, "status-report", "")
+	}
+	return toStatusReportListEntries(resp.GetStatusReports()), nil
+}
+
 func ListStatusReports(ctx context.Context, client status_reportv1connect.StatusReportServiceClient, statusFilter string, limit int, s *output.Spinner) error {
 	req := &status_reportv1.ListStatusReportsRequest{}
 
@@ -49,17 +78,7 @@ func ListStatusReports(ctx context.Context, client status_reportv1connect.Status
 	reports := resp.GetStatusReports()
 
 	if output.IsJSONOutput() {
-		entries := make([]statusReportListEntry, 0, len(reports))
-		for _, r := range reports {
-			entries = append(entries, statusReportListEntry{
-				ID:        r.GetId(),
-				Title:     r.GetTitle(),
-				Status:    statusToString(r.GetStatus()),
-				CreatedAt: r.GetCreatedAt(),
-				UpdatedAt: r.GetUpdatedAt(),
-			})
-		}
-		return output.PrintJSON(entries)
+		return output.PrintJSON(toStatusReportListEntries(reports))
 	}
 
 	if len(reports) == 0 {
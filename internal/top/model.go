@@ -0,0 +1,530 @@
+package top
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	output "github.com/openstatusHQ/cli/internal/cli"
+	"github.com/openstatusHQ/cli/internal/monitors"
+)
+
+type screen int
+
+const (
+	screenMonitors screen = iota
+	screenMonitor
+	screenLog
+)
+
+type snapshotMsg struct {
+	snap Snapshot
+	err  error
+}
+
+type logsMsg struct {
+	monitorID string
+	logs      []monitors.ResponseLogEntry
+	err       error
+}
+
+type logDetailMsg struct {
+	logID  string
+	detail monitors.ResponseLogDetailOutput
+	err    error
+}
+
+type tickMsg struct{}
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	faintStyle    = lipgloss.NewStyle().Faint(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	warnStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	okStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	sparkStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+)
+
+func statusStyle(status string) lipgloss.Style {
+	switch status {
+	case "active", "success":
+		return okStyle
+	case "degraded":
+		return warnStyle
+	case "error":
+		return errorStyle
+	default:
+		return faintStyle
+	}
+}
+
+func dot(status string) string {
+	return statusStyle(status).Render("●")
+}
+
+type model struct {
+	ctx      context.Context
+	source   Source
+	interval time.Duration
+
+	screen  screen
+	snap    Snapshot
+	err     error
+	loading bool
+
+	// cursor and offset select and scroll the monitors list.
+	cursor, offset int
+
+	monitorID            string
+	logs                 []monitors.ResponseLogEntry
+	logsErr              error
+	logCursor, logOffset int
+
+	detail    *monitors.ResponseLogDetailOutput
+	detailErr error
+
+	width, height int
+}
+
+func newModel(ctx context.Context, source Source, interval time.Duration) model {
+	return model{ctx: ctx, source: source, interval: interval, loading: true, width: 100, height: 30}
+}
+
+func (m model) fetchSnapshot() tea.Cmd {
+	return func() tea.Msg {
+		snap, err := m.source.Snapshot(m.ctx)
+		return snapshotMsg{snap: snap, err: err}
+	}
+}
+
+func (m model) fetchLogs(monitorID string) tea.Cmd {
+	return func() tea.Msg {
+		logs, err := m.source.Logs(m.ctx, monitorID)
+		return logsMsg{monitorID: monitorID, logs: logs, err: err}
+	}
+}
+
+func (m model) fetchLogDetail(logID string) tea.Cmd {
+	monitorID := m.monitorID
+	return func() tea.Msg {
+		detail, err := m.source.LogDetail(m.ctx, monitorID, logID)
+		return logDetailMsg{logID: logID, detail: detail, err: err}
+	}
+}
+
+func (m model) tick() tea.Cmd {
+	return tea.Tick(m.interval, func(time.Time) tea.Msg { return tickMsg{} })
+}
+
+func (m model) Init() tea.Cmd {
+	return tea.Batch(m.fetchSnapshot(), m.tick())
+}
+
+// refresh reloads the snapshot, and the logs of the open monitor.
+func (m model) refresh() tea.Cmd {
+	cmds := []tea.Cmd{m.fetchSnapshot()}
+	if m.screen == screenMonitor {
+		cmds = append(cmds, m.fetchLogs(m.monitorID))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m model) selectedMonitor() (monitors.MonitorHealth, bool) {
+	if m.screen != screenMonitors {
+		i := slices.IndexFunc(m.snap.Monitors, func(h monitors.MonitorHealth) bool { return h.ID == m.monitorID })
+		if i < 0 {
+			return monitors.MonitorHealth{}, false
+		}
+		return m.snap.Monitors[i], true
+	}
+	if m.cursor < len(m.snap.Monitors) {
+		return m.snap.Monitors[m.cursor], true
+	}
+	return monitors.MonitorHealth{}, false
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.offset = scroll(m.cursor, m.offset, m.monitorRows())
+		m.logOffset = scroll(m.logCursor, m.logOffset, m.logRows())
+		return m, nil
+
+	case tickMsg:
+		return m, tea.Batch(m.refresh(), m.tick())
+
+	case snapshotMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		// Keep the cursor on the same monitor when the list changes.
+		selected, _ := m.selectedMonitor()
+		m.snap = msg.snap
+		if i := slices.IndexFunc(m.snap.Monitors, func(h monitors.MonitorHealth) bool { return h.ID == selected.ID }); i >= 0 && m.screen == screenMonitors {
+			m.cursor = i
+		}
+		m.cursor = min(m.cursor, max(0, len(m.snap.Monitors)-1))
+		m.offset = scroll(m.cursor, m.offset, m.monitorRows())
+		return m, nil
+
+	case logsMsg:
+		if msg.monitorID != m.monitorID {
+			return m, nil
+		}
+		m.logs, m.logsErr = msg.logs, msg.err
+		m.logCursor = min(m.logCursor, max(0, len(m.logs)-1))
+		m.logOffset = scroll(m.logCursor, m.logOffset, m.logRows())
+		return m, nil
+
+	case logDetailMsg:
+		if m.screen != screenLog {
+			return m, nil
+		}
+		m.detailErr = msg.err
+		if msg.err == nil {
+			m.detail = &msg.detail
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "r":
+		return m, m.refresh()
+	case "esc", "backspace", "left", "h":
+		switch m.screen {
+		case screenLog:
+			m.screen = screenMonitor
+			m.detail, m.detailErr = nil, nil
+		case screenMonitor:
+			m.screen = screenMonitors
+			m.monitorID, m.logs, m.logsErr = "", nil, nil
+		}
+		return m, nil
+	case "up", "k":
+		m.move(-1)
+		return m, nil
+	case "down", "j":
+		m.move(1)
+		return m, nil
+	case "pgup":
+		m.move(-max(1, m.monitorRows()))
+		return m, nil
+	case "pgdown":
+		m.move(max(1, m.monitorRows()))
+		return m, nil
+	case "enter", "right", "l":
+		switch m.screen {
+		case screenMonitors:
+			h, ok := m.selectedMonitor()
+			if !ok {
+				return m, nil
+			}
+			m.screen = screenMonitor
+			m.monitorID = h.ID
+			m.logs, m.logsErr, m.logCursor, m.logOffset = nil, nil, 0, 0
+			if h.Kind != "http" {
+				return m, nil
+			}
+			return m, m.fetchLogs(h.ID)
+		case screenMonitor:
+			if m.logCursor >= len(m.logs) {
+				return m, nil
+			}
+			m.screen = screenLog
+			m.detail, m.detailErr = nil, nil
+			return m, m.fetchLogDetail(m.logs[m.logCursor].ID)
+		}
+	}
+	return m, nil
+}
+
+func (m *model) move(delta int) {
+	switch m.screen {
+	case screenMonitors:
+		m.cursor = clamp(m.cursor+delta, 0, len(m.snap.Monitors)-1)
+		m.offset = scroll(m.cursor, m.offset, m.monitorRows())
+	case screenMonitor:
+		m.logCursor = clamp(m.logCursor+delta, 0, len(m.logs)-1)
+		m.logOffset = scroll(m.logCursor, m.logOffset, m.logRows())
+	}
+}
+
+func clamp(v, lo, hi int) int {
+	return max(lo, min(v, hi))
+}
+
+// scroll returns the offset of a list of rows visible lines that keeps
+// cursor in view.
+func scroll(cursor, offset, rows int) int {
+	if rows <= 0 {
+		return cursor
+	}
+	if cursor < offset {
+		return cursor
+	}
+	if cursor >= offset+rows {
+		return cursor - rows + 1
+	}
+	return offset
+}
+
+// monitorRows is the number of monitors that fit under the header and the
+// incidents and maintenances panels.
+func (m model) monitorRows() int {
+	used := 4 // header, blank line, table header, footer
+	if n := len(m.snap.Incidents); n > 0 {
+		used += n + 2
+	}
+	if n := len(m.snap.Maintenances); n > 0 {
+		used += n + 2
+	}
+	used += len(m.snap.Warnings)
+	return max(1, m.height-used)
+}
+
+// logRows is the number of logs that fit under the monitor details.
+func (m model) logRows() int {
+	return max(1, m.height-10)
+}
+
+func truncate(s string, width int) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+	r := []rune(s)
+	if width <= 1 || len(r) <= width-1 {
+		return string(r[:min(len(r), width)])
+	}
+	return string(r[:width-1]) + "…"
+}
+
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", max(0, width-lipgloss.Width(s)))
+}
+
+func (m model) View() string {
+	var b strings.Builder
+	switch m.screen {
+	case screenMonitors:
+		m.viewMonitors(&b)
+	case screenMonitor:
+		m.viewMonitor(&b)
+	case screenLog:
+		m.viewLog(&b)
+	}
+	return b.String()
+}
+
+func (m model) header() string {
+	var failing, degraded int
+	for _, h := range m.snap.Monitors {
+		switch h.Status {
+		case "error":
+			failing++
+		case "degraded":
+			degraded++
+		}
+	}
+	s := titleStyle.Render("openstatus top") + fmt.Sprintf("  %d monitors", len(m.snap.Monitors))
+	if failing > 0 {
+		s += "  " + errorStyle.Render(fmt.Sprintf("%d error", failing))
+	}
+	if degraded > 0 {
+		s += "  " + warnStyle.Render(fmt.Sprintf("%d degraded", degraded))
+	}
+	switch {
+	case m.loading:
+		s += faintStyle.Render("  loading…")
+	case !m.snap.FetchedAt.IsZero():
+		s += faintStyle.Render(fmt.Sprintf("  updated %s, every %s", m.snap.FetchedAt.Format("15:04:05"), m.interval))
+	}
+	return s
+}
+
+func (m model) viewMonitors(b *strings.Builder) {
+	fmt.Fprintln(b, m.header())
+	if m.err != nil {
+		fmt.Fprintln(b, errorStyle.Render("Error: "+m.err.Error()))
+	}
+	for _, w := range m.snap.Warnings {
+		fmt.Fprintln(b, warnStyle.Render("Warning: "+w))
+	}
+
+	if len(m.snap.Incidents) > 0 {
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, titleStyle.Render("Open status reports"))
+		for _, r := range m.snap.Incidents {
+			fmt.Fprintf(b, "  %s %s  %s\n", errorStyle.Render("▲"), truncate(r.Title, m.width-30), faintStyle.Render(r.Status+" · "+output.FormatTimestamp(r.UpdatedAt)))
+		}
+	}
+	if len(m.snap.Maintenances) > 0 {
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, titleStyle.Render("Maintenances in progress"))
+		for _, mt := range m.snap.Maintenances {
+			fmt.Fprintf(b, "  %s %s  %s\n", warnStyle.Render("◆"), truncate(mt.Title, m.width-30), faintStyle.Render("until "+output.FormatTimestamp(mt.To)))
+		}
+	}
+
+	fmt.Fprintln(b)
+	nameWidth := clamp(m.width-60, 12, 40)
+	fmt.Fprintln(b, faintStyle.Render(fmt.Sprintf("  %s %-4s %-10s %-*s %s", pad("Name", nameWidth), "Kind", "Status", latencySamples, "Latency", "Regions")))
+	rows := m.monitorRows()
+	end := min(len(m.snap.Monitors), m.offset+rows)
+	for i := m.offset; i < end; i++ {
+		h := m.snap.Monitors[i]
+		line := fmt.Sprintf("%s %s %-4s %s %s %s",
+			dot(h.Status),
+			pad(truncate(h.Name, nameWidth), nameWidth),
+			h.Kind,
+			statusStyle(h.Status).Render(fmt.Sprintf("%-10s", h.Status)),
+			sparkStyle.Render(pad(output.Sparkline(h.Latencies, latencySamples), latencySamples)),
+			regionSummary(h),
+		)
+		if i == m.cursor {
+			line = selectedStyle.Render(line)
+		}
+		fmt.Fprintln(b, line)
+	}
+	if len(m.snap.Monitors) == 0 && !m.loading {
+		fmt.Fprintln(b, faintStyle.Render("  No active monitors"))
+	}
+	fmt.Fprint(b, faintStyle.Render("↑/↓ move · enter open · r refresh · q quit"))
+}
+
+// regionSummary counts healthy regions and names the others.
+func regionSummary(h monitors.MonitorHealth) string {
+	if h.Error != "" {
+		return errorStyle.Render(h.Error)
+	}
+	if len(h.Regions) == 0 {
+		return faintStyle.Render("-")
+	}
+	healthy := 0
+	var failing []string
+	for _, r := range h.Regions {
+		if r.Status == "active" {
+			healthy++
+			continue
+		}
+		failing = append(failing, statusStyle(r.Status).Render(r.Region))
+	}
+	s := fmt.Sprintf("%d/%d ok", healthy, len(h.Regions))
+	if len(failing) > 0 {
+		s += " " + strings.Join(failing, " ")
+	}
+	return s
+}
+
+func (m model) viewMonitor(b *strings.Builder) {
+	h, ok := m.selectedMonitor()
+	if !ok {
+		fmt.Fprintln(b, errorStyle.Render("The monitor is no longer in the workspace"))
+		fmt.Fprint(b, faintStyle.Render("esc back · q quit"))
+		return
+	}
+	fmt.Fprintf(b, "%s %s  %s  %s\n", dot(h.Status), titleStyle.Render(h.Name), faintStyle.Render(h.Kind+" · id "+h.ID), h.URL)
+	fmt.Fprintln(b)
+
+	var regions []string
+	for _, r := range h.Regions {
+		regions = append(regions, dot(r.Status)+" "+r.Region)
+	}
+	fmt.Fprintf(b, "Regions  %s\n", strings.Join(regions, "  "))
+	if len(h.Latencies) > 0 {
+		lo, hi, sum := h.Latencies[0], h.Latencies[0], int64(0)
+		for _, l := range h.Latencies {
+			lo, hi, sum = min(lo, l), max(hi, l), sum+l
+		}
+		fmt.Fprintf(b, "Latency  %s  %s\n", sparkStyle.Render(output.Sparkline(h.Latencies, 0)),
+			faintStyle.Render(fmt.Sprintf("min %dms · avg %dms · max %dms · last %dms", lo, sum/int64(len(h.Latencies)), hi, h.Latencies[len(h.Latencies)-1])))
+	}
+	fmt.Fprintln(b)
+
+	switch {
+	case h.Kind != "http":
+		fmt.Fprintln(b, faintStyle.Render("Response logs are only available for HTTP monitors"))
+	case m.logsErr != nil:
+		fmt.Fprintln(b, errorStyle.Render("Error: "+m.logsErr.Error()))
+	case m.logs == nil:
+		fmt.Fprintln(b, faintStyle.Render("Loading recent checks…"))
+	case len(m.logs) == 0:
+		fmt.Fprintln(b, faintStyle.Render("No recent checks"))
+	default:
+		fmt.Fprintln(b, titleStyle.Render("Recent checks"))
+		rows := m.logRows()
+		end := min(len(m.logs), m.logOffset+rows)
+		for i := m.logOffset; i < end; i++ {
+			l := m.logs[i]
+			code := ""
+			if l.StatusCode != 0 {
+				code = fmt.Sprint(l.StatusCode)
+			}
+			line := fmt.Sprintf("%s %s %-8s %4s %6dms %-22s %s", dot(l.RequestStatus), output.FormatTimestamp(l.Timestamp), l.RequestStatus, code, l.Latency, l.Region, faintStyle.Render(l.ID))
+			if i == m.logCursor {
+				line = selectedStyle.Render(line)
+			}
+			fmt.Fprintln(b, line)
+		}
+	}
+	fmt.Fprint(b, faintStyle.Render("↑/↓ move · enter open check · esc back · r refresh · q quit"))
+}
+
+func (m model) viewLog(b *strings.Builder) {
+	switch {
+	case m.detailErr != nil:
+		fmt.Fprintln(b, errorStyle.Render("Error: "+m.detailErr.Error()))
+	case m.detail == nil:
+		fmt.Fprintln(b, faintStyle.Render("Loading check…"))
+	default:
+		d := m.detail
+		fmt.Fprintf(b, "%s %s  %s\n", dot(d.RequestStatus), titleStyle.Render(d.URL), faintStyle.Render(d.ID))
+		fmt.Fprintln(b)
+		rows := [][2]string{
+			{"Status", d.RequestStatus},
+			{"Status Code", fmt.Sprint(d.StatusCode)},
+			{"Latency", fmt.Sprintf("%d ms", d.Latency)},
+			{"Region", d.Region},
+			{"Trigger", d.Trigger},
+			{"Timestamp", output.FormatTimestamp(d.Timestamp)},
+		}
+		if d.Error || d.Message != "" {
+			rows = append(rows, [2]string{"Error", errorStyle.Render(d.Message)})
+		}
+		if t := d.Timing; t != nil {
+			rows = append(rows, [2]string{"Timing", fmt.Sprintf("dns %dms · connect %dms · tls %dms · ttfb %dms · transfer %dms", t.DNS, t.Connect, t.TLS, t.TTFB, t.Transfer)})
+		}
+		for _, r := range rows {
+			fmt.Fprintf(b, "%s %s\n", faintStyle.Render(pad(r[0], 12)), r[1])
+		}
+		if len(d.Headers) > 0 {
+			fmt.Fprintln(b)
+			fmt.Fprintln(b, titleStyle.Render("Response headers"))
+			keys := make([]string, 0, len(d.Headers))
+			for k := range d.Headers {
+				keys = append(keys, k)
+			}
+			slices.Sort(keys)
+			for _, k := range keys {
+				fmt.Fprintf(b, "%s %s\n", faintStyle.Render(k+":"), truncate(d.Headers[k], m.width-len(k)-2))
+			}
+		}
+	}
+	fmt.Fprint(b, faintStyle.Render("esc back · q quit"))
+}
@@ -0,0 +1,159 @@
+package top
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/openstatusHQ/cli/internal/monitors"
+)
+
+type fakeSource struct {
+	snap Snapshot
+	logs map[string][]monitors.ResponseLogEntry
+}
+
+func (f fakeSource) Snapshot(context.Context) (Snapshot, error) {
+	return f.snap, nil
+}
+
+func (f fakeSource) Logs(_ context.Context, monitorID string) ([]monitors.ResponseLogEntry, error) {
+	return f.logs[monitorID], nil
+}
+
+func (f fakeSource) LogDetail(_ context.Context, monitorID, logID string) (monitors.ResponseLogDetailOutput, error) {
+	for _, l := range f.logs[monitorID] {
+		if l.ID == logID {
+			return monitors.ResponseLogDetailOutput{ID: l.ID, URL: "https://api.example.com", RequestStatus: l.RequestStatus, Message: "connection reset"}, nil
+		}
+	}
+	return monitors.ResponseLogDetailOutput{}, errors.New("not found")
+}
+
+var testSource = fakeSource{
+	snap: Snapshot{
+		Monitors: []monitors.MonitorHealth{
+			{ID: "1", Name: "API", Kind: "http", Status: "error", Latencies: []int64{100, 200, 900},
+				Regions: []monitors.RegionStatusOutput{{Region: "iad", Status: "active"}, {Region: "fra", Status: "error"}}},
+			{ID: "2", Name: "Database", Kind: "tcp", Status: "active"},
+		},
+		Incidents:    []Incident{{ID: "7", Title: "API outage", Status: "investigating"}},
+		Maintenances: []Maintenance{{ID: "9", Title: "DB upgrade", To: "2026-05-01T10:00:00Z"}},
+		FetchedAt:    time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC),
+	},
+	logs: map[string][]monitors.ResponseLogEntry{
+		"1": {
+			{ID: "log-2", RequestStatus: "error", Region: "fra", Latency: 900},
+			{ID: "log-1", RequestStatus: "success", Region: "iad", StatusCode: 200, Latency: 100},
+		},
+	},
+}
+
+// send applies msg and the messages produced by its command, except ticks.
+func send(t *testing.T, m tea.Model, msg tea.Msg) tea.Model {
+	t.Helper()
+	m, cmd := m.Update(msg)
+	for _, next := range run(cmd) {
+		m = send(t, m, next)
+	}
+	return m
+}
+
+func run(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		var out []tea.Msg
+		for _, c := range msg {
+			out = append(out, run(c)...)
+		}
+		return out
+	case tickMsg, nil:
+		return nil
+	default:
+		return []tea.Msg{msg}
+	}
+}
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestModelNavigation(t *testing.T) {
+	t.Parallel()
+	var m tea.Model = newModel(context.Background(), testSource, time.Minute)
+	m = send(t, m, tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = send(t, m, snapshotMsg{snap: testSource.snap})
+
+	view := m.View()
+	for _, want := range []string{"2 monitors", "1 error", "API outage", "DB upgrade", "Database", "1/2 ok", "▁▁█"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("monitors view missing %q:\n%s", want, view)
+		}
+	}
+
+	m = send(t, m, key("j"))
+	m = send(t, m, key("k"))
+	m = send(t, m, key("enter"))
+	if got := m.(model); got.screen != screenMonitor || got.monitorID != "1" || len(got.logs) != 2 {
+		t.Fatalf("expected the logs of monitor 1, got screen %d, monitor %q, %d logs", got.screen, got.monitorID, len(got.logs))
+	}
+	if view := m.View(); !strings.Contains(view, "Recent checks") || !strings.Contains(view, "log-2") {
+		t.Errorf("monitor view missing the recent checks:\n%s", view)
+	}
+
+	m = send(t, m, key("enter"))
+	if got := m.(model); got.screen != screenLog || got.detail == nil || got.detail.ID != "log-2" {
+		t.Fatalf("expected the detail of log-2, got %+v", got.detail)
+	}
+	if view := m.View(); !strings.Contains(view, "connection reset") {
+		t.Errorf("log view missing the error message:\n%s", view)
+	}
+
+	m = send(t, m, key("esc"))
+	m = send(t, m, key("esc"))
+	if got := m.(model); got.screen != screenMonitors || got.cursor != 0 {
+		t.Errorf("expected to be back on the first monitor, got screen %d, cursor %d", got.screen, got.cursor)
+	}
+}
+
+func TestModelKeepsSelectionOnRefresh(t *testing.T) {
+	t.Parallel()
+	var m tea.Model = newModel(context.Background(), testSource, time.Minute)
+	m = send(t, m, snapshotMsg{snap: testSource.snap})
+	m = send(t, m, key("j"))
+
+	snap := testSource.snap
+	snap.Monitors = append([]monitors.MonitorHealth{{ID: "3", Name: "Auth", Kind: "http", Status: "active"}}, snap.Monitors...)
+	m = send(t, m, snapshotMsg{snap: snap})
+	if got := m.(model); got.snap.Monitors[got.cursor].ID != "2" {
+		t.Errorf("cursor moved to monitor %s, want 2", got.snap.Monitors[got.cursor].ID)
+	}
+}
+
+func TestScroll(t *testing.T) {
+	t.Parallel()
+	tests := []struct{ cursor, offset, rows, want int }{
+		{0, 0, 5, 0},
+		{5, 0, 5, 1},
+		{2, 4, 5, 2},
+		{3, 1, 5, 1},
+	}
+	for _, tt := range tests {
+		if got := scroll(tt.cursor, tt.offset, tt.rows); got != tt.want {
+			t.Errorf("scroll(%d, %d, %d) = %d, want %d", tt.cursor, tt.offset, tt.rows, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package top
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"buf.build/gen/go/openstatus/api/connectrpc/gosimple/openstatus/maintenance/v1/maintenancev1connect"
+	"buf.build/gen/go/openstatus/api/connectrpc/gosimple/openstatus/monitor/v1/monitorv1connect"
+	"buf.build/gen/go/openstatus/api/connectrpc/gosimple/openstatus/status_report/v1/status_reportv1connect"
+
+	"github.com/openstatusHQ/cli/internal/maintenance"
+	"github.com/openstatusHQ/cli/internal/monitors"
+	"github.com/openstatusHQ/cli/internal/statusreport"
+)
+
+type apiSource struct {
+	monitors     monitorv1connect.MonitorServiceClient
+	reports      status_reportv1connect.StatusReportServiceClient
+	maintenances maintenancev1connect.MaintenanceServiceClient
+}
+
+func newAPISource(apiKey string) *apiSource {
+	return &apiSource{
+		monitors:     monitors.NewMonitorClient(apiKey),
+		reports:      statusreport.NewStatusReportClient(apiKey),
+		maintenances: maintenance.NewMaintenanceClient(apiKey),
+	}
+}
+
+// Snapshot fetches monitors, open status reports and maintenances
+// concurrently. Only failing to list monitors is an error.
+func (s *apiSource) Snapshot(ctx context.Context) (Snapshot, error) {
+	snap := Snapshot{FetchedAt: time.Now()}
+	var (
+		wg                      sync.WaitGroup
+		monitorsErr, reportsErr error
+		maintenancesErr         error
+		reports                 []statusreport.StatusReportListEntry
+		maintenances            []maintenance.MaintenanceListEntry
+	)
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		snap.Monitors, monitorsErr = monitors.WorkspaceHealth(ctx, s.monitors, latencySamples)
+	}()
+	go func() {
+		defer wg.Done()
+		reports, reportsErr = statusreport.OpenStatusReports(ctx, s.reports)
+	}()
+	go func() {
+		defer wg.Done()
+		maintenances, maintenancesErr = maintenance.InProgressMaintenances(ctx, s.maintenances)
+	}()
+	wg.Wait()
+
+	if monitorsErr != nil {
+		return Snapshot{}, monitorsErr
+	}
+	if reportsErr != nil {
+		snap.Warnings = append(snap.Warnings, "status reports: "+reportsErr.Error())
+	}
+	if maintenancesErr != nil {
+		snap.Warnings = append(snap.Warnings, "maintenances: "+maintenancesErr.Error())
+	}
+	for _, r := range reports {
+		snap.Incidents = append(snap.Incidents, Incident{ID: r.ID, Title: r.Title, Status: r.Status, UpdatedAt: r.UpdatedAt})
+	}
+	for _, m := range maintenances {
+		snap.Maintenances = append(snap.Maintenances, Maintenance{ID: m.ID, Title: m.Title, From: m.From, To: m.To})
+	}
+	return snap, nil
+}
+
+func (s *apiSource) Logs(ctx context.Context, monitorID string) ([]monitors.ResponseLogEntry, error) {
+	return monitors.RecentResponseLogs(ctx, s.monitors, monitorID, recentLogs)
+}
+
+func (s *apiSource) LogDetail(ctx context.Context, monitorID, logID string) (monitors.ResponseLogDetailOutput, error) {
+	return monitors.GetResponseLogDetail(ctx, s.monitors, monitorID, logID)
+}
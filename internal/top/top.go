@@ -0,0 +1,120 @@
+// Package top implements openstatus top, a terminal dashboard of the
+// workspace health.
+package top
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/urfave/cli/v3"
+
+	"github.com/openstatusHQ/cli/internal/auth"
+	output "github.com/openstatusHQ/cli/internal/cli"
+	"github.com/openstatusHQ/cli/internal/monitors"
+)
+
+// latencySamples is the number of recent checks drawn in the sparklines.
+const latencySamples = 30
+
+// recentLogs is the number of response logs listed for a monitor.
+const recentLogs = 50
+
+type Incident struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type Maintenance struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// Snapshot is the state of the workspace at one refresh.
+type Snapshot struct {
+	Monitors     []monitors.MonitorHealth `json:"monitors"`
+	Incidents    []Incident               `json:"status_reports"`
+	Maintenances []Maintenance            `json:"maintenances"`
+	FetchedAt    time.Time                `json:"fetched_at"`
+	// Warnings lists the parts of the snapshot that could not be fetched.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Source provides the data shown by the dashboard.
+type Source interface {
+	Snapshot(ctx context.Context) (Snapshot, error)
+	Logs(ctx context.Context, monitorID string) ([]monitors.ResponseLogEntry, error)
+	LogDetail(ctx context.Context, monitorID, logID string) (monitors.ResponseLogDetailOutput, error)
+}
+
+// Run shows the dashboard until the user quits or ctx is cancelled.
+func Run(ctx context.Context, source Source, interval time.Duration) error {
+	p := tea.NewProgram(newModel(ctx, source, interval), tea.WithAltScreen(), tea.WithContext(ctx))
+	_, err := p.Run()
+	if errors.Is(err, tea.ErrInterrupted) || (ctx.Err() != nil && errors.Is(err, tea.ErrProgramKilled)) {
+		return nil
+	}
+	return err
+}
+
+func TopCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "top",
+		Usage: "Live dashboard of monitors, status reports and maintenances",
+		UsageText: `openstatus top
+  openstatus top --interval 10s
+  openstatus top --json`,
+		Description: `Shows every active monitor with its global and per-region status and a
+sparkline of its latest latencies, along with open status reports and
+maintenances in progress. The dashboard refreshes every --interval.
+
+Keys: ↑/↓ or j/k to move, enter to open a monitor and its recent checks,
+enter again to open a check, esc to go back, r to refresh, q to quit.
+
+With --json, a single snapshot is printed instead.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "access-token",
+				Usage:   "OpenStatus API Access Token",
+				Aliases: []string{"t"},
+				Sources: cli.EnvVars("OPENSTATUS_API_TOKEN"),
+			},
+			&cli.DurationFlag{
+				Name:  "interval",
+				Usage: "Refresh interval",
+				Value: 30 * time.Second,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			apiKey, err := auth.ResolveAccessToken(cmd)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			source := newAPISource(apiKey)
+
+			if output.IsJSONOutput() {
+				snap, err := source.Snapshot(ctx)
+				if err != nil {
+					return cli.Exit(err.Error(), 1)
+				}
+				return output.PrintJSON(snap)
+			}
+			if !output.IsTerminal() || !output.IsStdinTerminal() {
+				return cli.Exit("openstatus top needs an interactive terminal; use --json for a single snapshot", 1)
+			}
+			if interval := cmd.Duration("interval"); interval < time.Second {
+				return cli.Exit(fmt.Sprintf("--interval must be at least 1s, got %s", interval), 1)
+			}
+			if err := Run(ctx, source, cmd.Duration("interval")); err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			return nil
+		},
+	}
+}